@@ -55,8 +55,19 @@ const (
 
 	// ErrUnknownService is thrown when there is no service matching
 	// the http request method and URI
+	//
+	// Deprecated: the server now responds with ErrUnknownPath or
+	// ErrMethodNotAllowed
 	ErrUnknownService = Err("503:unknown service")
 
+	// ErrUnknownPath is thrown when there is no service matching the http
+	// request URI whatever its method
+	ErrUnknownPath = Err("404:unknown path")
+
+	// ErrMethodNotAllowed is thrown when services match the http request URI
+	// but none of them with the request method
+	ErrMethodNotAllowed = Err("405:method not allowed")
+
 	// ErrUncallableService is thrown when there the requested service's handler
 	// is not found/callable
 	ErrUncallableService = Err("503:uncallable service")
@@ -89,6 +100,54 @@ const (
 	ErrBodyTooLarge = Err("413:request too large")
 )
 
+// ParamError is the error of a single invalid or missing parameter
+type ParamError struct {
+	// Param is the name of the parameter in the configuration, e.g. "GET@id"
+	Param string
+	// Field is the name of the parameter in handlers, e.g. "ID"
+	Field string
+	// Err is either ErrInvalidParam or ErrMissingParam
+	Err error
+	// Reason describes why the value is invalid, nil when unknown
+	Reason error
+}
+
+// Error implements the error interface, e.g. "ID: not an integer: invalid
+// parameter"
+func (e ParamError) Error() string {
+	var message = e.Err.Error()
+	if e.Reason != nil {
+		message = fmt.Sprintf("%s: %s", e.Reason, message)
+	}
+	if len(e.Field) > 0 {
+		message = fmt.Sprintf("%s: %s", e.Field, message)
+	}
+	return message
+}
+
+// Unwrap returns the api error
+func (e ParamError) Unwrap() error {
+	return e.Err
+}
+
+// ParamErrors lists the errors of every invalid or missing parameter of a
+// request, c.f. aicra.Builder.SetCollectErrors()
+type ParamErrors []ParamError
+
+// Error implements the error interface
+func (errs ParamErrors) Error() string {
+	var messages = make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Status returns the associated http status code
+func (errs ParamErrors) Status() int {
+	return http.StatusBadRequest
+}
+
 // GetErrorStatus returns the http status associated with a given error if the
 // error type implements the interface{ Status() int }
 func GetErrorStatus(err error) int {
@@ -93,6 +93,8 @@ func TestError_GetStatusHelper(t *testing.T) {
 		{"delete error", api.ErrDelete, http.StatusInternalServerError},
 		{"transactional error", api.ErrTransaction, http.StatusInternalServerError},
 		{"unknown service", api.ErrUnknownService, http.StatusServiceUnavailable},
+		{"unknown path", api.ErrUnknownPath, http.StatusNotFound},
+		{"method not allowed", api.ErrMethodNotAllowed, http.StatusMethodNotAllowed},
 		{"uncallable service", api.ErrUncallableService, http.StatusServiceUnavailable},
 		{"not implemented", api.ErrNotImplemented, http.StatusNotImplemented},
 		{"unauthorized", api.ErrUnauthorized, http.StatusUnauthorized},
@@ -2,6 +2,7 @@ package aicra_test
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -243,6 +244,27 @@ func outIntHandler(context.Context, struct{ ID int }) (*struct{}, error) {
 	return nil, nil
 }
 
+// handwritten codecs equivalent to the generated ones
+var noOpIntCodec = aicra.Codec[struct{ ID int }, struct{}]{
+	Decode: func(in map[string]interface{}) (req struct{ ID int }) {
+		if v, ok := in["ID"]; ok {
+			req.ID = v.(int)
+		}
+		return req
+	},
+	Encode: func(*struct{}) map[string]interface{} {
+		return nil
+	},
+}
+var outCodec = aicra.Codec[struct{}, struct{ ID int }]{
+	Decode: func(map[string]interface{}) (req struct{}) {
+		return req
+	},
+	Encode: func(res *struct{ ID int }) map[string]interface{} {
+		return map[string]interface{}{"ID": res.ID}
+	},
+}
+
 func Benchmark1StaticRouteMatch(b *testing.B) {
 	builder := &aicra.Builder{}
 
@@ -302,6 +324,37 @@ func Benchmark1StaticOutRouteMatch(b *testing.B) {
 		srv.ServeHTTP(res, req)
 	}
 }
+func Benchmark1StaticOutCodecRouteMatch(b *testing.B) {
+	builder := &aicra.Builder{}
+
+	if err := builder.Output("int", int(0)); err != nil {
+		b.Fatalf("cannot set output type: %s", err)
+	}
+	err := builder.RespondWith(func(w http.ResponseWriter, data map[string]interface{}, err error) {})
+	if err != nil {
+		b.Fatalf("cannot set responder: %s", err)
+	}
+	err = builder.Setup(strings.NewReader(staticOutConfig))
+	if err != nil {
+		b.Fatalf("cannot setup: %s", err)
+	}
+	err = aicra.BindCodec(builder, "GET", "/users/123", outHandler, outCodec)
+	if err != nil {
+		b.Fatalf("cannot bind: %s", err)
+	}
+	srv, err := builder.Build()
+	if err != nil {
+		b.Fatalf("cannot build: %s", err)
+	}
+
+	req, _ := http.NewRequest("GET", "/users/123", nil)
+	res := httptest.NewRecorder()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		srv.ServeHTTP(res, req)
+	}
+}
 
 func Benchmark1OverNStaticRouteMatch(b *testing.B) {
 	builder := &aicra.Builder{}
@@ -373,6 +426,38 @@ func Benchmark1UriRouteMatch(b *testing.B) {
 	}
 }
 
+func Benchmark1UriCodecRouteMatch(b *testing.B) {
+	builder := &aicra.Builder{}
+
+	if err := builder.Input(validator.IntType{}); err != nil {
+		b.Fatalf("cannot bind: %s", err)
+	}
+	err := builder.RespondWith(func(w http.ResponseWriter, data map[string]interface{}, err error) {})
+	if err != nil {
+		b.Fatalf("cannot set responder: %s", err)
+	}
+	err = builder.Setup(strings.NewReader(uriConfig))
+	if err != nil {
+		b.Fatalf("cannot setup: %s", err)
+	}
+	err = aicra.BindCodec(builder, "GET", "/users/{id}", noOpIntHandler, noOpIntCodec)
+	if err != nil {
+		b.Fatalf("cannot bind: %s", err)
+	}
+	srv, err := builder.Build()
+	if err != nil {
+		b.Fatalf("cannot build: %s", err)
+	}
+
+	req, _ := http.NewRequest("GET", "/users/123", nil)
+	res := httptest.NewRecorder()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		srv.ServeHTTP(res, req)
+	}
+}
+
 func Benchmark1OverNUriRouteMatch(b *testing.B) {
 	builder := &aicra.Builder{}
 
@@ -721,3 +806,78 @@ func Benchmark1OverNMultipartRouteMatch(b *testing.B) {
 		srv.ServeHTTP(res, req)
 	}
 }
+
+// largeConfig generates a configuration with `n` static services and `n` uri
+// services to measure routing cost over a large API
+func largeConfig(n int) (string, [][2]string) {
+	var (
+		services = make([]string, 0, 2*n)
+		routes   = make([][2]string, 0, 2*n)
+	)
+	for i := 0; i < n; i++ {
+		static := fmt.Sprintf("/resource%d/items", i)
+		uri := fmt.Sprintf("/resource%d/items/{id}", i)
+		services = append(services,
+			fmt.Sprintf(`{ "method": "GET", "path": %q, "scope": [], "info": "info", "in": {}, "out": {} }`, static),
+			fmt.Sprintf(`{ "method": "GET", "path": %q, "scope": [], "info": "info", "in": { "{id}": {"info":"info","name":"ID","type":"int"} }, "out": {} }`, uri),
+		)
+		routes = append(routes, [2]string{"GET", static}, [2]string{"GET", uri})
+	}
+	return "[" + strings.Join(services, ",") + "]", routes
+}
+
+func benchmarkLargeRouteMatch(b *testing.B, uri string) {
+	builder := &aicra.Builder{}
+
+	if err := builder.Input(validator.IntType{}); err != nil {
+		b.Fatalf("cannot bind: %s", err)
+	}
+	err := builder.RespondWith(func(w http.ResponseWriter, data map[string]interface{}, err error) {})
+	if err != nil {
+		b.Fatalf("cannot set responder: %s", err)
+	}
+
+	conf, routes := largeConfig(200)
+	err = builder.Setup(strings.NewReader(conf))
+	if err != nil {
+		b.Fatalf("cannot setup: %s", err)
+	}
+	for _, route := range routes {
+		var err error
+		if strings.HasSuffix(route[1], "{id}") {
+			err = aicra.Bind(builder, route[0], route[1], noOpIntHandler)
+		} else {
+			err = aicra.Bind(builder, route[0], route[1], noOpHandler)
+		}
+		if err != nil {
+			b.Fatalf("cannot bind: %s", err)
+		}
+	}
+	srv, err := builder.Build()
+	if err != nil {
+		b.Fatalf("cannot build: %s", err)
+	}
+
+	req, _ := http.NewRequest("GET", uri, nil)
+	res := httptest.NewRecorder()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		srv.ServeHTTP(res, req)
+	}
+}
+
+// the routing tree makes the first and last services of the configuration cost
+// the same, it used to be proportional to the service position
+func Benchmark400FirstStaticRouteMatch(b *testing.B) {
+	benchmarkLargeRouteMatch(b, "/resource0/items")
+}
+func Benchmark400LastStaticRouteMatch(b *testing.B) {
+	benchmarkLargeRouteMatch(b, "/resource199/items")
+}
+func Benchmark400FirstUriRouteMatch(b *testing.B) {
+	benchmarkLargeRouteMatch(b, "/resource0/items/123")
+}
+func Benchmark400LastUriRouteMatch(b *testing.B) {
+	benchmarkLargeRouteMatch(b, "/resource199/items/123")
+}
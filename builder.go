@@ -1,7 +1,9 @@
 package aicra
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,6 +11,8 @@ import (
 
 	"github.com/xdrm-io/aicra/internal/config"
 	"github.com/xdrm-io/aicra/internal/dynfunc"
+	"github.com/xdrm-io/aicra/internal/openapi"
+	"github.com/xdrm-io/aicra/internal/reference"
 	"github.com/xdrm-io/aicra/validator"
 )
 
@@ -31,6 +35,17 @@ type Builder struct {
 	respond Responder
 	// user-defined handlers bound to services from the configuration
 	handlers []*serviceHandler
+	// router is the routing tree compiled from the configuration at Build()
+	router *config.Router
+	// callables indexes handlers by their service, filled at Build()
+	callables map[*config.Service]*serviceHandler
+
+	// cors defines the cross-origin resource sharing policy, disabled when nil
+	cors *CORS
+	// corsPolicies indexes the cors policy of every service, filled at Build()
+	corsPolicies map[*config.Service]*corsPolicy
+	// corsPolicy applies to requests matching no service, filled at Build()
+	corsPolicy *corsPolicy
 	// http middlewares wrapping the entire http connection (e.g. logger)
 	middlewares []func(http.Handler) http.Handler
 	// custom middlewares only wrapping the service handler of a request
@@ -46,6 +61,9 @@ type Builder struct {
 	// exceeding `bodyLimit` (in bytes). Negative value means there is no
 	// limit. The default value (0) falls back to the default aicra limit
 	bodyLimit int64
+	// collectErrors makes requests validate every input parameter and respond
+	// with all the invalid or missing ones instead of the first one
+	collectErrors bool
 }
 
 // serviceHandler links a handler func to a service (method-path combination)
@@ -67,6 +85,20 @@ func (b *Builder) SetBodyLimit(size int64) {
 	b.bodyLimit = size
 }
 
+// SetCollectErrors defines whether every input parameter is validated before
+// responding to an invalid request. When enabled, the response error is an
+// api.ParamErrors listing all the invalid or missing parameters instead of
+// the first one.
+func (b *Builder) SetCollectErrors(collect bool) {
+	b.collectErrors = collect
+}
+
+// SetCORS enables cross-origin resource sharing with the given policy.
+// Preflight requests are automatically answered from the configuration.
+func (b *Builder) SetCORS(cors CORS) {
+	b.cors = &cors
+}
+
 // Input adds an available validator for input arguments
 func (b *Builder) Input(t validator.Type) error {
 	if b.conf == nil {
@@ -95,6 +127,19 @@ func (b *Builder) Output(name string, sample interface{}) error {
 	return nil
 }
 
+// Method makes a custom http method available for services in addition to
+// the standard ones, e.g. Method("PURGE") or Method("PROPFIND"). Custom methods
+// go through the same validation and input extraction as the standard ones.
+func (b *Builder) Method(name string) error {
+	if b.conf == nil {
+		b.conf = &config.Server{}
+	}
+	if b.conf.Services != nil {
+		return errLateMethod
+	}
+	return b.conf.AddMethod(name)
+}
+
 // RespondWith defines the server responder, i.e. how to write data and error
 // into the http response.
 func (b *Builder) RespondWith(responder Responder) error {
@@ -145,24 +190,48 @@ func (b *Builder) Setup(r io.Reader) error {
 
 // Bind a dynamic handler to a REST service (method and pattern)
 func Bind[Req, Res any](b *Builder, method, path string, fn HandlerFunc[Req, Res]) error {
-	if b.conf == nil || b.conf.Services == nil {
-		return errNotSetup
+	service, err := b.findService(method, path)
+	if err != nil {
+		return err
 	}
 
-	// find associated service from config
-	var service *config.Service
-	for _, s := range b.conf.Services {
-		if method == s.Method && path == s.Pattern {
-			service = s
-			break
-		}
+	callable, err := dynfunc.Build(service, dynfunc.HandlerFunc[Req, Res](fn))
+	if err != nil {
+		return fmt.Errorf("%s %q handler: %w", method, path, err)
+	}
+
+	b.handlers = append(b.handlers, &serviceHandler{
+		Path:     path,
+		Method:   method,
+		callable: callable,
+	})
+	return nil
+}
+
+// Codec converts input data into a handler request and a handler response into
+// output data without reflection. Codecs are usually generated from the
+// configuration with the aicra command, c.f. BindCodec()
+type Codec[Req, Res any] struct {
+	// Decode builds the request from input data indexed by their "name"
+	Decode func(map[string]interface{}) Req
+	// Encode returns output data indexed by their "name" from a response
+	Encode func(*Res) map[string]interface{}
+}
+
+// BindCodec binds a handler to a REST service like Bind but uses the codec to
+// convert data instead of reflection. Request and response types are still
+// checked against the configuration.
+func BindCodec[Req, Res any](b *Builder, method, path string, fn HandlerFunc[Req, Res], codec Codec[Req, Res]) error {
+	if codec.Decode == nil || codec.Encode == nil {
+		return fmt.Errorf("%s %q: %w", method, path, errIncompleteCodec)
 	}
 
-	if service == nil {
-		return fmt.Errorf("%s %q: %w", method, path, errUnknownService)
+	service, err := b.findService(method, path)
+	if err != nil {
+		return err
 	}
 
-	var callable, err = dynfunc.Build(service, dynfunc.HandlerFunc[Req, Res](fn))
+	callable, err := dynfunc.BuildCodec(service, dynfunc.HandlerFunc[Req, Res](fn), codec.Decode, codec.Encode)
 	if err != nil {
 		return fmt.Errorf("%s %q handler: %w", method, path, err)
 	}
@@ -172,10 +241,58 @@ func Bind[Req, Res any](b *Builder, method, path string, fn HandlerFunc[Req, Res
 		Method:   method,
 		callable: callable,
 	})
-
 	return nil
 }
 
+// findService returns the service matching a method and a path from the
+// configuration
+func (b *Builder) findService(method, path string) (*config.Service, error) {
+	if b.conf == nil || b.conf.Services == nil {
+		return nil, errNotSetup
+	}
+
+	for _, s := range b.conf.Services {
+		if method == s.Method && path == s.Pattern {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("%s %q: %w", method, path, errUnknownService)
+}
+
+// OpenAPI writes the OpenAPI 3.1 document describing the configuration as
+// json. Custom input types can describe their values by implementing
+// validator.SchemaType, values of other types are described from their go type.
+func (b *Builder) OpenAPI(w io.Writer, title, version string) error {
+	if b.conf == nil || b.conf.Services == nil {
+		return errNotSetup
+	}
+	doc := openapi.Export(b.conf, openapi.Info{Title: title, Version: version})
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// Reference returns an http handler serving the searchable html reference of
+// the configuration: services grouped by path prefix with their scopes, inputs
+// by location, outputs and named types. It is rendered once, the handler can
+// be mounted on any route, e.g. with a mux next to the built handler.
+func (b *Builder) Reference(title string) (http.Handler, error) {
+	if b.conf == nil || b.conf.Services == nil {
+		return nil, errNotSetup
+	}
+	var page bytes.Buffer
+	if err := reference.Render(&page, b.conf, title); err != nil {
+		return nil, err
+	}
+
+	var content = page.Bytes()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(content)
+	}), nil
+}
+
 // Build a fully-featured HTTP server
 func (b Builder) Build() (http.Handler, error) {
 	if b.uriLimit == 0 {
@@ -189,18 +306,27 @@ func (b Builder) Build() (http.Handler, error) {
 		b.respond = DefaultResponder
 	}
 
+	b.callables = make(map[*config.Service]*serviceHandler, len(b.conf.Services))
 	for _, service := range b.conf.Services {
-		var isHandled bool
 		for _, handler := range b.handlers {
 			if handler.Method == service.Method && handler.Path == service.Pattern {
-				isHandled = true
+				b.callables[service] = handler
 				break
 			}
 		}
-		if !isHandled {
+		if _, isHandled := b.callables[service]; !isHandled {
 			return nil, fmt.Errorf("%s %q: %w", service.Method, service.Pattern, errMissingHandler)
 		}
 	}
 
+	if b.cors != nil {
+		b.corsPolicies = make(map[*config.Service]*corsPolicy, len(b.conf.Services))
+		for _, service := range b.conf.Services {
+			b.corsPolicies[service] = newCORSPolicy(*b.cors, service)
+		}
+		b.corsPolicy = newCORSPolicy(*b.cors, &config.Service{})
+	}
+
+	b.router = config.NewRouter(b.conf.Services)
 	return Handler(b), nil
 }
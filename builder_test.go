@@ -2,11 +2,15 @@ package aicra
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/xdrm-io/aicra/internal/config"
 	"github.com/xdrm-io/aicra/internal/dynfunc"
 	"github.com/xdrm-io/aicra/validator"
 )
@@ -77,6 +81,28 @@ func TestAddOutputType(t *testing.T) {
 	}
 }
 
+func TestAddMethod(t *testing.T) {
+	t.Parallel()
+
+	builder := &Builder{}
+	err := builder.Method("PURGE")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	err = builder.Method("purge")
+	if !errors.Is(err, config.ErrInvalidMethod) {
+		t.Fatalf("expected <%v> got <%v>", config.ErrInvalidMethod, err)
+	}
+	err = builder.Setup(strings.NewReader(`[ { "method": "PURGE", "path": "/", "info": "info" } ]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	err = builder.Method("PROPFIND")
+	if err != errLateMethod {
+		t.Fatalf("expected <%v> got <%v>", errLateMethod, err)
+	}
+}
+
 func TestNilResponder(t *testing.T) {
 	t.Parallel()
 
@@ -387,6 +413,7 @@ func TestBind(t *testing.T) {
 	}
 
 	for _, tc := range tt {
+		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
@@ -415,3 +442,303 @@ func TestBind(t *testing.T) {
 		})
 	}
 }
+
+func TestBindObjectMismatch(t *testing.T) {
+	const config = `[
+		{
+			"method": "POST",
+			"path": "/users",
+			"info": "info",
+			"scope": [],
+			"in":  {
+				"address": { "info": "info", "type": "object", "name": "Address", "fields": {
+					"city": { "info": "info", "type": "string", "name": "City" }
+				} }
+			},
+			"out": {}
+		}
+	]`
+
+	builder := &Builder{}
+	if err := addBuiltinTypes(builder); err != nil {
+		t.Fatalf("unexpected error <%v>", err)
+	}
+	if err := builder.Setup(strings.NewReader(config)); err != nil {
+		t.Fatalf("setup: unexpected error <%v>", err)
+	}
+	type req struct {
+		Address struct{ Town string }
+	}
+	err := Bind(builder, http.MethodPost, "/users", func(_ context.Context, r req) (*struct{}, error) {
+		return nil, nil
+	})
+	if !errors.Is(err, dynfunc.ErrMissingField) {
+		t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, dynfunc.ErrMissingField)
+	}
+}
+
+func TestBindCodec(t *testing.T) {
+	t.Parallel()
+
+	const conf = `[
+		{
+			"method": "GET",
+			"path": "/users/{id}",
+			"scope": [],
+			"info": "info",
+			"in": {
+				"{id}": { "info": "info", "type": "int", "name": "ID" }
+			},
+			"out": {
+				"name": { "info": "info", "type": "string", "name": "Name" }
+			}
+		}
+	]`
+
+	type req struct{ ID int }
+	type res struct{ Name string }
+
+	var (
+		fn = func(_ context.Context, r req) (*res, error) {
+			return &res{Name: fmt.Sprintf("user-%d", r.ID)}, nil
+		}
+		codec = Codec[req, res]{
+			Decode: func(in map[string]interface{}) (r req) {
+				if v, ok := in["ID"]; ok {
+					r.ID = v.(int)
+				}
+				return r
+			},
+			Encode: func(r *res) map[string]interface{} {
+				return map[string]interface{}{"Name": r.Name}
+			},
+		}
+	)
+
+	tt := []struct {
+		name   string
+		path   string
+		codec  Codec[req, res]
+		err    error
+		output string
+	}{
+		{
+			name:  "missing decode",
+			path:  "/users/{id}",
+			codec: Codec[req, res]{Encode: codec.Encode},
+			err:   errIncompleteCodec,
+		},
+		{
+			name:  "missing encode",
+			path:  "/users/{id}",
+			codec: Codec[req, res]{Decode: codec.Decode},
+			err:   errIncompleteCodec,
+		},
+		{
+			name:  "unknown service",
+			path:  "/users",
+			codec: codec,
+			err:   errUnknownService,
+		},
+		{
+			name:   "valid",
+			path:   "/users/{id}",
+			codec:  codec,
+			output: `{"name":"user-123"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := &Builder{}
+			if err := addBuiltinTypes(builder); err != nil {
+				t.Fatalf("add built-in types: %s", err)
+			}
+			err := builder.RespondWith(func(w http.ResponseWriter, data map[string]interface{}, err error) {
+				json.NewEncoder(w).Encode(data)
+			})
+			if err != nil {
+				t.Fatalf("responder: unexpected error <%v>", err)
+			}
+			if err := builder.Setup(strings.NewReader(conf)); err != nil {
+				t.Fatalf("setup: unexpected error <%v>", err)
+			}
+
+			err = BindCodec(builder, http.MethodGet, tc.path, fn, tc.codec)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid bind error\nactual: %v\nexpect: %v", err, tc.err)
+			}
+			if err != nil {
+				return
+			}
+
+			handler, err := builder.Build()
+			if err != nil {
+				t.Fatalf("build: unexpected error <%v>", err)
+			}
+			var (
+				request  = httptest.NewRequest(http.MethodGet, "/users/123", nil)
+				response = httptest.NewRecorder()
+			)
+			handler.ServeHTTP(response, request)
+
+			if output := strings.TrimSpace(response.Body.String()); output != tc.output {
+				t.Fatalf("invalid response\nactual: %s\nexpect: %s", output, tc.output)
+			}
+		})
+	}
+}
+
+func TestBindCodecInvalidHandler(t *testing.T) {
+	t.Parallel()
+
+	builder := &Builder{}
+	if err := addBuiltinTypes(builder); err != nil {
+		t.Fatalf("add built-in types: %s", err)
+	}
+	err := builder.Setup(strings.NewReader(`[
+		{
+			"method": "GET",
+			"path": "/path",
+			"scope": [[]],
+			"info": "info",
+			"in": {},
+			"out": {}
+		}
+	]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	type req struct{ Unexpected int }
+	codec := Codec[req, struct{}]{
+		Decode: func(map[string]interface{}) (r req) { return r },
+		Encode: func(*struct{}) map[string]interface{} { return nil },
+	}
+	fn := func(context.Context, req) (*struct{}, error) {
+		return nil, nil
+	}
+	err = BindCodec(builder, http.MethodGet, "/path", fn, codec)
+	if !errors.Is(err, dynfunc.ErrUnexpectedFields) {
+		t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, dynfunc.ErrUnexpectedFields)
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	builder := &Builder{}
+	if err := addBuiltinTypes(builder); err != nil {
+		t.Fatalf("add built-in types: %s", err)
+	}
+
+	var out strings.Builder
+	if err := builder.OpenAPI(&out, "title", "1.0.0"); err != errNotSetup {
+		t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, errNotSetup)
+	}
+
+	err := builder.Setup(strings.NewReader(`[
+		{
+			"method": "GET",
+			"path": "/users/{id}",
+			"info": "info",
+			"scope": [],
+			"in": {
+				"{id}": { "info": "info", "type": "int", "name": "ID" }
+			},
+			"out": {}
+		}
+	]`))
+	if err != nil {
+		t.Fatalf("setup: unexpected error <%v>", err)
+	}
+	if err := builder.OpenAPI(&out, "title", "1.0.0"); err != nil {
+		t.Fatalf("unexpected error <%v>", err)
+	}
+
+	var doc struct {
+		OpenAPI string                            `json:"openapi"`
+		Paths   map[string]map[string]interface{} `json:"paths"`
+	}
+	if err := json.Unmarshal([]byte(out.String()), &doc); err != nil {
+		t.Fatalf("invalid json: %s", err)
+	}
+	if _, ok := doc.Paths["/users/{id}"]["get"]; !ok {
+		t.Fatalf("missing operation GET /users/{id} in\n%s", out.String())
+	}
+}
+
+func TestReference(t *testing.T) {
+	t.Parallel()
+
+	builder := &Builder{}
+	if err := addBuiltinTypes(builder); err != nil {
+		t.Fatalf("add built-in types: %s", err)
+	}
+
+	if _, err := builder.Reference("title"); err != errNotSetup {
+		t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, errNotSetup)
+	}
+
+	err := builder.Setup(strings.NewReader(`[
+		{
+			"method": "GET",
+			"path": "/users/{id}",
+			"info": "fetches a user",
+			"scope": [["user[ID]"]],
+			"in": {
+				"{id}": { "info": "info", "type": "int", "name": "ID" }
+			},
+			"out": {}
+		}
+	]`))
+	if err != nil {
+		t.Fatalf("setup: unexpected error <%v>", err)
+	}
+	handler, err := builder.Reference("title")
+	if err != nil {
+		t.Fatalf("unexpected error <%v>", err)
+	}
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if ct := res.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Fatalf("invalid content type\nactual: %q\nexpect: %q", ct, "text/html")
+	}
+	if !strings.Contains(res.Body.String(), "fetches a user") {
+		t.Fatalf("missing service in\n%s", res.Body.String())
+	}
+}
+
+func TestSetupErrors(t *testing.T) {
+	t.Parallel()
+
+	const conf = `[
+	{ "method": "GET", "path": "/a", "info": "" },
+	{ "method": "GET", "path": "/b", "info": "info", "in": {
+		"a": { "info": "info", "type": "unknown" }
+	} }
+]`
+	builder := &Builder{}
+	if err := addBuiltinTypes(builder); err != nil {
+		t.Fatalf("unexpected error <%v>", err)
+	}
+	err := builder.Setup(strings.NewReader(conf))
+
+	var errs ConfigErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ConfigErrors, got %v", err)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("invalid errors count\nactual: %d\nexpect: %d", len(errs), 2)
+	}
+	if !errors.Is(err, config.ErrMissingDescription) || !errors.Is(err, config.ErrUnknownParamType) {
+		t.Fatalf("missing errors in %v", err)
+	}
+	if errs[1].Line != 4 || errs[1].Param != "a" {
+		t.Fatalf("invalid location\nactual: line %d param %q\nexpect: line 4 param %q", errs[1].Line, errs[1].Param, "a")
+	}
+}
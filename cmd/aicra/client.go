@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/xdrm-io/aicra/internal/codegen"
+)
+
+// client writes a go client of the api with a method calling each service
+func client(args []string) error {
+	var (
+		fs     = newFlagSet("client")
+		conf   configFlags
+		output = fs.String("o", "", "output `file`, defaults to the standard output")
+		pkg    = fs.String("package", "client", "`name` of the generated package")
+	)
+	conf.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	path, err := configPath(fs)
+	if err != nil {
+		return err
+	}
+	srv, types, err := conf.load(path)
+	if err != nil {
+		return err
+	}
+
+	var code bytes.Buffer
+	if err := codegen.GenerateClient(&code, srv, types, *pkg); err != nil {
+		return err
+	}
+	if len(*output) < 1 {
+		_, err = os.Stdout.Write(code.Bytes())
+		return err
+	}
+	return os.WriteFile(*output, code.Bytes(), 0o644)
+}
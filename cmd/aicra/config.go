@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/xdrm-io/aicra/internal/codegen"
+	"github.com/xdrm-io/aicra/internal/config"
+)
+
+// configFlags are the flags shared by commands reading a configuration
+type configFlags struct {
+	types   stringsFlag
+	methods stringsFlag
+}
+
+// register the flags into a flag set
+func (c *configFlags) register(fs *flag.FlagSet) {
+	fs.Var(&c.types, "type", "custom `typename=gotype`, e.g. uuid=github.com/google/uuid.UUID, or opaque typename of type any (repeatable)")
+	fs.Var(&c.methods, "method", "custom http `method`, e.g. PURGE (repeatable)")
+}
+
+// load parses and validates the configuration file with the builtin types and
+// custom types
+func (c *configFlags) load(path string) (*config.Server, *codegen.Types, error) {
+	srv, types, err := c.server()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	if err := srv.Parse(file); err != nil {
+		var errs config.Errors
+		if errors.As(err, &errs) {
+			return nil, nil, fmt.Errorf("%s:\n%w", path, errs)
+		}
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return srv, types, nil
+}
+
+// server returns an empty server with the builtin types, custom types and
+// custom methods. Custom types without go type are opaque values of type any
+func (c *configFlags) server() (*config.Server, *codegen.Types, error) {
+	var types = &codegen.Types{}
+	for _, def := range c.types {
+		typename, ref, found := strings.Cut(def, "=")
+		if !found {
+			ref = "any"
+		}
+		if len(typename) < 1 {
+			return nil, nil, fmt.Errorf("-type %q: expected typename=gotype", def)
+		}
+		ext, err := codegen.ParseExternal(ref)
+		if err != nil {
+			return nil, nil, fmt.Errorf("-type %q: %w", def, err)
+		}
+		types.Add(typename, ext)
+	}
+
+	var srv = types.Server()
+	for _, method := range c.methods {
+		if err := srv.AddMethod(method); err != nil {
+			return nil, nil, fmt.Errorf("-method: %w", err)
+		}
+	}
+	return srv, types, nil
+}
+
+// configPath returns the configuration path from the command arguments
+func configPath(fs *flag.FlagSet) (string, error) {
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return "", fmt.Errorf("expected a configuration file")
+	}
+	return fs.Arg(0), nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/xdrm-io/aicra/internal/diff"
+)
+
+// exitBreaking is the exit status of the diff command when some changes break
+// clients, it differs from the status of other failures so that CI can tell
+// an invalid configuration from a breaking one
+const exitBreaking = 3
+
+// compare reports the changes between two versions of a configuration and
+// fails when some of them break clients
+func compare(args []string) error {
+	var (
+		fs   = newFlagSet("diff")
+		conf configFlags
+	)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: aicra diff [flags] <previous.json> <next.json>\n\nflags:\n")
+		fs.PrintDefaults()
+	}
+	conf.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("expected two configuration files")
+	}
+
+	prev, _, err := conf.load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	next, _, err := conf.load(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	changes := diff.Compare(prev, next)
+	for _, change := range changes {
+		fmt.Fprintf(os.Stdout, "%-12s  %s\n", change.Kind(), change)
+	}
+
+	if count := changes.Breaking(); count > 0 {
+		return statusError{status: exitBreaking, err: fmt.Errorf("%d breaking change(s)", count)}
+	}
+	return nil
+}
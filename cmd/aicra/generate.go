@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/xdrm-io/aicra/internal/codegen"
+)
+
+// generate writes the request and response types along with reflection-free
+// codecs of every service
+func generate(args []string) error {
+	var (
+		fs     = newFlagSet("generate")
+		conf   configFlags
+		output = fs.String("o", "", "output `file`, defaults to the standard output")
+		pkg    = fs.String("package", "api", "`name` of the generated package")
+	)
+	conf.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	path, err := configPath(fs)
+	if err != nil {
+		return err
+	}
+	srv, types, err := conf.load(path)
+	if err != nil {
+		return err
+	}
+
+	var code bytes.Buffer
+	if err := codegen.GenerateCodecs(&code, srv, types, *pkg); err != nil {
+		return err
+	}
+	if len(*output) < 1 {
+		_, err = os.Stdout.Write(code.Bytes())
+		return err
+	}
+	return os.WriteFile(*output, code.Bytes(), 0o644)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/xdrm-io/aicra/internal/openapi/importer"
+)
+
+// importOpenAPI converts an OpenAPI document into an aicra configuration,
+// constructs without an equivalent are reported on the standard error
+func importOpenAPI(args []string) error {
+	var (
+		fs     = newFlagSet("import")
+		conf   configFlags
+		output = fs.String("o", "", "output `file`, defaults to the standard output")
+	)
+	conf.register(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: aicra import [flags] <openapi.json|openapi.yaml>\n\nflags:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	path, err := configPath(fs)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	imported, issues, err := importer.Import(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for _, issue := range issues {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", path, issue)
+	}
+
+	encoded, err := json.MarshalIndent(imported, "", "  ")
+	if err != nil {
+		return err
+	}
+	encoded = append(encoded, '\n')
+
+	// the configuration may need edits, e.g. output types to register
+	srv, _, err := conf.server()
+	if err != nil {
+		return err
+	}
+	if err := srv.Parse(bytes.NewReader(encoded)); err != nil {
+		fmt.Fprintf(os.Stderr, "the configuration needs edits:\n%s\n", err)
+	}
+
+	if len(*output) < 1 {
+		_, err = os.Stdout.Write(encoded)
+		return err
+	}
+	return os.WriteFile(*output, encoded, 0o644)
+}
@@ -0,0 +1,155 @@
+// Command aicra generates go code from an aicra configuration.
+//
+// Usage:
+//
+//	aicra <command> [flags] <config.json>
+//
+// Commands:
+//
+//	client     generates a go client with a method calling each service
+//	diff       reports the changes between two configurations, exits with
+//	           status 3 on breaking changes
+//	generate   generates request and response types along with reflection-free
+//	           codecs for every service
+//	import     converts an OpenAPI 3 document into an aicra configuration
+//	openapi    exports the OpenAPI 3.1 document of the configuration
+//	reference  renders a searchable html reference of the configuration
+//	routes     prints the route table with methods, paths, scopes and inputs
+//	scaffold   generates request and response types, the binding of every
+//	           handler and adds missing handler stubs
+//	typescript generates typescript definitions along with a fetch-based
+//	           client
+//	validate   checks the configuration the same way the server does
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// command is a subcommand of the aicra command
+type command struct {
+	description string
+	run         func(args []string) error
+}
+
+var commands = map[string]command{
+	"client": {
+		description: "generates a go client with a method calling each service",
+		run:         client,
+	},
+	"diff": {
+		description: "reports changes between two configurations, fails on breaking ones",
+		run:         compare,
+	},
+	"generate": {
+		description: "generates request and response types along with reflection-free codecs",
+		run:         generate,
+	},
+	"import": {
+		description: "converts an OpenAPI 3 document into a configuration",
+		run:         importOpenAPI,
+	},
+	"openapi": {
+		description: "exports the OpenAPI 3.1 document of the configuration",
+		run:         exportOpenAPI,
+	},
+	"reference": {
+		description: "renders a searchable html reference of the configuration",
+		run:         htmlReference,
+	},
+	"routes": {
+		description: "prints the route table with scopes and inputs by location",
+		run:         routes,
+	},
+	"scaffold": {
+		description: "generates types and bindings, adds missing handler stubs",
+		run:         scaffold,
+	},
+	"typescript": {
+		description: "generates typescript definitions and a fetch-based client",
+		run:         typescript,
+	},
+	"validate": {
+		description: "checks the configuration the same way the server does",
+		run:         validate,
+	},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+
+	cmd, exists := commands[os.Args[1]]
+	if !exists {
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+
+	if err := cmd.run(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "aicra %s: %s\n", os.Args[1], err)
+
+		var status statusError
+		if errors.As(err, &status) {
+			os.Exit(status.status)
+		}
+		os.Exit(1)
+	}
+}
+
+// statusError is a command error exiting with a specific status instead of 1
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e statusError) Error() string {
+	return e.err.Error()
+}
+
+func (e statusError) Unwrap() error {
+	return e.err
+}
+
+// usage prints the list of commands
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "usage: aicra <command> [flags] <config.json>\n\ncommands:\n")
+
+	var names = make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].description)
+	}
+}
+
+// stringsFlag is a repeatable string flag
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// newFlagSet returns the flag set of a command
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("aicra "+name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: aicra %s [flags] <config.json>\n\nflags:\n", name)
+		fs.PrintDefaults()
+	}
+	return fs
+}
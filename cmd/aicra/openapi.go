@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/xdrm-io/aicra/internal/openapi"
+)
+
+// exportOpenAPI writes the OpenAPI document of the configuration
+func exportOpenAPI(args []string) error {
+	var (
+		fs      = newFlagSet("openapi")
+		conf    configFlags
+		output  = fs.String("o", "", "output `file`, defaults to the standard output")
+		title   = fs.String("title", "API", "`title` of the api")
+		version = fs.String("version", "1.0.0", "`version` of the api")
+	)
+	conf.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	path, err := configPath(fs)
+	if err != nil {
+		return err
+	}
+	srv, _, err := conf.load(path)
+	if err != nil {
+		return err
+	}
+
+	doc := openapi.Export(srv, openapi.Info{Title: *title, Version: *version})
+	encoded, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	encoded = append(encoded, '\n')
+
+	if len(*output) < 1 {
+		_, err = os.Stdout.Write(encoded)
+		return err
+	}
+	return os.WriteFile(*output, encoded, 0o644)
+}
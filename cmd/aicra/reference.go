@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/xdrm-io/aicra/internal/reference"
+)
+
+// htmlReference writes the html reference of the configuration
+func htmlReference(args []string) error {
+	var (
+		fs     = newFlagSet("reference")
+		conf   configFlags
+		output = fs.String("o", "", "output `file`, defaults to the standard output")
+		title  = fs.String("title", "API reference", "`title` of the page")
+	)
+	conf.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	path, err := configPath(fs)
+	if err != nil {
+		return err
+	}
+	srv, _, err := conf.load(path)
+	if err != nil {
+		return err
+	}
+
+	var page bytes.Buffer
+	if err := reference.Render(&page, srv, *title); err != nil {
+		return err
+	}
+
+	if len(*output) < 1 {
+		_, err = os.Stdout.Write(page.Bytes())
+		return err
+	}
+	return os.WriteFile(*output, page.Bytes(), 0o644)
+}
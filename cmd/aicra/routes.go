@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/xdrm-io/aicra/internal/config"
+)
+
+// routes prints the route table of the configuration
+func routes(args []string) error {
+	var (
+		fs   = newFlagSet("routes")
+		conf configFlags
+	)
+	conf.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	path, err := configPath(fs)
+	if err != nil {
+		return err
+	}
+	srv, _, err := conf.load(path)
+	if err != nil {
+		return err
+	}
+
+	return printRoutes(os.Stdout, srv.Services)
+}
+
+// printRoutes writes a table of the services with a line for each route
+func printRoutes(out io.Writer, services []*config.Service) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tPATH\tSCOPE\tINPUTS\tOUTPUTS")
+	for _, service := range services {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			service.Method,
+			service.Pattern,
+			formatScope(service.Scope),
+			formatInputs(service),
+			formatOutputs(service),
+		)
+	}
+	return w.Flush()
+}
+
+// formatScope returns the permission sets of a scope, e.g.
+// "[admin] or [user[ID] write]", or "public"
+func formatScope(scope [][]string) string {
+	if len(scope) < 1 {
+		return "public"
+	}
+	var sets = make([]string, 0, len(scope))
+	for _, perms := range scope {
+		sets = append(sets, "["+strings.Join(perms, " ")+"]")
+	}
+	return strings.Join(sets, " or ")
+}
+
+// formatInputs returns the inputs of a service prefixed by their location,
+// e.g. "path:id query:limit? form:name", optional ones end with "?"
+func formatInputs(service *config.Service) string {
+	var inputs []string
+	for _, capture := range service.Captures {
+		inputs = append(inputs, "path:"+capture.Name)
+	}
+	for _, location := range []struct {
+		name   string
+		params map[string]*config.Parameter
+	}{
+		{"query", service.Query},
+		{"header", service.Header},
+		{"cookie", service.Cookie},
+		{"form", service.Form},
+	} {
+		for _, key := range sortedParams(location.params) {
+			var input = location.name + ":" + key
+			if location.params[key].Optional {
+				input += "?"
+			}
+			inputs = append(inputs, input)
+		}
+	}
+	if len(inputs) < 1 {
+		return "-"
+	}
+	return strings.Join(inputs, " ")
+}
+
+// formatOutputs returns the output keys of a service
+func formatOutputs(service *config.Service) string {
+	if len(service.Output) < 1 {
+		return "-"
+	}
+	return strings.Join(sortedParams(service.Output), " ")
+}
+
+// sortedParams returns the keys of parameters in order
+func sortedParams(params map[string]*config.Parameter) []string {
+	var keys = make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+const routesConfig = `[
+	{
+		"method": "GET",
+		"path": "/user/{id}",
+		"scope": [["admin"], ["user[ID]", "read"]],
+		"info": "info",
+		"in": {
+			"{id}": { "info": "info", "type": "uint", "name": "ID" },
+			"GET@fields": { "info": "info", "type": "?[]string", "name": "Fields" },
+			"HEADER@X-Token": { "info": "info", "type": "string", "name": "Token" }
+		},
+		"out": {
+			"name": { "info": "info", "type": "string", "name": "Name" },
+			"email": { "info": "info", "type": "string", "name": "Email" }
+		}
+	},
+	{
+		"method": "POST",
+		"path": "/user",
+		"info": "info",
+		"in": {
+			"name": { "info": "info", "type": "string", "name": "Name" },
+			"COOKIE@session": { "info": "info", "type": "?string", "name": "Session" }
+		}
+	}
+]`
+
+func TestRoutes(t *testing.T) {
+	t.Parallel()
+
+	var conf configFlags
+	srv, _, err := conf.load(writeFile(t, t.TempDir(), "api.json", routesConfig))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var out bytes.Buffer
+	if err := printRoutes(&out, srv.Services); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	const expect = "" +
+		"METHOD  PATH        SCOPE                       INPUTS                                OUTPUTS\n" +
+		"GET     /user/{id}  [admin] or [user[ID] read]  path:id query:fields? header:X-Token  email name\n" +
+		"POST    /user       public                      cookie:session? form:name             -\n"
+	if out.String() != expect {
+		t.Fatalf("invalid routes\nactual:\n%s\nexpect:\n%s", out.String(), expect)
+	}
+}
+
+func TestFormatScope(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		scope  [][]string
+		expect string
+	}{
+		{name: "public", scope: nil, expect: "public"},
+		{name: "public without permission", scope: [][]string{}, expect: "public"},
+		{name: "single permission", scope: [][]string{{"admin"}}, expect: "[admin]"},
+		{name: "permission set", scope: [][]string{{"user[ID]", "write"}}, expect: "[user[ID] write]"},
+		{name: "alternatives", scope: [][]string{{"admin"}, {"user[ID]", "write"}}, expect: "[admin] or [user[ID] write]"},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if actual := formatScope(tc.scope); actual != tc.expect {
+				t.Fatalf("invalid scope\nactual: %q\nexpect: %q", actual, tc.expect)
+			}
+		})
+	}
+}
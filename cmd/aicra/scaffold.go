@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/xdrm-io/aicra/internal/codegen"
+)
+
+const (
+	// bindingsFile is generated from the configuration and overwritten
+	bindingsFile = "bindings.go"
+	// handlersFile contains handler implementations, missing stubs are
+	// appended to it
+	handlersFile = "handlers.go"
+)
+
+// scaffold writes the request and response types and a function binding
+// every handler, then adds a stub for every handler not implemented yet
+func scaffold(args []string) error {
+	var (
+		flags    = newFlagSet("scaffold")
+		conf     configFlags
+		dir      = flags.String("dir", ".", "output `directory`")
+		pkg      = flags.String("package", "api", "`name` of the package, only used when the directory has no handlers or bindings file")
+		receiver = flags.String("receiver", "Handlers", "`type` implementing the handlers")
+		codecs   = flags.Bool("codec", false, "bind handlers with generated codecs instead of reflection")
+	)
+	conf.register(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	path, err := configPath(flags)
+	if err != nil {
+		return err
+	}
+	srv, types, err := conf.load(path)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(*dir, 0o755); err != nil {
+		return err
+	}
+
+	var handlersPath = filepath.Join(*dir, handlersFile)
+	current, err := os.ReadFile(handlersPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	// existing files define the package
+	name, err := packageName(*dir, *pkg)
+	if err != nil {
+		return err
+	}
+
+	var bindings bytes.Buffer
+	if err := codegen.GenerateBindings(&bindings, srv, types, name, *receiver, *codecs); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(*dir, bindingsFile), bindings.Bytes(), 0o644); err != nil {
+		return err
+	}
+
+	handlers, err := codegen.ScaffoldHandlers(current, srv, name, *receiver)
+	if err != nil {
+		return fmt.Errorf("%s: %w", handlersPath, err)
+	}
+	if bytes.Equal(current, handlers) {
+		return nil
+	}
+	return os.WriteFile(handlersPath, handlers, 0o644)
+}
+
+// packageName returns the package of the handlers file in `dir`, or of the
+// bindings file when there is no handlers file, `fallback` when there is
+// none of them
+func packageName(dir, fallback string) (string, error) {
+	for _, name := range []string{handlersFile, bindingsFile} {
+		var path = filepath.Join(dir, name)
+		src, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if len(bytes.TrimSpace(src)) < 1 {
+			continue
+		}
+		pkg, err := codegen.PackageName(src)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", path, err)
+		}
+		return pkg, nil
+	}
+	return fallback, nil
+}
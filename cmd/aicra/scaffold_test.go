@@ -0,0 +1,117 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xdrm-io/aicra/internal/codegen"
+)
+
+const scaffoldConfig = `[
+	{
+		"method": "GET",
+		"path": "/user/{id}",
+		"info": "info",
+		"in": {
+			"{id}": { "info": "info", "type": "uint", "name": "ID" }
+		},
+		"out": {
+			"name": { "info": "info", "type": "string", "name": "Name" }
+		}
+	}
+]`
+
+// writeFile writes a file into a directory and returns its path
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	var path = filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return path
+}
+
+// filePackage returns the package name of a file
+func filePackage(t *testing.T, path string) string {
+	t.Helper()
+	src, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	pkg, err := codegen.PackageName(src)
+	if err != nil {
+		t.Fatalf("%s: unexpected error: %s", path, err)
+	}
+	return pkg
+}
+
+func TestScaffoldPackage(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		// existing files of the output directory
+		files  map[string]string
+		args   []string
+		expect string
+	}{
+		{
+			name:   "new directory",
+			expect: "api",
+		},
+		{
+			name:   "package flag",
+			args:   []string{"-package", "users"},
+			expect: "users",
+		},
+		{
+			name:   "existing handlers",
+			files:  map[string]string{handlersFile: "package users\n"},
+			expect: "users",
+		},
+		{
+			name:   "existing handlers over the package flag",
+			files:  map[string]string{handlersFile: "package users\n"},
+			args:   []string{"-package", "api"},
+			expect: "users",
+		},
+		{
+			name:   "existing bindings",
+			files:  map[string]string{bindingsFile: "package users\n"},
+			expect: "users",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				dir  = t.TempDir()
+				out  = filepath.Join(dir, "out")
+				conf = writeFile(t, dir, "api.json", scaffoldConfig)
+			)
+			if err := os.Mkdir(out, 0o755); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for name, content := range tc.files {
+				writeFile(t, out, name, content)
+			}
+
+			// running it again must keep the package
+			for i := 0; i < 2; i++ {
+				var args = append([]string{"-dir", out}, tc.args...)
+				if err := scaffold(append(args, conf)); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				for _, name := range []string{bindingsFile, handlersFile} {
+					if pkg := filePackage(t, filepath.Join(out, name)); pkg != tc.expect {
+						t.Fatalf("run %d: invalid %s package\nactual: %q\nexpect: %q", i, name, pkg, tc.expect)
+					}
+				}
+			}
+		})
+	}
+}
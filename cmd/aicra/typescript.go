@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/xdrm-io/aicra/internal/codegen"
+)
+
+// typescript writes typescript definitions of the api along with a
+// fetch-based client
+func typescript(args []string) error {
+	var (
+		fs     = newFlagSet("typescript")
+		conf   configFlags
+		output = fs.String("o", "", "output `file`, defaults to the standard output")
+	)
+	conf.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	path, err := configPath(fs)
+	if err != nil {
+		return err
+	}
+	srv, _, err := conf.load(path)
+	if err != nil {
+		return err
+	}
+
+	var code bytes.Buffer
+	if err := codegen.GenerateTypeScript(&code, srv); err != nil {
+		return err
+	}
+	if len(*output) < 1 {
+		_, err = os.Stdout.Write(code.Bytes())
+		return err
+	}
+	return os.WriteFile(*output, code.Bytes(), 0o644)
+}
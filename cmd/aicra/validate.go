@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// validate checks the configuration the same way the server does
+func validate(args []string) error {
+	var (
+		fs   = newFlagSet("validate")
+		conf configFlags
+	)
+	conf.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	path, err := configPath(fs)
+	if err != nil {
+		return err
+	}
+	srv, _, err := conf.load(path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "%s: valid, %d service(s), %d named type(s)\n", path, len(srv.Services), len(srv.Types))
+	return nil
+}
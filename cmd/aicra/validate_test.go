@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/xdrm-io/aicra/internal/codegen"
+	"github.com/xdrm-io/aicra/internal/config"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		flags []string
+		conf  string
+		err   error
+		// locations of the configuration errors, e.g. "line 3, column 5"
+		locations []string
+	}{
+		{
+			name: "valid",
+			conf: scaffoldConfig,
+		},
+		{
+			name:      "invalid syntax",
+			conf:      `[{ "method": "GET", }]`,
+			locations: []string{"line 1, column 21"},
+		},
+		{
+			name: "config errors",
+			conf: `[
+	{ "method": "GET", "path": "/user", "info": "info" },
+	{
+		"method": "GET",
+		"path": "/user",
+		"info": "info"
+	},
+	{ "method": "POST", "path": "/user" }
+]`,
+			err:       config.ErrPatternCollision,
+			locations: []string{"line 5, column 3", "line 8, column 2"},
+		},
+		{
+			name: "unknown custom type",
+			conf: `[{
+	"method": "GET", "path": "/user", "info": "info",
+	"in": { "GET@id": { "info": "info", "type": "?uuid", "name": "ID", "default": "0f8f" } }
+}]`,
+			err:       config.ErrUnknownParamType,
+			locations: []string{"line 3, column 10"},
+		},
+		{
+			name:  "custom type",
+			flags: []string{"-type", "uuid"},
+			conf: `[{
+	"method": "GET", "path": "/user", "info": "info",
+	"in": { "GET@id": { "info": "info", "type": "?uuid", "name": "ID", "default": "0f8f" } }
+}]`,
+		},
+		{
+			name:  "custom type with go type",
+			flags: []string{"-type", "uuid=github.com/google/uuid.UUID"},
+			conf: `[{
+	"method": "GET", "path": "/user/{id}", "info": "info",
+	"in": { "{id}": { "info": "info", "type": "uuid", "name": "ID" } }
+}, { "method": "GET", "path": "/user/me", "info": "info" }]`,
+		},
+		{
+			name:  "invalid custom type",
+			flags: []string{"-type", "uuid=google/uuid"},
+			conf:  scaffoldConfig,
+			err:   codegen.ErrInvalidTypeRef,
+		},
+		{
+			name:      "unknown method",
+			conf:      `[{ "method": "PURGE", "path": "/cache", "info": "info" }]`,
+			err:       config.ErrUnknownMethod,
+			locations: []string{"line 1, column 4"},
+		},
+		{
+			name:  "custom method",
+			flags: []string{"-method", "PURGE"},
+			conf:  `[{ "method": "PURGE", "path": "/cache", "info": "info" }]`,
+		},
+		{
+			name:  "invalid custom method",
+			flags: []string{"-method", "purge"},
+			conf:  `[{ "method": "purge", "path": "/cache", "info": "info" }]`,
+			err:   config.ErrInvalidMethod,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := writeFile(t, t.TempDir(), "api.json", tc.conf)
+			err := validate(append(tc.flags, path))
+			if tc.err == nil && len(tc.locations) < 1 {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("missing error\nexpect: %v", tc.err)
+			}
+			if tc.err != nil && !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, tc.err)
+			}
+
+			var errs config.Errors
+			if len(tc.locations) > 0 && !errors.As(err, &errs) {
+				t.Fatalf("missing config errors\nactual: %v", err)
+			}
+			if len(errs) != len(tc.locations) {
+				t.Fatalf("invalid error count\nactual: %d (%v)\nexpect: %d", len(errs), errs, len(tc.locations))
+			}
+			for i, location := range tc.locations {
+				if actual := fmt.Sprintf("line %d, column %d", errs[i].Line, errs[i].Column); actual != location {
+					t.Fatalf("invalid location of %q\nactual: %s\nexpect: %s", errs[i], actual, location)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package aicra
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xdrm-io/aicra/internal/config"
+)
+
+// CORS defines the cross-origin resource sharing policy of the server. It
+// applies to every service ; services can override it with the "cors" field of
+// the configuration.
+//
+// Preflight requests are answered automatically from the configuration unless
+// an OPTIONS service is defined for the requested path:
+//   - Access-Control-Allow-Methods lists the methods available for the path
+//   - Access-Control-Allow-Headers lists AllowedHeaders and the headers
+//     required by the requested service: header parameters and Content-Type
+//     for body parameters
+type CORS struct {
+	// AllowedOrigins lists origins allowed to send requests, "*" allows any
+	// origin
+	AllowedOrigins []string
+	// AllowedHeaders lists request headers allowed for every service, in
+	// addition to those computed from the configuration (e.g. Authorization)
+	AllowedHeaders []string
+	// ExposedHeaders lists response headers that browsers can read
+	ExposedHeaders []string
+	// AllowCredentials allows requests with credentials (cookies, etc)
+	AllowCredentials bool
+	// MaxAge defines how long preflight results can be cached, ignored when
+	// zero
+	MaxAge time.Duration
+}
+
+// corsPolicy is the cors policy of a service, precomputed at Build()
+type corsPolicy struct {
+	anyOrigin   bool
+	origins     map[string]struct{}
+	credentials bool
+	headers     string
+	exposed     string
+	maxAge      string
+}
+
+// newCORSPolicy computes the policy of a service from the server's policy and
+// the service overrides
+func newCORSPolicy(base CORS, service *config.Service) *corsPolicy {
+	var (
+		origins     = base.AllowedOrigins
+		headers     = append([]string{}, base.AllowedHeaders...)
+		exposed     = base.ExposedHeaders
+		credentials = base.AllowCredentials
+		maxAge      = int(base.MaxAge.Seconds())
+	)
+
+	if override := service.CORS; override != nil {
+		if len(override.Origins) > 0 {
+			origins = override.Origins
+		}
+		headers = append(headers, override.Headers...)
+		if len(override.Exposed) > 0 {
+			exposed = override.Exposed
+		}
+		if override.Credentials != nil {
+			credentials = *override.Credentials
+		}
+		if override.MaxAge != nil {
+			maxAge = *override.MaxAge
+		}
+	}
+
+	// header parameters are sent by clients
+	var params = make([]string, 0, len(service.Header))
+	for name := range service.Header {
+		params = append(params, name)
+	}
+	sort.Strings(params)
+	headers = append(headers, params...)
+
+	// body parameters require the Content-Type header
+	if len(service.Form) > 0 {
+		headers = append(headers, "Content-Type")
+	}
+
+	policy := &corsPolicy{
+		origins:     make(map[string]struct{}, len(origins)),
+		credentials: credentials,
+		headers:     joinHeaders(headers),
+		exposed:     joinHeaders(exposed),
+	}
+	for _, origin := range origins {
+		if origin == "*" {
+			policy.anyOrigin = true
+			continue
+		}
+		policy.origins[origin] = struct{}{}
+	}
+	if maxAge > 0 {
+		policy.maxAge = strconv.Itoa(maxAge)
+	}
+	return policy
+}
+
+// joinHeaders joins canonical header names without duplicates
+func joinHeaders(headers []string) string {
+	var (
+		unique = make([]string, 0, len(headers))
+		seen   = make(map[string]struct{}, len(headers))
+	)
+	for _, header := range headers {
+		header = http.CanonicalHeaderKey(header)
+		if _, exists := seen[header]; exists {
+			continue
+		}
+		seen[header] = struct{}{}
+		unique = append(unique, header)
+	}
+	return strings.Join(unique, ", ")
+}
+
+// allowOrigin returns the Access-Control-Allow-Origin value for an origin and
+// whether the origin is allowed
+func (p *corsPolicy) allowOrigin(origin string) (string, bool) {
+	if len(origin) < 1 {
+		return "", false
+	}
+	if _, exists := p.origins[origin]; exists {
+		return origin, true
+	}
+	if !p.anyOrigin {
+		return "", false
+	}
+	// the wildcard is not allowed by browsers along with credentials
+	if p.credentials {
+		return origin, true
+	}
+	return "*", true
+}
+
+// writeHeaders writes the cors headers common to actual and preflight requests
+func (p *corsPolicy) writeHeaders(h http.Header, origin string) bool {
+	h.Add("Vary", "Origin")
+	allowed, ok := p.allowOrigin(origin)
+	if !ok {
+		return false
+	}
+	h.Set("Access-Control-Allow-Origin", allowed)
+	if p.credentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+	return true
+}
+
+// actual writes the cors headers of an actual request
+func (p *corsPolicy) actual(h http.Header, origin string) {
+	if !p.writeHeaders(h, origin) {
+		return
+	}
+	if len(p.exposed) > 0 {
+		h.Set("Access-Control-Expose-Headers", p.exposed)
+	}
+}
+
+// preflight writes the cors headers of a preflight request
+func (p *corsPolicy) preflight(h http.Header, origin string, methods []string) {
+	h.Add("Vary", "Access-Control-Request-Method")
+	h.Add("Vary", "Access-Control-Request-Headers")
+	if !p.writeHeaders(h, origin) {
+		return
+	}
+	h.Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+	if len(p.headers) > 0 {
+		h.Set("Access-Control-Allow-Headers", p.headers)
+	}
+	if len(p.maxAge) > 0 {
+		h.Set("Access-Control-Max-Age", p.maxAge)
+	}
+}
+
+// isPreflight returns whether a request is a cors preflight request
+func isPreflight(r *http.Request) bool {
+	return r.Method == http.MethodOptions &&
+		len(r.Header.Get("Origin")) > 0 &&
+		len(r.Header.Get("Access-Control-Request-Method")) > 0
+}
@@ -1,5 +1,15 @@
 package aicra
 
+import "github.com/xdrm-io/aicra/internal/config"
+
+// ConfigError is an error of the configuration located in the configuration
+// file
+type ConfigError = config.Error
+
+// ConfigErrors lists every error of an invalid configuration. It can be
+// extracted from Builder.Setup() errors with errors.As()
+type ConfigErrors = config.Errors
+
 // cerr allows you to create constant "const" error with type boxing.
 type cerr string
 
@@ -11,6 +21,9 @@ const (
 	// errLateType - cannot add datatype after setting up the definition
 	errLateType = cerr("types cannot be added after Setup")
 
+	// errLateMethod - cannot add http method after setting up the definition
+	errLateMethod = cerr("methods cannot be added after Setup")
+
 	// errNotSetup - not set up yet
 	errNotSetup = cerr("not set up")
 
@@ -23,6 +36,9 @@ const (
 	// errMissingHandler - missing handler
 	errMissingHandler = cerr("missing handler")
 
+	// errIncompleteCodec - codec without decode or encode function
+	errIncompleteCodec = cerr("incomplete codec")
+
 	// errNilResponder - nil responder provided
 	errNilResponder = cerr("nil responder")
 )
@@ -18,6 +18,11 @@ type Handler Builder
 
 // ServeHTTP implements http.Handler and wraps it in middlewares (adapters)
 func (s Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// HEAD responses only feature headers
+	if r.Method == http.MethodHead {
+		w = headResponseWriter{w}
+	}
+
 	if s.uriLimit > 0 && len(r.URL.RequestURI()) > s.uriLimit {
 		s.respond(w, nil, api.ErrURITooLong)
 		return
@@ -37,23 +42,40 @@ func (s Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 var zeroRequest http.Request
 
+// headResponseWriter discards the response body for HEAD requests
+type headResponseWriter struct {
+	http.ResponseWriter
+}
+
+// Write implements io.Writer
+func (w headResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
 // ServeHTTP implements http.Handler and wraps it in middlewares (adapters)
 func (s Handler) resolve(w http.ResponseWriter, r *http.Request) {
 	// match service from config
-	var service = s.conf.Find(r)
+	var service = s.router.Find(r)
+
+	// answer cors preflight requests from the configuration unless an OPTIONS
+	// service is explicitly defined for the path
+	if s.cors != nil && isPreflight(r) && service == nil {
+		s.preflight(w, r)
+		return
+	}
+
 	if service == nil {
-		s.respond(w, nil, api.ErrUnknownService)
+		s.notFound(w, r)
 		return
 	}
 
-	// match handler
-	var handler *serviceHandler
-	for _, h := range s.handlers {
-		if h.Method == service.Method && h.Path == service.Pattern {
-			handler = h
-		}
+	if s.cors != nil {
+		s.corsPolicies[service].actual(w.Header(), r.Header.Get("Origin"))
 	}
 
+	// match handler
+	var handler = s.callables[service]
+
 	// no handler found
 	if handler == nil {
 		// should never fail as the builder ensures all services are plugged
@@ -68,11 +90,14 @@ func (s Handler) resolve(w http.ResponseWriter, r *http.Request) {
 	var input = reqdata.NewRequest(service)
 	if err := input.ExtractURI(r); err != nil {
 		// should never fail as type validators are always checked in
-		// s.conf.Find -> config.Service.matchPattern
+		// s.router.Find
 		input.Release()
 		s.respond(w, nil, enrichInputError(err))
 		return
 	}
+	if s.collectErrors {
+		input.CollectErrors()
+	}
 
 	// add info into context
 	c := context.WithValue(r.Context(), ctx.Key, &api.Context{
@@ -104,11 +129,27 @@ func (s Handler) resolve(w http.ResponseWriter, r *http.Request) {
 			s.respond(w, nil, enrichInputError(err))
 			return
 		}
+		if err := input.ExtractHeader(ctx.Request); err != nil {
+			input.Release()
+			s.respond(w, nil, enrichInputError(err))
+			return
+		}
+		if err := input.ExtractCookie(ctx.Request); err != nil {
+			input.Release()
+			s.respond(w, nil, enrichInputError(err))
+			return
+		}
 		if err := input.ExtractForm(ctx.Request); err != nil {
 			input.Release()
 			s.respond(w, nil, enrichInputError(err))
 			return
 		}
+		if errs := input.Errors(); errs != nil {
+			input.Release()
+			s.respond(w, nil, enrichInputErrors(errs))
+			return
+		}
+		input.ExtractDefaults()
 
 		// execute the service handler
 		s.handle(r.Context(), input, handler, service, w)
@@ -124,6 +165,39 @@ func (s Handler) resolve(w http.ResponseWriter, r *http.Request) {
 	h.ServeHTTP(w, zeroRequest.WithContext(c))
 }
 
+// notFound responds to a request that matches no service. It distinguishes
+// unknown paths from paths that only exist for other methods, the latter
+// features the list of allowed methods in the "Allow" header
+func (s *Handler) notFound(w http.ResponseWriter, r *http.Request) {
+	// browsers hide responses without cors headers from cross-origin callers
+	if s.cors != nil {
+		s.corsPolicy.actual(w.Header(), r.Header.Get("Origin"))
+	}
+
+	var allowed = s.router.Allowed(r)
+	if len(allowed) < 1 {
+		s.respond(w, nil, api.ErrUnknownPath)
+		return
+	}
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	s.respond(w, nil, api.ErrMethodNotAllowed)
+}
+
+// preflight answers a cors preflight request according to the service
+// matching the requested method
+func (s *Handler) preflight(w http.ResponseWriter, r *http.Request) {
+	var (
+		method  = r.Header.Get("Access-Control-Request-Method")
+		service = s.router.Lookup(method, r.URL.Path)
+	)
+	if service == nil {
+		s.notFound(w, r)
+		return
+	}
+	s.corsPolicies[service].preflight(w.Header(), r.Header.Get("Origin"), s.router.Allowed(r))
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // handle the service request with the associated handler func and respond using
 // the handler func output
 func (s *Handler) handle(c context.Context, input *reqdata.Request, handler *serviceHandler, service *config.Service, w http.ResponseWriter) {
@@ -162,7 +236,13 @@ func enrichInputError(err error) error {
 			return api.ErrInvalidParam
 		}
 
-		// add field name to error
+		// add field name and reason to error
+		if cast.Reason() != nil {
+			return api.Error(
+				api.ErrInvalidParam.Status(),
+				fmt.Errorf("%s: %s: %w", cast.Field(), cast.Reason(), api.ErrInvalidParam),
+			)
+		}
 		return api.Error(
 			api.ErrInvalidParam.Status(),
 			fmt.Errorf("%s: %w", cast.Field(), api.ErrInvalidParam),
@@ -188,6 +268,25 @@ func enrichInputError(err error) error {
 	return api.ErrMissingParam
 }
 
+// enrichInputErrors converts the errors of every invalid or missing parameter
+// into api errors indexed by their parameter name
+func enrichInputErrors(errs reqdata.Errors) error {
+	var list = make(api.ParamErrors, 0, len(errs))
+	for _, err := range errs {
+		var param = api.ParamError{
+			Param:  err.Param(),
+			Field:  err.Field(),
+			Err:    api.ErrMissingParam,
+			Reason: err.Reason(),
+		}
+		if errors.Is(err, reqdata.ErrInvalidType) {
+			param.Err = api.ErrInvalidParam
+		}
+		list = append(list, param)
+	}
+	return list
+}
+
 // buildAuth builds the api.Auth struct from the service scope configuration
 //
 // it replaces format '[a]' in scope where 'a' is an existing input argument's
@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/xdrm-io/aicra"
 	"github.com/xdrm-io/aicra/api"
@@ -29,6 +30,7 @@ func addDefaultTypes(b *aicra.Builder) error {
 		validator.IntType{},
 		validator.StringType{},
 		validator.UintType{},
+		validator.EnumType{},
 	}
 	outputTypes := map[string]interface{}{
 		"any":    interface{}(nil),
@@ -58,6 +60,48 @@ func bind[Req, Res any](method, path string, fn aicra.HandlerFunc[Req, Res]) fun
 	}
 }
 
+// responseCase is a GET request along with its expected response body
+type responseCase struct {
+	name     string
+	url      string
+	response string
+}
+
+// testResponses builds a handler from the configuration and the binders, then
+// checks the response body of every request
+func testResponses(t *testing.T, config string, binders []func(*aicra.Builder) error, tt []responseCase) {
+	t.Helper()
+
+	builder := &aicra.Builder{}
+	if err := addDefaultTypes(builder); err != nil {
+		t.Fatalf("unexpected error <%v>", err)
+	}
+	if err := builder.Setup(strings.NewReader(config)); err != nil {
+		t.Fatalf("setup: unexpected error <%v>", err)
+	}
+	for _, binder := range binders {
+		if err := binder(builder); err != nil {
+			t.Fatalf("bind: unexpected error <%v>", err)
+		}
+	}
+	handler, err := builder.Build()
+	if err != nil {
+		t.Fatalf("build: unexpected error <%v>", err)
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, tc.url, nil))
+
+			if body := strings.TrimSpace(response.Body.String()); body != tc.response {
+				t.Fatalf("invalid response\nactual: %s\nexpect: %s", printEscaped(body), printEscaped(tc.response))
+			}
+		})
+	}
+}
+
 func TestHandlerWith(t *testing.T) {
 	builder := &aicra.Builder{}
 	if err := addDefaultTypes(builder); err != nil {
@@ -338,7 +382,7 @@ func TestHandlerPermissionError(t *testing.T) {
 			} ]`,
 			binder:      bind("GET", "/path/{uid}", func(context.Context, struct{ UserID uint }) (*struct{}, error) { return nil, api.ErrNotImplemented }),
 			permissions: []string{},
-			err:         api.ErrUnknownService,
+			err:         api.ErrUnknownPath,
 		},
 		{
 			name: "permission with wrong query param",
@@ -713,7 +757,7 @@ func TestHandlerServiceErrors(t *testing.T) {
 			url:         "/",
 			body:        ``,
 			permissions: []string{},
-			err:         api.ErrUnknownService,
+			err:         api.ErrMethodNotAllowed,
 		},
 		{
 			name: "unknown service path",
@@ -734,7 +778,7 @@ func TestHandlerServiceErrors(t *testing.T) {
 			url:         "/invalid",
 			body:        ``,
 			permissions: []string{},
-			err:         api.ErrUnknownService,
+			err:         api.ErrUnknownPath,
 		},
 		{
 			name: "valid empty service",
@@ -758,7 +802,7 @@ func TestHandlerServiceErrors(t *testing.T) {
 			err:         nil,
 		},
 
-		// invalid uri param -> unknown service
+		// invalid uri param -> unknown path
 		{
 			name: "invalid uri param",
 			config: `[
@@ -780,7 +824,7 @@ func TestHandlerServiceErrors(t *testing.T) {
 			url:         "/a/invalid/b",
 			body:        ``,
 			permissions: []string{},
-			err:         api.ErrUnknownService,
+			err:         api.ErrUnknownPath,
 		},
 
 		// query param
@@ -830,7 +874,7 @@ func TestHandlerServiceErrors(t *testing.T) {
 			body:        ``,
 			permissions: []string{},
 			err:         api.ErrInvalidParam,
-			errReason:   fmt.Sprintf("ID: %s", api.ErrInvalidParam.Error()),
+			errReason:   fmt.Sprintf("ID: not an integer: %s", api.ErrInvalidParam.Error()),
 		},
 		{
 			name: "query unexpected slice param",
@@ -854,7 +898,7 @@ func TestHandlerServiceErrors(t *testing.T) {
 			body:        ``,
 			permissions: []string{},
 			err:         api.ErrInvalidParam,
-			errReason:   fmt.Sprintf("ID: %s", api.ErrInvalidParam.Error()),
+			errReason:   fmt.Sprintf("ID: expected a single value: %s", api.ErrInvalidParam.Error()),
 		},
 		{
 			name: "valid query param",
@@ -929,7 +973,7 @@ func TestHandlerServiceErrors(t *testing.T) {
 			body:        `{ "id": "invalid type" }`,
 			permissions: []string{},
 			err:         api.ErrInvalidParam,
-			errReason:   fmt.Sprintf("ID: %s", api.ErrInvalidParam.Error()),
+			errReason:   fmt.Sprintf("ID: not an integer: %s", api.ErrInvalidParam.Error()),
 		},
 		{
 			name: "valid json param",
@@ -1005,7 +1049,7 @@ func TestHandlerServiceErrors(t *testing.T) {
 			body:        `id=abc`,
 			permissions: []string{},
 			err:         api.ErrInvalidParam,
-			errReason:   fmt.Sprintf("ID: %s", api.ErrInvalidParam.Error()),
+			errReason:   fmt.Sprintf("ID: not an integer: %s", api.ErrInvalidParam.Error()),
 		},
 		{
 			name: "valid urlencoded param",
@@ -1085,7 +1129,7 @@ abc
 --xxx--`,
 			permissions: []string{},
 			err:         api.ErrInvalidParam,
-			errReason:   fmt.Sprintf("ID: %s", api.ErrInvalidParam.Error()),
+			errReason:   fmt.Sprintf("ID: not an integer: %s", api.ErrInvalidParam.Error()),
 		},
 		{
 			name: "valid multipart param",
@@ -1371,13 +1415,13 @@ func TestHandlerRequestTooLarge(t *testing.T) {
 			name:     "defaults -1",
 			uriSize:  aicra.DefaultURILimit - 1,
 			bodySize: aicra.DefaultBodyLimit - 1,
-			err:      api.ErrUnknownService,
+			err:      api.ErrUnknownPath,
 		},
 		{
 			name:     "defaults eq",
 			uriSize:  aicra.DefaultURILimit,
 			bodySize: aicra.DefaultBodyLimit,
-			err:      api.ErrUnknownService,
+			err:      api.ErrUnknownPath,
 		},
 		{
 			name:    "defaults uri",
@@ -1400,19 +1444,19 @@ func TestHandlerRequestTooLarge(t *testing.T) {
 			name:    "unlimited uri",
 			uriMax:  -1,
 			uriSize: aicra.DefaultURILimit + 1,
-			err:     api.ErrUnknownService,
+			err:     api.ErrUnknownPath,
 		},
 		{
 			name:     "unlimited body",
 			bodyMax:  -1,
 			bodySize: aicra.DefaultBodyLimit + 1,
-			err:      api.ErrUnknownService,
+			err:      api.ErrUnknownPath,
 		},
 		{
 			name:    "custom uri ok",
 			uriMax:  50,
 			uriSize: 50,
-			err:     api.ErrUnknownService,
+			err:     api.ErrUnknownPath,
 		},
 		{
 			name:    "custom uri",
@@ -1424,7 +1468,7 @@ func TestHandlerRequestTooLarge(t *testing.T) {
 			name:     "custom body ok",
 			bodyMax:  50,
 			bodySize: 50,
-			err:      api.ErrUnknownService,
+			err:      api.ErrUnknownPath,
 		},
 		{
 			name:     "custom body",
@@ -1494,3 +1538,1285 @@ func TestHandlerRequestTooLarge(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlerMethods(t *testing.T) {
+	const config = `[
+		{
+			"method": "GET",
+			"path": "/users/{id}",
+			"info": "info",
+			"scope": [],
+			"in":  { "{id}": { "info": "info", "type": "int", "name": "ID" } },
+			"out": { "id":   { "info": "info", "type": "int", "name": "ID" } }
+		},
+		{
+			"method": "PATCH",
+			"path": "/users/{id}",
+			"info": "info",
+			"scope": [],
+			"in":  {
+				"{id}": { "info": "info", "type": "int",     "name": "ID" },
+				"name": { "info": "info", "type": "?string", "name": "Name" }
+			},
+			"out": { "id": { "info": "info", "type": "int", "name": "ID" } }
+		},
+		{
+			"method": "PURGE",
+			"path": "/users/{id}",
+			"info": "info",
+			"scope": [],
+			"in":  {
+				"{id}":  { "info": "info", "type": "int",  "name": "ID" },
+				"force": { "info": "info", "type": "bool", "name": "Force" }
+			},
+			"out": { "id": { "info": "info", "type": "int", "name": "ID" } }
+		}
+	]`
+
+	type req struct{ ID int }
+	type res struct{ ID int }
+	type patchReq struct {
+		ID   int
+		Name *string
+	}
+	type purgeReq struct {
+		ID    int
+		Force bool
+	}
+
+	tt := []struct {
+		name        string
+		method, url string
+		contentType string
+		body        string
+		status      int
+		response    string
+	}{
+		{
+			name:     "get",
+			method:   http.MethodGet,
+			url:      "/users/12",
+			status:   http.StatusOK,
+			response: `{"id":12,"status":"all right"}`,
+		},
+		{
+			name:     "head from get",
+			method:   http.MethodHead,
+			url:      "/users/12",
+			status:   http.StatusOK,
+			response: ``,
+		},
+		{
+			name:     "head from get error",
+			method:   http.MethodHead,
+			url:      "/users/abc",
+			status:   api.ErrUnknownPath.Status(),
+			response: ``,
+		},
+		{
+			name:        "patch",
+			method:      http.MethodPatch,
+			url:         "/users/12",
+			contentType: "application/json",
+			body:        `{"name":"abc"}`,
+			status:      http.StatusOK,
+			response:    `{"id":12,"status":"all right"}`,
+		},
+		{
+			name:        "custom method",
+			method:      "PURGE",
+			url:         "/users/12",
+			contentType: "application/x-www-form-urlencoded",
+			body:        `force=true`,
+			status:      http.StatusOK,
+			response:    `{"id":12,"status":"all right"}`,
+		},
+		{
+			name:     "custom method missing body",
+			method:   "PURGE",
+			url:      "/users/12",
+			status:   api.ErrMissingParam.Status(),
+			response: `{"status":"Force: missing parameter"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := &aicra.Builder{}
+			if err := addDefaultTypes(builder); err != nil {
+				t.Fatalf("unexpected error <%v>", err)
+			}
+			if err := builder.Method("PURGE"); err != nil {
+				t.Fatalf("method: unexpected error <%v>", err)
+			}
+			if err := builder.Setup(strings.NewReader(config)); err != nil {
+				t.Fatalf("setup: unexpected error <%v>", err)
+			}
+
+			err := aicra.Bind(builder, http.MethodGet, "/users/{id}", func(_ context.Context, r req) (*res, error) {
+				return &res{ID: r.ID}, nil
+			})
+			if err != nil {
+				t.Fatalf("bind: unexpected error <%v>", err)
+			}
+			err = aicra.Bind(builder, http.MethodPatch, "/users/{id}", func(_ context.Context, r patchReq) (*res, error) {
+				if r.Name == nil || *r.Name != "abc" {
+					return nil, api.ErrInvalidParam
+				}
+				return &res{ID: r.ID}, nil
+			})
+			if err != nil {
+				t.Fatalf("bind: unexpected error <%v>", err)
+			}
+			err = aicra.Bind(builder, "PURGE", "/users/{id}", func(_ context.Context, r purgeReq) (*res, error) {
+				if !r.Force {
+					return nil, api.ErrInvalidParam
+				}
+				return &res{ID: r.ID}, nil
+			})
+			if err != nil {
+				t.Fatalf("bind: unexpected error <%v>", err)
+			}
+
+			handler, err := builder.Build()
+			if err != nil {
+				t.Fatalf("build: unexpected error <%v>", err)
+			}
+
+			var (
+				response = httptest.NewRecorder()
+				request  = httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			)
+			if len(tc.contentType) > 0 {
+				request.Header.Add("Content-Type", tc.contentType)
+			}
+			handler.ServeHTTP(response, request)
+
+			if response.Code != tc.status {
+				t.Fatalf("invalid status\nactual: %d\nexpect: %d", response.Code, tc.status)
+			}
+			if body := strings.TrimSpace(response.Body.String()); body != tc.response {
+				t.Fatalf("invalid response\nactual: %s\nexpect: %s", printEscaped(body), printEscaped(tc.response))
+			}
+		})
+	}
+}
+
+func TestHandlerNotFound(t *testing.T) {
+	const config = `[
+		{ "method": "GET",    "path": "/users",      "info": "info", "scope": [], "in": {}, "out": {} },
+		{ "method": "POST",   "path": "/users",      "info": "info", "scope": [], "in": {}, "out": {} },
+		{ "method": "PUT",    "path": "/users/{id}", "info": "info", "scope": [], "in": { "{id}": { "info": "info", "type": "int", "name": "ID" } }, "out": {} },
+		{ "method": "DELETE", "path": "/users/{id}", "info": "info", "scope": [], "in": { "{id}": { "info": "info", "type": "int", "name": "ID" } }, "out": {} }
+	]`
+
+	tt := []struct {
+		name        string
+		method, url string
+		err         error
+		allow       string
+	}{
+		{
+			name:   "unknown path",
+			method: http.MethodGet,
+			url:    "/articles",
+			err:    api.ErrUnknownPath,
+		},
+		{
+			name:   "invalid capture",
+			method: http.MethodPut,
+			url:    "/users/abc",
+			err:    api.ErrUnknownPath,
+		},
+		{
+			name:   "static path other method",
+			method: http.MethodDelete,
+			url:    "/users",
+			err:    api.ErrMethodNotAllowed,
+			allow:  "GET, HEAD, POST",
+		},
+		{
+			name:   "capture path other method",
+			method: http.MethodGet,
+			url:    "/users/12",
+			err:    api.ErrMethodNotAllowed,
+			allow:  "DELETE, PUT",
+		},
+		{
+			name:   "found",
+			method: http.MethodPut,
+			url:    "/users/12",
+			err:    nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := &aicra.Builder{}
+			if err := addDefaultTypes(builder); err != nil {
+				t.Fatalf("unexpected error <%v>", err)
+			}
+			if err := builder.Setup(strings.NewReader(config)); err != nil {
+				t.Fatalf("setup: unexpected error <%v>", err)
+			}
+			binders := []func(*aicra.Builder) error{
+				bind(http.MethodGet, "/users", noOpHandler),
+				bind(http.MethodPost, "/users", noOpHandler),
+				bind(http.MethodPut, "/users/{id}", noOpIntHandler),
+				bind(http.MethodDelete, "/users/{id}", noOpIntHandler),
+			}
+			for _, binder := range binders {
+				if err := binder(builder); err != nil {
+					t.Fatalf("bind: unexpected error <%v>", err)
+				}
+			}
+			handler, err := builder.Build()
+			if err != nil {
+				t.Fatalf("build: unexpected error <%v>", err)
+			}
+
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, httptest.NewRequest(tc.method, tc.url, nil))
+
+			if response.Code != api.GetErrorStatus(tc.err) {
+				t.Fatalf("invalid status\nactual: %d\nexpect: %d", response.Code, api.GetErrorStatus(tc.err))
+			}
+			if allow := response.Header().Get("Allow"); allow != tc.allow {
+				t.Fatalf("invalid Allow header\nactual: %q\nexpect: %q", allow, tc.allow)
+			}
+		})
+	}
+}
+
+func TestHandlerCORS(t *testing.T) {
+	const config = `[
+		{ "method": "GET",    "path": "/users",      "info": "info", "scope": [], "in": {}, "out": {} },
+		{ "method": "POST",   "path": "/users",      "info": "info", "scope": [],
+			"in": { "id": { "info": "info", "type": "int", "name": "ID" } }, "out": {} },
+		{ "method": "DELETE", "path": "/users/{id}", "info": "info", "scope": [],
+			"in": { "{id}": { "info": "info", "type": "int", "name": "ID" } }, "out": {},
+			"cors": { "origins": ["https://admin.example.com"], "headers": ["X-Confirm"], "credentials": true, "max_age": 60 }
+		},
+		{ "method": "PUT",    "path": "/users/{id}", "info": "info", "scope": [],
+			"in": {
+				"{id}":            { "info": "info", "type": "int",     "name": "ID" },
+				"HEADER@if-match": { "info": "info", "type": "?string", "name": "ETag" }
+			}, "out": {}
+		},
+		{ "method": "OPTIONS", "path": "/status",    "info": "info", "scope": [], "in": {}, "out": {} }
+	]`
+
+	tt := []struct {
+		name        string
+		cors        aicra.CORS
+		method, url string
+		headers     map[string]string
+		status      int
+		expect      map[string]string
+	}{
+		{
+			name:    "preflight allowed origin",
+			cors:    aicra.CORS{AllowedOrigins: []string{"https://example.com"}, AllowedHeaders: []string{"authorization"}, MaxAge: time.Hour},
+			method:  http.MethodOptions,
+			url:     "/users",
+			headers: map[string]string{"Origin": "https://example.com", "Access-Control-Request-Method": "POST"},
+			status:  http.StatusNoContent,
+			expect: map[string]string{
+				"Access-Control-Allow-Origin":      "https://example.com",
+				"Access-Control-Allow-Methods":     "GET, HEAD, POST",
+				"Access-Control-Allow-Headers":     "Authorization, Content-Type",
+				"Access-Control-Max-Age":           "3600",
+				"Access-Control-Allow-Credentials": "",
+			},
+		},
+		{
+			name:    "preflight no body parameter",
+			cors:    aicra.CORS{AllowedOrigins: []string{"*"}},
+			method:  http.MethodOptions,
+			url:     "/users",
+			headers: map[string]string{"Origin": "https://example.com", "Access-Control-Request-Method": "GET"},
+			status:  http.StatusNoContent,
+			expect: map[string]string{
+				"Access-Control-Allow-Origin":  "*",
+				"Access-Control-Allow-Methods": "GET, HEAD, POST",
+				"Access-Control-Allow-Headers": "",
+			},
+		},
+		{
+			name:    "preflight forbidden origin",
+			cors:    aicra.CORS{AllowedOrigins: []string{"https://example.com"}},
+			method:  http.MethodOptions,
+			url:     "/users",
+			headers: map[string]string{"Origin": "https://other.com", "Access-Control-Request-Method": "GET"},
+			status:  http.StatusNoContent,
+			expect: map[string]string{
+				"Access-Control-Allow-Origin":  "",
+				"Access-Control-Allow-Methods": "",
+			},
+		},
+		{
+			name:    "preflight unknown method",
+			cors:    aicra.CORS{AllowedOrigins: []string{"*"}},
+			method:  http.MethodOptions,
+			url:     "/users",
+			headers: map[string]string{"Origin": "https://example.com", "Access-Control-Request-Method": "PUT"},
+			status:  http.StatusMethodNotAllowed,
+			expect: map[string]string{
+				"Allow":                        "GET, HEAD, POST",
+				"Access-Control-Allow-Origin":  "*",
+				"Access-Control-Allow-Methods": "",
+			},
+		},
+		{
+			name:    "preflight unknown path",
+			cors:    aicra.CORS{AllowedOrigins: []string{"*"}},
+			method:  http.MethodOptions,
+			url:     "/articles",
+			headers: map[string]string{"Origin": "https://example.com", "Access-Control-Request-Method": "GET"},
+			status:  http.StatusNotFound,
+			expect: map[string]string{
+				"Access-Control-Allow-Origin": "*",
+			},
+		},
+		{
+			name:    "preflight explicit options service",
+			cors:    aicra.CORS{AllowedOrigins: []string{"*"}},
+			method:  http.MethodOptions,
+			url:     "/status",
+			headers: map[string]string{"Origin": "https://example.com", "Access-Control-Request-Method": "GET"},
+			status:  http.StatusOK,
+			expect: map[string]string{
+				"Access-Control-Allow-Origin":  "*",
+				"Access-Control-Allow-Methods": "",
+			},
+		},
+		{
+			name:    "unknown path",
+			cors:    aicra.CORS{AllowedOrigins: []string{"https://example.com"}, ExposedHeaders: []string{"x-request-id"}},
+			method:  http.MethodGet,
+			url:     "/articles",
+			headers: map[string]string{"Origin": "https://example.com"},
+			status:  http.StatusNotFound,
+			expect: map[string]string{
+				"Access-Control-Allow-Origin":   "https://example.com",
+				"Access-Control-Expose-Headers": "X-Request-Id",
+			},
+		},
+		{
+			name:    "method not allowed",
+			cors:    aicra.CORS{AllowedOrigins: []string{"*"}},
+			method:  http.MethodPatch,
+			url:     "/users",
+			headers: map[string]string{"Origin": "https://example.com"},
+			status:  http.StatusMethodNotAllowed,
+			expect: map[string]string{
+				"Allow":                       "GET, HEAD, POST",
+				"Access-Control-Allow-Origin": "*",
+			},
+		},
+		{
+			name:    "preflight service override",
+			cors:    aicra.CORS{AllowedOrigins: []string{"https://example.com"}},
+			method:  http.MethodOptions,
+			url:     "/users/12",
+			headers: map[string]string{"Origin": "https://admin.example.com", "Access-Control-Request-Method": "DELETE"},
+			status:  http.StatusNoContent,
+			expect: map[string]string{
+				"Access-Control-Allow-Origin":      "https://admin.example.com",
+				"Access-Control-Allow-Methods":     "DELETE, PUT",
+				"Access-Control-Allow-Headers":     "X-Confirm",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Max-Age":           "60",
+			},
+		},
+		{
+			name:    "preflight header parameter",
+			cors:    aicra.CORS{AllowedOrigins: []string{"*"}, AllowedHeaders: []string{"Authorization"}},
+			method:  http.MethodOptions,
+			url:     "/users/12",
+			headers: map[string]string{"Origin": "https://example.com", "Access-Control-Request-Method": "PUT"},
+			status:  http.StatusNoContent,
+			expect: map[string]string{
+				"Access-Control-Allow-Methods": "DELETE, PUT",
+				"Access-Control-Allow-Headers": "Authorization, If-Match",
+			},
+		},
+		{
+			name:    "actual request",
+			cors:    aicra.CORS{AllowedOrigins: []string{"*"}, ExposedHeaders: []string{"x-request-id"}},
+			method:  http.MethodGet,
+			url:     "/users",
+			headers: map[string]string{"Origin": "https://example.com"},
+			status:  http.StatusOK,
+			expect: map[string]string{
+				"Access-Control-Allow-Origin":   "*",
+				"Access-Control-Expose-Headers": "X-Request-Id",
+				"Access-Control-Allow-Methods":  "",
+				"Vary":                          "Origin",
+			},
+		},
+		{
+			name:    "actual request any origin with credentials",
+			cors:    aicra.CORS{AllowedOrigins: []string{"*"}, AllowCredentials: true},
+			method:  http.MethodGet,
+			url:     "/users",
+			headers: map[string]string{"Origin": "https://example.com"},
+			status:  http.StatusOK,
+			expect: map[string]string{
+				"Access-Control-Allow-Origin":      "https://example.com",
+				"Access-Control-Allow-Credentials": "true",
+			},
+		},
+		{
+			name:    "actual request overridden origin",
+			cors:    aicra.CORS{AllowedOrigins: []string{"*"}},
+			method:  http.MethodDelete,
+			url:     "/users/12",
+			headers: map[string]string{"Origin": "https://example.com"},
+			status:  http.StatusOK,
+			expect: map[string]string{
+				"Access-Control-Allow-Origin": "",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := &aicra.Builder{}
+			if err := addDefaultTypes(builder); err != nil {
+				t.Fatalf("unexpected error <%v>", err)
+			}
+			builder.SetCORS(tc.cors)
+			if err := builder.Setup(strings.NewReader(config)); err != nil {
+				t.Fatalf("setup: unexpected error <%v>", err)
+			}
+			binders := []func(*aicra.Builder) error{
+				bind(http.MethodGet, "/users", noOpHandler),
+				bind(http.MethodPost, "/users", noOpIntHandler),
+				bind(http.MethodDelete, "/users/{id}", noOpIntHandler),
+				bind(http.MethodOptions, "/status", noOpHandler),
+				bind(http.MethodPut, "/users/{id}", func(context.Context, struct {
+					ID   int
+					ETag *string
+				}) (*struct{}, error) {
+					return nil, nil
+				}),
+			}
+			for _, binder := range binders {
+				if err := binder(builder); err != nil {
+					t.Fatalf("bind: unexpected error <%v>", err)
+				}
+			}
+			handler, err := builder.Build()
+			if err != nil {
+				t.Fatalf("build: unexpected error <%v>", err)
+			}
+
+			var (
+				response = httptest.NewRecorder()
+				request  = httptest.NewRequest(tc.method, tc.url, nil)
+			)
+			for k, v := range tc.headers {
+				request.Header.Set(k, v)
+			}
+			handler.ServeHTTP(response, request)
+
+			if response.Code != tc.status {
+				t.Fatalf("invalid status\nactual: %d\nexpect: %d", response.Code, tc.status)
+			}
+			for k, expect := range tc.expect {
+				if actual := response.Header().Get(k); actual != expect {
+					t.Fatalf("invalid header %q\nactual: %q\nexpect: %q", k, actual, expect)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerHeaderCookie(t *testing.T) {
+	const config = `[
+		{
+			"method": "GET",
+			"path": "/",
+			"info": "info",
+			"scope": [],
+			"in":  {
+				"HEADER@X-Tenant-ID": { "info": "info", "type": "int",     "name": "Tenant" },
+				"HEADER@If-Match":    { "info": "info", "type": "?string", "name": "ETag" },
+				"COOKIE@session":     { "info": "info", "type": "string",  "name": "Session" }
+			},
+			"out": {
+				"tenant":  { "info": "info", "type": "int",    "name": "Tenant" },
+				"etag":    { "info": "info", "type": "string", "name": "ETag" },
+				"session": { "info": "info", "type": "string", "name": "Session" }
+			}
+		}
+	]`
+
+	type req struct {
+		Tenant  int
+		ETag    *string
+		Session string
+	}
+	type res struct {
+		Tenant  int
+		ETag    string
+		Session string
+	}
+
+	tt := []struct {
+		name     string
+		headers  map[string]string
+		cookies  map[string]string
+		response string
+	}{
+		{
+			name:     "all provided",
+			headers:  map[string]string{"X-Tenant-Id": "12", "If-Match": "abc"},
+			cookies:  map[string]string{"session": "xyz"},
+			response: `{"etag":"abc","session":"xyz","status":"all right","tenant":12}`,
+		},
+		{
+			name:     "optional header missing",
+			headers:  map[string]string{"X-Tenant-Id": "12"},
+			cookies:  map[string]string{"session": "xyz"},
+			response: `{"etag":"","session":"xyz","status":"all right","tenant":12}`,
+		},
+		{
+			name:     "invalid header",
+			headers:  map[string]string{"X-Tenant-Id": "abc"},
+			cookies:  map[string]string{"session": "xyz"},
+			response: `{"status":"Tenant: not an integer: invalid parameter"}`,
+		},
+		{
+			name:     "missing header",
+			cookies:  map[string]string{"session": "xyz"},
+			response: `{"status":"Tenant: missing parameter"}`,
+		},
+		{
+			name:     "missing cookie",
+			headers:  map[string]string{"X-Tenant-Id": "12"},
+			response: `{"status":"Session: missing parameter"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := &aicra.Builder{}
+			if err := addDefaultTypes(builder); err != nil {
+				t.Fatalf("unexpected error <%v>", err)
+			}
+			if err := builder.Setup(strings.NewReader(config)); err != nil {
+				t.Fatalf("setup: unexpected error <%v>", err)
+			}
+			err := aicra.Bind(builder, http.MethodGet, "/", func(_ context.Context, r req) (*res, error) {
+				out := &res{Tenant: r.Tenant, Session: r.Session}
+				if r.ETag != nil {
+					out.ETag = *r.ETag
+				}
+				return out, nil
+			})
+			if err != nil {
+				t.Fatalf("bind: unexpected error <%v>", err)
+			}
+			handler, err := builder.Build()
+			if err != nil {
+				t.Fatalf("build: unexpected error <%v>", err)
+			}
+
+			var (
+				response = httptest.NewRecorder()
+				request  = httptest.NewRequest(http.MethodGet, "/", nil)
+			)
+			for k, v := range tc.headers {
+				request.Header.Set(k, v)
+			}
+			for k, v := range tc.cookies {
+				request.AddCookie(&http.Cookie{Name: k, Value: v})
+			}
+			handler.ServeHTTP(response, request)
+
+			if body := strings.TrimSpace(response.Body.String()); body != tc.response {
+				t.Fatalf("invalid response\nactual: %s\nexpect: %s", printEscaped(body), printEscaped(tc.response))
+			}
+		})
+	}
+}
+
+func TestHandlerDefaultValues(t *testing.T) {
+	const config = `[
+		{
+			"method": "GET",
+			"path": "/articles",
+			"info": "info",
+			"scope": [],
+			"in":  {
+				"GET@limit": { "info": "info", "type": "?int",    "name": "Limit", "default": 20 },
+				"GET@order": { "info": "info", "type": "?string", "name": "Order", "default": "asc" }
+			},
+			"out": {
+				"limit": { "info": "info", "type": "int",    "name": "Limit" },
+				"order": { "info": "info", "type": "string", "name": "Order" }
+			}
+		}
+	]`
+
+	type req struct {
+		Limit int
+		Order string
+	}
+	type res struct {
+		Limit int
+		Order string
+	}
+
+	tt := []struct {
+		name     string
+		url      string
+		response string
+	}{
+		{
+			name:     "defaults",
+			url:      "/articles",
+			response: `{"limit":20,"order":"asc","status":"all right"}`,
+		},
+		{
+			name:     "partial default",
+			url:      "/articles?limit=5",
+			response: `{"limit":5,"order":"asc","status":"all right"}`,
+		},
+		{
+			name:     "no default",
+			url:      "/articles?limit=5&order=desc",
+			response: `{"limit":5,"order":"desc","status":"all right"}`,
+		},
+		{
+			name:     "invalid value",
+			url:      "/articles?limit=abc",
+			response: `{"status":"Limit: not an integer: invalid parameter"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := &aicra.Builder{}
+			if err := addDefaultTypes(builder); err != nil {
+				t.Fatalf("unexpected error <%v>", err)
+			}
+			if err := builder.Setup(strings.NewReader(config)); err != nil {
+				t.Fatalf("setup: unexpected error <%v>", err)
+			}
+			err := aicra.Bind(builder, http.MethodGet, "/articles", func(_ context.Context, r req) (*res, error) {
+				return &res{Limit: r.Limit, Order: r.Order}, nil
+			})
+			if err != nil {
+				t.Fatalf("bind: unexpected error <%v>", err)
+			}
+			handler, err := builder.Build()
+			if err != nil {
+				t.Fatalf("build: unexpected error <%v>", err)
+			}
+
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, tc.url, nil))
+
+			if body := strings.TrimSpace(response.Body.String()); body != tc.response {
+				t.Fatalf("invalid response\nactual: %s\nexpect: %s", printEscaped(body), printEscaped(tc.response))
+			}
+		})
+	}
+}
+
+func TestHandlerSliceParameters(t *testing.T) {
+	const config = `[
+		{
+			"method": "GET",
+			"path": "/sum",
+			"info": "info",
+			"scope": [],
+			"in":  {
+				"GET@ids": { "info": "info", "type": "[]int", "name": "IDs" }
+			},
+			"out": {
+				"sum": { "info": "info", "type": "int", "name": "Sum" }
+			}
+		},
+		{
+			"method": "POST",
+			"path": "/sum",
+			"info": "info",
+			"scope": [],
+			"in":  {
+				"ids": { "info": "info", "type": "[]int", "name": "IDs" }
+			},
+			"out": {
+				"sum": { "info": "info", "type": "int", "name": "Sum" }
+			}
+		}
+	]`
+
+	type req struct {
+		IDs []int
+	}
+	type res struct {
+		Sum int
+	}
+	sum := func(_ context.Context, r req) (*res, error) {
+		var total int
+		for _, id := range r.IDs {
+			total += id
+		}
+		return &res{Sum: total}, nil
+	}
+
+	tt := []struct {
+		name        string
+		method      string
+		url         string
+		contentType string
+		body        string
+		response    string
+	}{
+		{
+			name:     "query single value",
+			method:   http.MethodGet,
+			url:      "/sum?ids=1",
+			response: `{"status":"all right","sum":1}`,
+		},
+		{
+			name:     "query repeated values",
+			method:   http.MethodGet,
+			url:      "/sum?ids=1&ids=2&ids=3",
+			response: `{"status":"all right","sum":6}`,
+		},
+		{
+			name:     "query invalid item",
+			method:   http.MethodGet,
+			url:      "/sum?ids=1&ids=a",
+			response: `{"status":"IDs: item 1: not an integer: invalid parameter"}`,
+		},
+		{
+			name:        "json array",
+			method:      http.MethodPost,
+			url:         "/sum",
+			contentType: "application/json",
+			body:        `{"ids": [1, 2, 3, 4]}`,
+			response:    `{"status":"all right","sum":10}`,
+		},
+		{
+			name:        "json invalid item",
+			method:      http.MethodPost,
+			url:         "/sum",
+			contentType: "application/json",
+			body:        `{"ids": [1, "a"]}`,
+			response:    `{"status":"IDs: item 1: not an integer: invalid parameter"}`,
+		},
+		{
+			name:        "urlencoded repeated values",
+			method:      http.MethodPost,
+			url:         "/sum",
+			contentType: "application/x-www-form-urlencoded",
+			body:        `ids=1&ids=2`,
+			response:    `{"status":"all right","sum":3}`,
+		},
+		{
+			name:        "multipart repeated parts",
+			method:      http.MethodPost,
+			url:         "/sum",
+			contentType: "multipart/form-data; boundary=xxx",
+			body: "--xxx\r\nContent-Disposition: form-data; name=\"ids\"\r\n\r\n5\r\n" +
+				"--xxx\r\nContent-Disposition: form-data; name=\"ids\"\r\n\r\n7\r\n--xxx--\r\n",
+			response: `{"status":"all right","sum":12}`,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			builder := &aicra.Builder{}
+			if err := addDefaultTypes(builder); err != nil {
+				t.Fatalf("unexpected error <%v>", err)
+			}
+			if err := builder.Input(validator.SliceType{}); err != nil {
+				t.Fatalf("unexpected error <%v>", err)
+			}
+			if err := builder.Setup(strings.NewReader(config)); err != nil {
+				t.Fatalf("setup: unexpected error <%v>", err)
+			}
+			if err := aicra.Bind(builder, http.MethodGet, "/sum", sum); err != nil {
+				t.Fatalf("bind: unexpected error <%v>", err)
+			}
+			if err := aicra.Bind(builder, http.MethodPost, "/sum", sum); err != nil {
+				t.Fatalf("bind: unexpected error <%v>", err)
+			}
+			handler, err := builder.Build()
+			if err != nil {
+				t.Fatalf("build: unexpected error <%v>", err)
+			}
+
+			request := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if len(tc.contentType) > 0 {
+				request.Header.Set("Content-Type", tc.contentType)
+			}
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, request)
+
+			if body := strings.TrimSpace(response.Body.String()); body != tc.response {
+				t.Fatalf("invalid response\nactual: %s\nexpect: %s", printEscaped(body), printEscaped(tc.response))
+			}
+		})
+	}
+}
+
+func TestHandlerObjectParameters(t *testing.T) {
+	const config = `[
+		{
+			"method": "POST",
+			"path": "/users",
+			"info": "info",
+			"scope": [],
+			"in":  {
+				"name": { "info": "info", "type": "string", "name": "Name" },
+				"address": { "info": "info", "type": "object", "name": "Address", "fields": {
+					"city": { "info": "info", "type": "string", "name": "City" },
+					"zip":  { "info": "info", "type": "?string", "name": "Zip" }
+				} },
+				"scores": { "info": "info", "type": "?map[string]int", "name": "Scores" }
+			},
+			"out": {
+				"summary": { "info": "info", "type": "string", "name": "Summary" }
+			}
+		}
+	]`
+
+	type address struct {
+		City string
+		Zip  *string
+	}
+	type req struct {
+		Name    string
+		Address address
+		Scores  *map[string]int
+	}
+	type res struct {
+		Summary string
+	}
+
+	tt := []struct {
+		name     string
+		body     string
+		response string
+	}{
+		{
+			name:     "mandatory fields",
+			body:     `{"name": "john", "address": {"city": "Paris"}}`,
+			response: `{"status":"all right","summary":"john Paris - 0"}`,
+		},
+		{
+			name:     "optional fields",
+			body:     `{"name": "john", "address": {"city": "Paris", "zip": "75000"}, "scores": {"a": 1, "b": 2}}`,
+			response: `{"status":"all right","summary":"john Paris 75000 2"}`,
+		},
+		{
+			name:     "missing nested field",
+			body:     `{"name": "john", "address": {"zip": "75000"}}`,
+			response: `{"status":"Address: field 'city': missing: invalid parameter"}`,
+		},
+		{
+			name:     "invalid nested field",
+			body:     `{"name": "john", "address": {"city": 12}}`,
+			response: `{"status":"Address: field 'city': not a string: invalid parameter"}`,
+		},
+		{
+			name:     "invalid map value",
+			body:     `{"name": "john", "address": {"city": "Paris"}, "scores": {"a": "b"}}`,
+			response: `{"status":"Scores: key \"a\": not an integer: invalid parameter"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			builder := &aicra.Builder{}
+			if err := addDefaultTypes(builder); err != nil {
+				t.Fatalf("unexpected error <%v>", err)
+			}
+			if err := builder.Input(validator.MapType{}); err != nil {
+				t.Fatalf("unexpected error <%v>", err)
+			}
+			if err := builder.Setup(strings.NewReader(config)); err != nil {
+				t.Fatalf("setup: unexpected error <%v>", err)
+			}
+			err := aicra.Bind(builder, http.MethodPost, "/users", func(_ context.Context, r req) (*res, error) {
+				zip := "-"
+				if r.Address.Zip != nil {
+					zip = *r.Address.Zip
+				}
+				var scores int
+				if r.Scores != nil {
+					scores = len(*r.Scores)
+				}
+				return &res{Summary: fmt.Sprintf("%s %s %s %d", r.Name, r.Address.City, zip, scores)}, nil
+			})
+			if err != nil {
+				t.Fatalf("bind: unexpected error <%v>", err)
+			}
+			handler, err := builder.Build()
+			if err != nil {
+				t.Fatalf("build: unexpected error <%v>", err)
+			}
+
+			request := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(tc.body))
+			request.Header.Set("Content-Type", "application/json")
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, request)
+
+			if body := strings.TrimSpace(response.Body.String()); body != tc.response {
+				t.Fatalf("invalid response\nactual: %s\nexpect: %s", printEscaped(body), printEscaped(tc.response))
+			}
+		})
+	}
+}
+
+func TestHandlerCollectErrors(t *testing.T) {
+	const config = `[
+		{
+			"method": "POST",
+			"path": "/users/{id}",
+			"info": "info",
+			"scope": [],
+			"in":  {
+				"{id}":     { "info": "info", "type": "int", "name": "ID" },
+				"GET@page": { "info": "info", "type": "?uint", "name": "Page" },
+				"name":     { "info": "info", "type": "string(2,10)", "name": "Name" },
+				"age":      { "info": "info", "type": "uint", "name": "Age" },
+				"email":    { "info": "info", "type": "string", "name": "Email" }
+			},
+			"out": {}
+		}
+	]`
+
+	type req struct {
+		ID    int
+		Page  *uint
+		Name  string
+		Age   uint
+		Email string
+	}
+
+	tt := []struct {
+		name     string
+		collect  bool
+		url      string
+		body     string
+		status   int
+		response string
+	}{
+		{
+			name:     "valid",
+			collect:  true,
+			url:      "/users/1?page=2",
+			body:     `{"name": "john", "age": 12, "email": "john@host"}`,
+			status:   http.StatusOK,
+			response: `{"status":"all right"}`,
+		},
+		{
+			name:     "first error only",
+			collect:  false,
+			url:      "/users/1?page=-2",
+			body:     `{"name": "j", "age": -12}`,
+			status:   http.StatusBadRequest,
+			response: `{"status":"Page: negative value: invalid parameter"}`,
+		},
+		{
+			name:     "every error",
+			collect:  true,
+			url:      "/users/1?page=-2",
+			body:     `{"name": "j", "age": -12}`,
+			status:   http.StatusBadRequest,
+			response: `{"errors":{"GET@page":{"error":"invalid parameter","reason":"negative value"},"age":{"error":"invalid parameter","reason":"negative value"},"email":{"error":"missing parameter"},"name":{"error":"invalid parameter","reason":"length 1 is below min 2"}},"status":"Age: negative value: invalid parameter; Email: missing parameter; Name: length 1 is below min 2: invalid parameter; Page: negative value: invalid parameter"}`,
+		},
+		{
+			name:     "invalid json",
+			collect:  true,
+			url:      "/users/1?page=-2",
+			body:     `{"name": `,
+			status:   http.StatusBadRequest,
+			response: `{"status":"missing parameter"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			builder := &aicra.Builder{}
+			if err := addDefaultTypes(builder); err != nil {
+				t.Fatalf("unexpected error <%v>", err)
+			}
+			builder.SetCollectErrors(tc.collect)
+			if err := builder.Setup(strings.NewReader(config)); err != nil {
+				t.Fatalf("setup: unexpected error <%v>", err)
+			}
+			err := aicra.Bind(builder, http.MethodPost, "/users/{id}", func(context.Context, req) (*struct{}, error) {
+				return nil, nil
+			})
+			if err != nil {
+				t.Fatalf("bind: unexpected error <%v>", err)
+			}
+			handler, err := builder.Build()
+			if err != nil {
+				t.Fatalf("build: unexpected error <%v>", err)
+			}
+
+			request := httptest.NewRequest(http.MethodPost, tc.url, strings.NewReader(tc.body))
+			request.Header.Set("Content-Type", "application/json")
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, request)
+
+			if response.Code != tc.status {
+				t.Fatalf("invalid status\nactual: %d\nexpect: %d", response.Code, tc.status)
+			}
+			if body := strings.TrimSpace(response.Body.String()); body != tc.response {
+				t.Fatalf("invalid response\nactual: %s\nexpect: %s", printEscaped(body), printEscaped(tc.response))
+			}
+		})
+	}
+}
+
+func TestHandlerRangeParameters(t *testing.T) {
+	const config = `[
+		{
+			"method": "GET",
+			"path": "/items/{id}",
+			"info": "info",
+			"scope": [],
+			"in":  {
+				"{id}":      { "info": "info", "type": "uint(,<100)", "name": "ID" },
+				"GET@ratio": { "info": "info", "type": "?float(>0,1)", "name": "Ratio" }
+			},
+			"out": {
+				"kind": { "info": "info", "type": "string", "name": "Kind" }
+			}
+		},
+		{
+			"method": "GET",
+			"path": "/items/{code}",
+			"info": "info",
+			"scope": [],
+			"in":  {
+				"{code}": { "info": "info", "type": "int(100,)", "name": "Code" }
+			},
+			"out": {
+				"kind": { "info": "info", "type": "string", "name": "Kind" }
+			}
+		}
+	]`
+
+	type res struct {
+		Kind string
+	}
+
+	binders := []func(*aicra.Builder) error{
+		bind(http.MethodGet, "/items/{id}", func(context.Context, struct {
+			ID    uint
+			Ratio *float64
+		}) (*res, error) {
+			return &res{Kind: "id"}, nil
+		}),
+		bind(http.MethodGet, "/items/{code}", func(context.Context, struct{ Code int }) (*res, error) {
+			return &res{Kind: "code"}, nil
+		}),
+	}
+
+	testResponses(t, config, binders, []responseCase{
+		{
+			name:     "lower range",
+			url:      "/items/99?ratio=0.5",
+			response: `{"kind":"id","status":"all right"}`,
+		},
+		{
+			name:     "upper range",
+			url:      "/items/100",
+			response: `{"kind":"code","status":"all right"}`,
+		},
+		{
+			name:     "no range",
+			url:      "/items/-1",
+			response: `{"status":"unknown path"}`,
+		},
+		{
+			name:     "exclusive bound",
+			url:      "/items/1?ratio=0",
+			response: `{"status":"Ratio: must be above 0: invalid parameter"}`,
+		},
+		{
+			name:     "inclusive bound",
+			url:      "/items/1?ratio=1.5",
+			response: `{"status":"Ratio: exceeds max 1: invalid parameter"}`,
+		},
+	})
+}
+
+func TestHandlerEnumParameters(t *testing.T) {
+	const config = `{
+		"types": {
+			"order": "enum(asc,desc)"
+		},
+		"services": [
+			{
+				"method": "GET",
+				"path": "/items/{id}",
+				"info": "info",
+				"scope": [],
+				"in":  {
+					"{id}":      { "info": "info", "type": "uint", "name": "ID" },
+					"GET@order": { "info": "info", "type": "?order", "name": "Order" }
+				},
+				"out": {
+					"kind": { "info": "info", "type": "string", "name": "Kind" }
+				}
+			},
+			{
+				"method": "GET",
+				"path": "/items/{list}",
+				"info": "info",
+				"scope": [],
+				"in":  {
+					"{list}": { "info": "info", "type": "enum(new, top)", "name": "List" }
+				},
+				"out": {
+					"kind": { "info": "info", "type": "string", "name": "Kind" }
+				}
+			}
+		]
+	}`
+
+	type res struct {
+		Kind string
+	}
+
+	binders := []func(*aicra.Builder) error{
+		bind(http.MethodGet, "/items/{id}", func(_ context.Context, in struct {
+			ID    uint
+			Order *string
+		}) (*res, error) {
+			if in.Order == nil {
+				return &res{Kind: "id"}, nil
+			}
+			return &res{Kind: "id " + *in.Order}, nil
+		}),
+		bind(http.MethodGet, "/items/{list}", func(_ context.Context, in struct{ List string }) (*res, error) {
+			return &res{Kind: in.List}, nil
+		}),
+	}
+
+	testResponses(t, config, binders, []responseCase{
+		{
+			name:     "named enum",
+			url:      "/items/1?order=desc",
+			response: `{"kind":"id desc","status":"all right"}`,
+		},
+		{
+			name:     "inline enum",
+			url:      "/items/top",
+			response: `{"kind":"top","status":"all right"}`,
+		},
+		{
+			name:     "unexpected value",
+			url:      "/items/1?order=random",
+			response: `{"status":"Order: not one of asc, desc: invalid parameter"}`,
+		},
+		{
+			name:     "unexpected capture",
+			url:      "/items/old",
+			response: `{"status":"unknown path"}`,
+		},
+	})
+}
+
+func TestHandlerPatternParameters(t *testing.T) {
+	const config = `{
+		"types": {
+			"slug": "string(/^[a-z0-9-]+$/)"
+		},
+		"services": [
+			{
+				"method": "GET",
+				"path": "/items/{slug}",
+				"info": "info",
+				"scope": [],
+				"in":  {
+					"{slug}": { "info": "info", "type": "slug", "name": "Slug" }
+				},
+				"out": {
+					"kind": { "info": "info", "type": "string", "name": "Kind" }
+				}
+			},
+			{
+				"method": "GET",
+				"path": "/items/NEW",
+				"info": "info",
+				"scope": [],
+				"out": {
+					"kind": { "info": "info", "type": "string", "name": "Kind" }
+				}
+			},
+			{
+				"method": "GET",
+				"path": "/colors",
+				"info": "info",
+				"scope": [],
+				"in":  {
+					"GET@hex": { "info": "info", "type": "string(/^#[0-9a-f]{6}$/)", "name": "Hex" }
+				},
+				"out": {
+					"kind": { "info": "info", "type": "string", "name": "Kind" }
+				}
+			}
+		]
+	}`
+
+	type res struct {
+		Kind string
+	}
+
+	binders := []func(*aicra.Builder) error{
+		bind(http.MethodGet, "/items/{slug}", func(_ context.Context, in struct{ Slug string }) (*res, error) {
+			return &res{Kind: in.Slug}, nil
+		}),
+		bind(http.MethodGet, "/items/NEW", func(context.Context, struct{}) (*res, error) {
+			return &res{Kind: "new"}, nil
+		}),
+		bind(http.MethodGet, "/colors", func(_ context.Context, in struct{ Hex string }) (*res, error) {
+			return &res{Kind: in.Hex}, nil
+		}),
+	}
+
+	testResponses(t, config, binders, []responseCase{
+		{
+			name:     "matching capture",
+			url:      "/items/my-slug",
+			response: `{"kind":"my-slug","status":"all right"}`,
+		},
+		{
+			name:     "static path excluded by the pattern",
+			url:      "/items/NEW",
+			response: `{"kind":"new","status":"all right"}`,
+		},
+		{
+			name:     "mismatching capture",
+			url:      "/items/My-Slug",
+			response: `{"status":"unknown path"}`,
+		},
+		{
+			name:     "matching query",
+			url:      "/colors?hex=%2300ff7f",
+			response: `{"kind":"#00ff7f","status":"all right"}`,
+		},
+		{
+			name:     "mismatching query",
+			url:      "/colors?hex=red",
+			response: `{"status":"Hex: does not match ^#[0-9a-f]{6}$: invalid parameter"}`,
+		},
+	})
+}
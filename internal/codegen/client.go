@@ -0,0 +1,363 @@
+package codegen
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xdrm-io/aicra/internal/config"
+)
+
+// clientNames are declared by the generated client and cannot be used by
+// object schemas
+var clientNames = []string{"Client", "NewClient"}
+
+// GenerateClient writes a go client of the api: the request and response
+// types of every service and a Client type with a method calling each
+// service, e.g. for "GET /user/{id}" the Client's GetUserByID method.
+//
+// Captures are written in the path, "GET@" parameters in the query, "HEADER@"
+// and "COOKIE@" parameters as headers and cookies, and form parameters in a
+// json body. Optional parameters are pointers sent when non-nil, including the
+// ones with a default value so that their zero value can be sent. Responses
+// of the default responder are decoded into the response types, error
+// statuses are returned as api.Err values that match the errors of the api
+// package with errors.Is().
+func GenerateClient(w io.Writer, srv *config.Server, types *Types, pkg string) error {
+	var f = newFile(pkg, types)
+	f.optionalPointers = true
+
+	if err := f.registerNamedTypes(srv); err != nil {
+		return err
+	}
+
+	var names = serviceNames(srv.Services)
+	for i, service := range srv.Services {
+		in, out, err := f.writeTypes(names[i], service)
+		if err != nil {
+			return fmt.Errorf("%s %q: %w", service.Method, service.Pattern, err)
+		}
+		f.writeCall(names[i], service, in, out)
+	}
+	for _, name := range clientNames {
+		if _, taken := f.aliasByName[name]; taken {
+			return fmt.Errorf("type %q: %w", name, ErrReservedName)
+		}
+	}
+	f.writeClient()
+
+	_, err := f.WriteTo(w)
+	return err
+}
+
+// writeCall writes the Client method calling a service
+func (f *file) writeCall(name string, service *config.Service, in, out []field) {
+	var byName = make(map[string]field, len(in))
+	for _, field := range in {
+		byName[field.name] = field
+	}
+
+	f.printf("// %s calls %s\n", name, route(service))
+	f.printf("func (c *Client) %s(ctx context.Context, req %sReq) (*%sRes, error) {\n", name, name, name)
+	f.printf("var call = newCall(%q, %s)\n", service.Method, pathExpr(service))
+
+	for _, group := range []struct {
+		params map[string]*config.Parameter
+		set    string
+		add    string
+	}{
+		{service.Query, "call.query.Set(%q, format(%s))", "call.query.Add(%q, format(%s))"},
+		{service.Header, "call.header.Set(%q, format(%s))", "call.header.Add(%q, format(%s))"},
+		{service.Cookie, "call.cookie(%q, format(%s))", ""},
+		{service.Form, "call.form[%q] = %s", ""},
+	} {
+		for _, key := range sortedParams(group.params) {
+			var (
+				param = group.params[key]
+				field = byName[param.Rename]
+				value = "req." + field.name
+			)
+			if field.pointer {
+				f.printf("if req.%s != nil {\n", field.name)
+				value = "*" + value
+			}
+
+			// repeated values for slices, as the server gathers them
+			if len(group.add) > 0 && isSlice(param.GoType) {
+				f.printf("for _, v := range %s {\n", value)
+				f.printf(group.add+"\n", key, "v")
+				f.printf("}\n")
+			} else {
+				f.printf(group.set+"\n", key, value)
+			}
+
+			if field.pointer {
+				f.printf("}\n")
+			}
+		}
+	}
+
+	if len(out) < 1 {
+		f.printf("if _, err := c.do(ctx, call); err != nil {\n")
+		f.printf("return nil, err\n")
+		f.printf("}\n")
+		f.printf("return &%sRes{}, nil\n", name)
+		f.printf("}\n\n")
+		return
+	}
+
+	f.printf("fields, err := c.do(ctx, call)\n")
+	f.printf("if err != nil {\n")
+	f.printf("return nil, err\n")
+	f.printf("}\n")
+	f.printf("var res %sRes\n", name)
+
+	var keys = make(map[string]string, len(service.Output))
+	for key, param := range service.Output {
+		keys[param.Rename] = key
+	}
+	for _, field := range out {
+		f.printf("if err := decode(fields, %q, &res.%s); err != nil {\n", keys[field.name], field.name)
+		f.printf("return nil, err\n")
+		f.printf("}\n")
+	}
+	f.printf("return &res, nil\n")
+	f.printf("}\n\n")
+}
+
+// pathExpr returns the go expression of the path of a service request, e.g.
+// "/user/" + url.PathEscape(format(req.ID)) for "/user/{id}"
+func pathExpr(service *config.Service) string {
+	var captures = make(map[int]string, len(service.Captures))
+	for _, capture := range service.Captures {
+		captures[capture.Index] = capture.Ref.Rename
+	}
+
+	var (
+		parts   []string
+		literal string
+	)
+	for i, segment := range config.SplitURI(service.Pattern) {
+		literal += "/"
+		if rename, isCapture := captures[i]; isCapture {
+			parts = append(parts, strconv.Quote(literal), fmt.Sprintf("url.PathEscape(format(req.%s))", rename))
+			literal = ""
+			continue
+		}
+		literal += segment
+	}
+	if len(parts) < 1 && len(literal) < 1 {
+		literal = "/"
+	}
+	if len(literal) > 0 {
+		parts = append(parts, strconv.Quote(literal))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// writeClient writes the Client type and the helpers used by its methods
+func (f *file) writeClient() {
+	for _, importPath := range []string{
+		"bytes", "context", "encoding", "encoding/json", "fmt", "io",
+		"net/http", "net/url", "strings", apiImport,
+	} {
+		f.use(importPath)
+	}
+	f.printf("%s", clientSource)
+}
+
+// sortedParams returns the keys of parameters sorted alphabetically
+func sortedParams(params map[string]*config.Parameter) []string {
+	var keys = make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isSlice returns whether values of a go type are sent as repeated values,
+// raw bytes being sent as a single value
+func isSlice(t reflect.Type) bool {
+	return t != nil && t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8
+}
+
+// clientSource is the code shared by the methods of the generated client
+const clientSource = `// Client calls the services of the api
+type Client struct {
+	// URL of the api, e.g. "https://example.com/api"
+	URL string
+	// HTTP client sending requests, http.DefaultClient when nil
+	HTTP *http.Client
+	// Header is added to every request, e.g. for authentication
+	Header http.Header
+}
+
+// NewClient returns a client of the api at the given url
+func NewClient(baseURL string) *Client {
+	return &Client{URL: baseURL, Header: make(http.Header)}
+}
+
+// call is a request being built
+type call struct {
+	method  string
+	path    string
+	query   url.Values
+	header  http.Header
+	cookies []*http.Cookie
+	form    map[string]interface{}
+}
+
+func newCall(method, path string) *call {
+	return &call{
+		method: method,
+		path:   path,
+		query:  make(url.Values),
+		header: make(http.Header),
+		form:   make(map[string]interface{}),
+	}
+}
+
+func (c *call) cookie(name, value string) {
+	c.cookies = append(c.cookies, &http.Cookie{Name: name, Value: value})
+}
+
+// do sends a request and returns the fields of the response
+func (c *Client) do(ctx context.Context, call *call) (map[string]json.RawMessage, error) {
+	var uri = strings.TrimSuffix(c.URL, "/") + call.path
+	if len(call.query) > 0 {
+		uri += "?" + call.query.Encode()
+	}
+
+	var body io.Reader
+	if len(call.form) > 0 {
+		encoded, err := json.Marshal(call.form)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, call.method, uri, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for _, header := range []http.Header{c.Header, call.header} {
+		for key, values := range header {
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+	}
+	for _, cookie := range call.cookies {
+		req.AddCookie(cookie)
+	}
+
+	var client = c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var fields map[string]json.RawMessage
+	decodeErr := json.NewDecoder(res.Body).Decode(&fields)
+	if decodeErr == io.EOF {
+		decodeErr = nil
+	}
+
+	// errors of the default responder
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		var status string
+		if raw, ok := fields["status"]; ok {
+			_ = json.Unmarshal(raw, &status)
+		}
+		if len(status) < 1 {
+			status = http.StatusText(res.StatusCode)
+		}
+		return nil, responseError(res.StatusCode, status)
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("invalid response: %w", decodeErr)
+	}
+	return fields, nil
+}
+
+// knownErrors are the errors of the api package a response error can wrap
+var knownErrors = []api.Err{
+	api.ErrFailure, api.ErrNotFound, api.ErrAlreadyExists, api.ErrCreate,
+	api.ErrUpdate, api.ErrDelete, api.ErrTransaction, api.ErrUnknownPath,
+	api.ErrMethodNotAllowed, api.ErrUncallableService, api.ErrNotImplemented,
+	api.ErrUnauthorized, api.ErrForbidden, api.ErrMissingParam,
+	api.ErrInvalidParam, api.ErrURITooLong, api.ErrBodyTooLarge,
+}
+
+// wrappedError is an api error wrapping a known error, e.g. "400:Name:
+// invalid parameter" wraps api.ErrInvalidParam
+type wrappedError struct {
+	api.Err
+	known api.Err
+}
+
+// Unwrap returns the known error
+func (e wrappedError) Unwrap() error {
+	return e.known
+}
+
+// responseError returns the api error of an error response, it can be
+// compared to the errors of the api package with errors.Is()
+func responseError(status int, message string) error {
+	var err = api.Err(fmt.Sprintf("%d:%s", status, message))
+	for _, known := range knownErrors {
+		if known.Status() != status {
+			continue
+		}
+		if err == known {
+			return known
+		}
+		if strings.HasSuffix(message, ": "+known.Error()) {
+			return wrappedError{Err: err, known: known}
+		}
+	}
+	return err
+}
+
+// decode a field of the response
+func decode(fields map[string]json.RawMessage, key string, dst interface{}) error {
+	raw, ok := fields[key]
+	if !ok {
+		return nil
+	}
+	if err := json.Unmarshal(raw, dst); err != nil {
+		return fmt.Errorf("invalid response field %q: %w", key, err)
+	}
+	return nil
+}
+
+// format returns the text representation of a parameter
+func format(value interface{}) string {
+	switch typed := value.(type) {
+	case string:
+		return typed
+	case []byte:
+		return string(typed)
+	case encoding.TextMarshaler:
+		text, err := typed.MarshalText()
+		if err == nil {
+			return string(text)
+		}
+	case fmt.Stringer:
+		return typed.String()
+	}
+	return fmt.Sprint(value)
+}
+`
@@ -0,0 +1,260 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"go/parser"
+	"go/token"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const clientConfig = `{
+	"types": {
+		"address": { "type": "object", "fields": {
+			"city": { "info": "info", "type": "string", "name": "City" }
+		} }
+	},
+	"services": [
+		{
+			"method": "PUT",
+			"path": "/user/{id}/tags",
+			"info": "info",
+			"in": {
+				"{id}": { "info": "info", "type": "uint", "name": "ID" },
+				"GET@ids": { "info": "info", "type": "?[]int", "name": "IDs" },
+				"HEADER@X-Tenant": { "info": "info", "type": "string", "name": "Tenant" },
+				"COOKIE@session": { "info": "info", "type": "?string", "name": "Session" },
+				"name": { "info": "info", "type": "string", "name": "Name" },
+				"limit": { "info": "info", "type": "?int", "name": "Limit", "default": 10 },
+				"address": { "info": "info", "type": "?address", "name": "Address" }
+			},
+			"out": {
+				"full-name": { "info": "info", "type": "string", "name": "FullName" }
+			}
+		},
+		{
+			"method": "GET",
+			"path": "/",
+			"info": "info"
+		}
+	]
+}`
+
+func TestGenerateClient(t *testing.T) {
+	t.Parallel()
+
+	var types = &Types{}
+	srv := types.Server()
+	if err := srv.Parse(strings.NewReader(clientConfig)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var code bytes.Buffer
+	if err := GenerateClient(&code, srv, types, "client"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "client.go", code.Bytes(), 0); err != nil {
+		t.Fatalf("invalid go code: %s\n%s", err, code.String())
+	}
+
+	expect := []string{
+		"package client",
+		`"github.com/xdrm-io/aicra/api"`,
+		"type Address = struct {",
+		"type PutUserByIDTagsReq struct {",
+		"func (c *Client) PutUserByIDTags(ctx context.Context, req PutUserByIDTagsReq) (*PutUserByIDTagsRes, error) {",
+		`var call = newCall("PUT", "/user/"+url.PathEscape(format(req.ID))+"/tags")`,
+		"for _, v := range *req.IDs {",
+		`call.query.Add("ids", format(v))`,
+		`call.header.Set("X-Tenant", format(req.Tenant))`,
+		`call.cookie("session", format(*req.Session))`,
+		`call.form["address"] = *req.Address`,
+		"if req.Limit != nil {",
+		`call.form["limit"] = *req.Limit`,
+		`call.form["name"] = req.Name`,
+		`if err := decode(fields, "full-name", &res.FullName); err != nil {`,
+		`var call = newCall("GET", "/")`,
+		"return &GetRootRes{}, nil",
+		"type Client struct {",
+		"func NewClient(baseURL string) *Client {",
+		"return nil, responseError(res.StatusCode, status)",
+	}
+	for _, snippet := range expect {
+		if !strings.Contains(code.String(), snippet) {
+			t.Fatalf("missing code %q in\n%s", snippet, code.String())
+		}
+	}
+}
+
+// clientMain calls the generated client with the zero value of the optional
+// "limit" parameter that has a default value
+const clientMain = `package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"clienttest/client"
+)
+
+func main() {
+	var limit = 0
+	_, err := client.NewClient(os.Args[1]).PutUserByIDTags(context.Background(), client.PutUserByIDTagsReq{
+		ID:     1,
+		Tenant: "tenant",
+		Name:   "name",
+		Limit:  &limit,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+`
+
+func TestGenerateClientZeroValue(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a go program")
+	}
+	t.Parallel()
+
+	var types = &Types{}
+	srv := types.Server()
+	if err := srv.Parse(strings.NewReader(clientConfig)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var code bytes.Buffer
+	if err := GenerateClient(&code, srv, types, "client"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// module using the generated client against this repository
+	root, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	sum, err := os.ReadFile(filepath.Join(root, "go.sum"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var (
+		dir   = t.TempDir()
+		files = map[string][]byte{
+			"go.mod":           []byte("module clienttest\n\ngo 1.18\n\nrequire github.com/xdrm-io/aicra v0.0.0\n\nreplace github.com/xdrm-io/aicra => " + root + "\n"),
+			"go.sum":           sum,
+			"main.go":          []byte(clientMain),
+			"client/client.go": code.Bytes(),
+		}
+	)
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	var bodies = make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies <- body
+		w.Write([]byte(`{"full-name":"name"}`))
+	}))
+	defer server.Close()
+
+	cmd := exec.Command("go", "run", ".", server.URL)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go run: %s\n%s", err, out)
+	}
+
+	var form map[string]interface{}
+	if err := json.Unmarshal(<-bodies, &form); err != nil {
+		t.Fatalf("invalid body: %s", err)
+	}
+	expect := map[string]interface{}{"name": "name", "limit": float64(0)}
+	if !reflect.DeepEqual(form, expect) {
+		t.Fatalf("invalid form\nactual: %v\nexpect: %v", form, expect)
+	}
+}
+
+func TestGenerateClientReservedName(t *testing.T) {
+	t.Parallel()
+
+	var types = &Types{}
+	srv := types.Server()
+	err := srv.Parse(strings.NewReader(`{
+		"types": {
+			"client": { "type": "object", "fields": {
+				"id": { "info": "info", "type": "int", "name": "ID" }
+			} }
+		},
+		"services": [
+			{
+				"method": "POST",
+				"path": "/",
+				"info": "info",
+				"in": {
+					"client": { "info": "info", "type": "client", "name": "Client" }
+				}
+			}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	err = GenerateClient(&bytes.Buffer{}, srv, types, "client")
+	if !errors.Is(err, ErrReservedName) {
+		t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, ErrReservedName)
+	}
+}
+
+func TestPathExpr(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		path   string
+		in     string
+		expect string
+	}{
+		{"/", ``, `"/"`},
+		{"/user", ``, `"/user"`},
+		{"/user/{id}", `"{id}": { "info": "info", "type": "uint", "name": "ID" }`, `"/user/" + url.PathEscape(format(req.ID))`},
+		{
+			"/{a}/{b}/c",
+			`"{a}": { "info": "info", "type": "uint", "name": "A" }, "{b}": { "info": "info", "type": "uint", "name": "B" }`,
+			`"/" + url.PathEscape(format(req.A)) + "/" + url.PathEscape(format(req.B)) + "/c"`,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.path, func(t *testing.T) {
+			t.Parallel()
+
+			var types = &Types{}
+			srv := types.Server()
+			conf := `[{ "method": "GET", "path": "` + tc.path + `", "info": "info", "in": {` + tc.in + `} }]`
+			if err := srv.Parse(strings.NewReader(conf)); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if actual := pathExpr(srv.Services[0]); actual != tc.expect {
+				t.Fatalf("invalid path\nactual: %s\nexpect: %s", actual, tc.expect)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package codegen
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/xdrm-io/aicra/internal/config"
+	"github.com/xdrm-io/aicra/internal/naming"
+)
+
+const aicraImport = "github.com/xdrm-io/aicra"
+
+// field of a generated request or response struct
+type field struct {
+	name string
+	expr string
+	// pointer is set for optional inputs without default value, or for every
+	// optional input with file.optionalPointers, `expr` is then the pointer
+	// type
+	pointer bool
+	// elem is the type expression of the value without the pointer
+	elem string
+	// source is the type expression of validated values when they must be
+	// converted into `elem`, e.g. "[]string" for a slice of enums
+	source string
+}
+
+// fields returns the struct fields of parameters sorted by name, `prefix` is
+// used to name object schemas
+func (f *file) fields(params map[string]*config.Parameter, prefix string, input bool) ([]field, error) {
+	var fields = make([]field, 0, len(params))
+	for key, param := range params {
+		if !isExported(param.Rename) {
+			return nil, fmt.Errorf("%s: %s: %w", prefix, key, ErrUnexportedName)
+		}
+
+		var alias = prefix + param.Rename
+		if def := f.namedType(param); len(def) > 0 {
+			alias = def
+		}
+		elem, err := f.typeExpr(param.GoType, alias)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", prefix, key, err)
+		}
+		var source string
+		if expr, ok := f.enumExpr(param.Type, prefix+param.Rename); ok {
+			source, elem = elem, expr
+		}
+
+		var pointer = input && param.Optional && (param.Default == nil || f.optionalPointers)
+		var expr = elem
+		if pointer {
+			expr = "*" + elem
+		}
+		fields = append(fields, field{name: param.Rename, expr: expr, pointer: pointer, elem: elem, source: source})
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].name < fields[j].name
+	})
+	return fields, nil
+}
+
+// namedType returns the go name of the named object type used by a parameter,
+// if any. Named object types are registered first so that parameters using
+// them share the same alias.
+func (f *file) namedType(param *config.Parameter) string {
+	if t, exists := f.aliasByName[naming.GoName(param.Type)]; exists && t == param.GoType {
+		return naming.GoName(param.Type)
+	}
+	return ""
+}
+
+// registerNamedTypes creates an alias for every named object type and an enum
+// for every named enum
+func (f *file) registerNamedTypes(srv *config.Server) error {
+	f.avail = srv.Input
+
+	var defs = srv.Types
+	var names = make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if values := enumValues(defs[name].Type, f.avail); values != nil {
+			f.namedEnums[name] = f.enum(naming.GoName(name), values)
+			continue
+		}
+		var t = defs[name].GoType
+		if t == nil || t.Kind() != reflect.Struct || len(t.Name()) > 0 {
+			continue
+		}
+		if _, err := f.alias(t, naming.GoName(name)); err != nil {
+			return fmt.Errorf("type %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// GenerateCodecs writes the request and response types of every service along
+// with codecs converting data without reflection and functions binding
+// handlers to the builder with them, e.g. for "GET /user/{id}":
+//   - GetUserByIDReq and GetUserByIDRes types
+//   - GetUserByIDCodec, the aicra.Codec of the service
+//   - BindGetUserByID(), that binds a handler using the codec
+func GenerateCodecs(w io.Writer, srv *config.Server, types *Types, pkg string) error {
+	var f = newFile(pkg, types)
+	f.use(aicraImport)
+
+	if err := f.registerNamedTypes(srv); err != nil {
+		return err
+	}
+
+	var names = serviceNames(srv.Services)
+	for i, service := range srv.Services {
+		in, out, err := f.writeTypes(names[i], service)
+		if err != nil {
+			return fmt.Errorf("%s %q: %w", service.Method, service.Pattern, err)
+		}
+		f.writeCodec(names[i], service, in, out)
+
+		f.printf("// Bind%s binds a handler to %s using its codec\n", names[i], route(service))
+		f.printf("func Bind%s(b *aicra.Builder, fn aicra.HandlerFunc[%sReq, %sRes]) error {\n", names[i], names[i], names[i])
+		f.printf("return aicra.BindCodec(b, %q, %q, fn, %sCodec)\n", service.Method, service.Pattern, names[i])
+		f.printf("}\n\n")
+	}
+
+	_, err := f.WriteTo(w)
+	return err
+}
+
+// route returns the readable route of a service, e.g. "GET /user/{id}"
+func route(service *config.Service) string {
+	return fmt.Sprintf("%s %s", service.Method, service.Pattern)
+}
+
+// writeTypes writes the request and response types of a service and returns
+// their fields
+func (f *file) writeTypes(name string, service *config.Service) (in, out []field, err error) {
+	in, err = f.fields(service.Input, name, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("field 'in': %w", err)
+	}
+	out, err = f.fields(service.Output, name, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("field 'out': %w", err)
+	}
+
+	f.printf("// %sReq is the request of %s\n", name, route(service))
+	f.printf("type %sReq struct {\n", name)
+	for _, field := range in {
+		f.printf("%s %s\n", field.name, field.expr)
+	}
+	f.printf("}\n\n")
+
+	f.printf("// %sRes is the response of %s\n", name, route(service))
+	f.printf("type %sRes struct {\n", name)
+	for _, field := range out {
+		f.printf("%s %s\n", field.name, field.expr)
+	}
+	f.printf("}\n\n")
+	return in, out, nil
+}
+
+// writeCodec writes the codec of a service from the fields of its types
+func (f *file) writeCodec(name string, service *config.Service, in, out []field) {
+	f.printf("// %sCodec converts the data of %s without reflection\n", name, route(service))
+	f.printf("var %sCodec = aicra.Codec[%sReq, %sRes]{\n", name, name, name)
+
+	f.printf("Decode: func(in map[string]interface{}) (req %sReq) {\n", name)
+	for _, field := range in {
+		f.printf("if v, ok := in[%q]; ok {\n", field.name)
+		var cast = fmt.Sprintf("v.(%s)", field.elem)
+		switch {
+		case field.elem == "interface{}":
+			cast = "v"
+		case len(field.source) > 0:
+			cast = f.enumCast(field.elem, fmt.Sprintf("v.(%s)", field.source), 0)
+		}
+		if field.pointer {
+			f.printf("cast := %s\n", cast)
+			f.printf("req.%s = &cast\n", field.name)
+		} else {
+			f.printf("req.%s = %s\n", field.name, cast)
+		}
+		f.printf("}\n")
+	}
+	f.printf("return req\n")
+	f.printf("},\n")
+
+	f.printf("Encode: func(res *%sRes) map[string]interface{} {\n", name)
+	if len(out) < 1 {
+		f.printf("return nil\n")
+	} else {
+		f.printf("return map[string]interface{}{\n")
+		for _, field := range out {
+			f.printf("%q: res.%s,\n", field.name, field.name)
+		}
+		f.printf("}\n")
+	}
+	f.printf("},\n")
+	f.printf("}\n\n")
+}
+
+// enumCast returns the go expression converting `src`, a validated value, into
+// the enum type expression `expr`, e.g. "Order(v.(string))". Slices and maps
+// are converted item by item into a variable declared beforehand, `depth`
+// makes the names of nested variables unique.
+func (f *file) enumCast(expr, src string, depth int) string {
+	var prefix string
+	switch {
+	case strings.HasPrefix(expr, "[]"):
+		prefix = "[]"
+	case strings.HasPrefix(expr, "map[string]"):
+		prefix = "map[string]"
+	default:
+		return fmt.Sprintf("%s(%s)", expr, src)
+	}
+
+	var (
+		values = fmt.Sprintf("values%d", depth)
+		enums  = fmt.Sprintf("enums%d", depth)
+		key    = fmt.Sprintf("k%d", depth)
+	)
+	f.printf("%s := %s\n", values, src)
+	f.printf("%s := make(%s, len(%s))\n", enums, expr, values)
+	f.printf("for %s, item := range %s {\n", key, values)
+	item := f.enumCast(expr[len(prefix):], "item", depth+1)
+	f.printf("%s[%s] = %s\n", enums, key, item)
+	f.printf("}\n")
+	return enums
+}
@@ -0,0 +1,183 @@
+package codegen
+
+import (
+	"bytes"
+	"errors"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+)
+
+const codecConfig = `{
+	"types": {
+		"order": "enum(asc,desc)",
+		"user": { "type": "object", "fields": {
+			"name": { "info": "info", "type": "string", "name": "Name" },
+			"id": { "info": "info", "type": "uuid", "name": "ID" }
+		} }
+	},
+	"services": [
+		{
+			"method": "PUT",
+			"path": "/user/{id}",
+			"info": "info",
+			"in": {
+				"{id}": { "info": "info", "type": "uint", "name": "ID" },
+				"GET@dry": { "info": "info", "type": "?bool", "name": "DryRun" },
+				"GET@limit": { "info": "info", "type": "?int", "name": "Limit", "default": 10 },
+				"GET@order": { "info": "info", "type": "?order", "name": "Order" },
+				"GET@locales": { "info": "info", "type": "?[]enum(en-US,fr-FR)", "name": "Locales" },
+				"sorts": { "info": "info", "type": "?map[string][]order", "name": "Sorts" },
+				"user": { "info": "info", "type": "user", "name": "User" },
+				"tags": { "info": "info", "type": "?[]string", "name": "Tags" },
+				"meta": { "info": "info", "type": "?any", "name": "Meta" },
+				"address": { "info": "info", "type": "object", "name": "Address", "fields": {
+					"city": { "info": "info", "type": "string", "name": "City" }
+				} }
+			},
+			"out": {
+				"name": { "info": "info", "type": "string", "name": "Name" },
+				"status": { "info": "info", "type": "enum(active,banned)", "name": "Status" }
+			}
+		},
+		{
+			"method": "GET",
+			"path": "/",
+			"info": "info"
+		}
+	]
+}`
+
+func TestGenerateCodecs(t *testing.T) {
+	t.Parallel()
+
+	var types = &Types{}
+	types.Add("uuid", External{Import: "github.com/google/uuid", Name: "uuid.UUID"})
+
+	srv := types.Server()
+	if err := srv.Parse(strings.NewReader(codecConfig)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var code bytes.Buffer
+	if err := GenerateCodecs(&code, srv, types, "api"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "api.go", code.Bytes(), 0); err != nil {
+		t.Fatalf("invalid go code: %s\n%s", err, code.String())
+	}
+
+	expect := []string{
+		"// Code generated by aicra; DO NOT EDIT.",
+		"package api",
+		`"github.com/google/uuid"`,
+		`"github.com/xdrm-io/aicra"`,
+		"type User = struct {",
+		"ID   uuid.UUID `json:\"id\"`",
+		"type PutUserByIDAddress = struct {",
+		"type PutUserByIDReq struct {",
+		"Address PutUserByIDAddress",
+		"DryRun  *bool",
+		"Limit   int",
+		"Locales *[]PutUserByIDLocalesItem",
+		"Meta    *interface{}",
+		"Order   *Order",
+		"Tags    *[]string",
+		"User    User",
+		"type PutUserByIDRes struct {",
+		"Status PutUserByIDStatus",
+		"// Order is an enum of the configuration",
+		"type Order string",
+		`OrderAsc  Order = "asc"`,
+		`OrderDesc Order = "desc"`,
+		`PutUserByIDLocalesItemEnUS PutUserByIDLocalesItem = "en-US"`,
+		`PutUserByIDStatusBanned PutUserByIDStatus = "banned"`,
+		"cast := Order(v.(string))",
+		"values0 := v.([]string)",
+		"enums0[k0] = PutUserByIDLocalesItem(item)",
+		"req.Locales = &cast",
+		"enums0 := make(map[string][]Order, len(values0))",
+		"values1 := item",
+		"enums1[k1] = Order(item)",
+		"enums0[k0] = enums1",
+		"var PutUserByIDCodec = aicra.Codec[PutUserByIDReq, PutUserByIDRes]{",
+		"req.ID = v.(uint)",
+		"cast := v.(bool)",
+		"req.DryRun = &cast",
+		`"Name":   res.Name,`,
+		`"Status": res.Status,`,
+		"func BindPutUserByID(b *aicra.Builder, fn aicra.HandlerFunc[PutUserByIDReq, PutUserByIDRes]) error {",
+		`return aicra.BindCodec(b, "PUT", "/user/{id}", fn, PutUserByIDCodec)`,
+		"type GetRootReq struct {",
+		"func BindGetRoot(",
+	}
+	for _, snippet := range expect {
+		if !strings.Contains(code.String(), snippet) {
+			t.Fatalf("missing code %q in\n%s", snippet, code.String())
+		}
+	}
+}
+
+func TestGenerateCodecsTypeCheck(t *testing.T) {
+	t.Parallel()
+
+	// the uuid package is not a dependency, any external type is enough to
+	// check imports
+	var types = &Types{}
+	types.Add("uuid", External{Import: "time", Name: "time.Time"})
+
+	srv := types.Server()
+	if err := srv.Parse(strings.NewReader(codecConfig)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var code bytes.Buffer
+	if err := GenerateCodecs(&code, srv, types, "api"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	checkTypes(t, code.String())
+}
+
+// checkTypes fails when the go code of package "api" does not type-check
+func checkTypes(t *testing.T, code string) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "api.go", code, 0)
+	if err != nil {
+		t.Fatalf("invalid go code: %s\n%s", err, code)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("api", fset, []*ast.File{file}, nil); err != nil {
+		t.Fatalf("invalid go code: %s\n%s", err, code)
+	}
+}
+
+func TestGenerateCodecsUnexportedName(t *testing.T) {
+	t.Parallel()
+
+	var types = &Types{}
+	srv := types.Server()
+	err := srv.Parse(strings.NewReader(`[
+		{
+			"method": "GET",
+			"path": "/",
+			"info": "info",
+			"in": {
+				"id": { "info": "info", "type": "int", "name": "id" }
+			}
+		}
+	]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	err = GenerateCodecs(&bytes.Buffer{}, srv, types, "api")
+	if !errors.Is(err, ErrUnexportedName) {
+		t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, ErrUnexportedName)
+	}
+}
@@ -0,0 +1,25 @@
+package codegen
+
+// Err allows you to create constant "const" error with type boxing.
+type Err string
+
+func (err Err) Error() string {
+	return string(err)
+}
+
+const (
+	// ErrInvalidTypeRef - invalid go type reference
+	ErrInvalidTypeRef = Err("invalid go type reference")
+
+	// ErrUnexportedName - parameter name is not an exported go name
+	ErrUnexportedName = Err("parameter name must be an exported go identifier")
+
+	// ErrUnsupportedType - go type that cannot be generated
+	ErrUnsupportedType = Err("unsupported go type")
+
+	// ErrInvalidReceiver - handlers receiver is not a valid go type name
+	ErrInvalidReceiver = Err("invalid receiver type name")
+
+	// ErrReservedName - type name already declared by the generated code
+	ErrReservedName = Err("reserved type name")
+)
@@ -0,0 +1,298 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/xdrm-io/aicra/internal/naming"
+	"github.com/xdrm-io/aicra/validator"
+)
+
+// file builds a go source file, it keeps track of imports, of the aliases
+// of anonymous structs built from object schemas and of enums
+type file struct {
+	pkg   string
+	types *Types
+	// optionalPointers makes every optional input a pointer, including the
+	// ones with a default value, so that clients can send their zero value
+	optionalPointers bool
+	// avail are the input types of the configuration
+	avail []validator.Type
+
+	imports map[string]struct{}
+	// aliases of anonymous structs, in order of creation
+	aliases     map[reflect.Type]string
+	aliasOrder  []reflect.Type
+	aliasByName map[string]reflect.Type
+	// enums in order of creation, named ones are indexed by their typename
+	enums      []*enum
+	namedEnums map[string]*enum
+
+	body bytes.Buffer
+}
+
+// enum is a defined string type along with a constant for each of its values
+type enum struct {
+	name   string
+	values []string
+	consts []string
+}
+
+func newFile(pkg string, types *Types) *file {
+	return &file{
+		pkg:         pkg,
+		types:       types,
+		imports:     make(map[string]struct{}),
+		aliases:     make(map[reflect.Type]string),
+		aliasByName: make(map[string]reflect.Type),
+		namedEnums:  make(map[string]*enum),
+	}
+}
+
+// printf writes formatted code into the file's body
+func (f *file) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&f.body, format, args...)
+}
+
+// use an import path
+func (f *file) use(importPath string) {
+	if len(importPath) > 0 {
+		f.imports[importPath] = struct{}{}
+	}
+}
+
+// alias returns the name of an anonymous struct type, it is created with the
+// `name` hint when missing
+func (f *file) alias(t reflect.Type, name string) (string, error) {
+	if alias, exists := f.aliases[t]; exists {
+		return alias, nil
+	}
+
+	// ensure unique names
+	var unique = f.unique(name)
+	f.aliases[t] = unique
+	f.aliasByName[unique] = t
+	f.aliasOrder = append(f.aliasOrder, t)
+
+	// resolve field types to create nested aliases
+	for i := 0; i < t.NumField(); i++ {
+		var field = t.Field(i)
+		if !isExported(field.Name) {
+			return "", fmt.Errorf("%s: %w", t, ErrUnsupportedType)
+		}
+		if _, err := f.typeExpr(field.Type, unique+field.Name); err != nil {
+			return "", err
+		}
+	}
+	return unique, nil
+}
+
+// enum creates an enum named after the `name` hint
+func (f *file) enum(name string, values []string) *enum {
+	var e = &enum{
+		name:   f.unique(name),
+		values: values,
+		consts: make([]string, 0, len(values)),
+	}
+	for i, value := range values {
+		var hint = e.name + naming.GoName(value)
+		if hint == e.name {
+			hint = fmt.Sprintf("%sN%d", e.name, i)
+		}
+		e.consts = append(e.consts, f.unique(hint))
+	}
+	f.enums = append(f.enums, e)
+	return e
+}
+
+// unique reserves and returns a type name from the `name` hint, a number is
+// appended when the name is taken
+func (f *file) unique(name string) string {
+	var unique = name
+	for i := 2; ; i++ {
+		if _, taken := f.aliasByName[unique]; !taken {
+			break
+		}
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	f.aliasByName[unique] = nil
+	return unique
+}
+
+// enumExpr returns the go expression of a typename using enums, e.g.
+// "[]Order" for a slice of named enums. Enums are created for inline enum
+// typenames with the `name` hint. It returns false when the typename does not
+// use enums
+func (f *file) enumExpr(typename, name string) (string, bool) {
+	switch {
+	case strings.HasPrefix(typename, "[]"):
+		elem, ok := f.enumExpr(typename[len("[]"):], name+"Item")
+		return "[]" + elem, ok
+	case strings.HasPrefix(typename, "map[string]"):
+		elem, ok := f.enumExpr(typename[len("map[string]"):], name+"Item")
+		return "map[string]" + elem, ok
+	}
+	if e, exists := f.namedEnums[typename]; exists {
+		return e.name, true
+	}
+	values := enumValues(typename, f.avail)
+	if values == nil {
+		return "", false
+	}
+	return f.enum(name, values).name, true
+}
+
+// enumValues returns the values of a typename described as a string enum, nil
+// when it is not
+func enumValues(typename string, avail []validator.Type) []string {
+	var schema = validator.Describe(typename, avail...)
+	if schema == nil || schema.Type != "string" || len(schema.Enum) < 1 {
+		return nil
+	}
+	if _, goType := validator.Resolve(typename, avail...); goType == nil || goType.Kind() != reflect.String || len(goType.PkgPath()) > 0 {
+		return nil
+	}
+	var values = make([]string, 0, len(schema.Enum))
+	for _, value := range schema.Enum {
+		str, ok := value.(string)
+		if !ok {
+			return nil
+		}
+		values = append(values, str)
+	}
+	return values
+}
+
+// typeExpr returns the go expression of a type, `name` is used to name
+// anonymous structs
+func (f *file) typeExpr(t reflect.Type, name string) (string, error) {
+	// the "any" type has no go type
+	if t == nil {
+		return "interface{}", nil
+	}
+	if ext, ok := f.types.lookup(t); ok {
+		f.use(ext.Import)
+		return ext.Name, nil
+	}
+
+	if len(t.Name()) > 0 {
+		if len(t.PkgPath()) < 1 {
+			return t.Name(), nil
+		}
+		f.use(t.PkgPath())
+		return path.Base(t.PkgPath()) + "." + t.Name(), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		elem, err := f.typeExpr(t.Elem(), name)
+		return "*" + elem, err
+
+	case reflect.Slice:
+		elem, err := f.typeExpr(t.Elem(), name+"Item")
+		return "[]" + elem, err
+
+	case reflect.Map:
+		key, err := f.typeExpr(t.Key(), name+"Key")
+		if err != nil {
+			return "", err
+		}
+		elem, err := f.typeExpr(t.Elem(), name+"Item")
+		return "map[" + key + "]" + elem, err
+
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return "interface{}", nil
+		}
+
+	case reflect.Struct:
+		return f.alias(t, name)
+	}
+	return "", fmt.Errorf("%s: %w", t, ErrUnsupportedType)
+}
+
+// structBody returns the body of a struct type without its braces
+func (f *file) structBody(t reflect.Type) string {
+	var b strings.Builder
+	for i := 0; i < t.NumField(); i++ {
+		var field = t.Field(i)
+		expr, _ := f.typeExpr(field.Type, f.aliases[t]+field.Name)
+		fmt.Fprintf(&b, "\t%s %s", field.Name, expr)
+		if len(field.Tag) > 0 {
+			fmt.Fprintf(&b, " `%s`", field.Tag)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// WriteTo writes the formatted go file
+func (f *file) WriteTo(w io.Writer) (int64, error) {
+	var out bytes.Buffer
+	out.WriteString("// Code generated by aicra; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", f.pkg)
+
+	if len(f.imports) > 0 {
+		var imports = make([]string, 0, len(f.imports))
+		for importPath := range f.imports {
+			imports = append(imports, importPath)
+		}
+		// standard packages first
+		sort.Slice(imports, func(i, j int) bool {
+			if std := isStd(imports[i]); std != isStd(imports[j]) {
+				return std
+			}
+			return imports[i] < imports[j]
+		})
+
+		out.WriteString("import (\n")
+		for i, importPath := range imports {
+			if i > 0 && isStd(imports[i-1]) && !isStd(importPath) {
+				out.WriteString("\n")
+			}
+			fmt.Fprintf(&out, "\t%q\n", importPath)
+		}
+		out.WriteString(")\n\n")
+	}
+
+	// object schemas are aliases of anonymous structs so that validated values
+	// can be used as is
+	for _, t := range f.aliasOrder {
+		fmt.Fprintf(&out, "// %s is an object schema of the configuration\n", f.aliases[t])
+		fmt.Fprintf(&out, "type %s = struct {\n%s}\n\n", f.aliases[t], f.structBody(t))
+	}
+
+	// enums are defined string types so that they cannot be mixed with other
+	// strings, codecs convert validated values. Their values are typed constants
+	for _, e := range f.enums {
+		fmt.Fprintf(&out, "// %s is an enum of the configuration\n", e.name)
+		fmt.Fprintf(&out, "type %s string\n\n", e.name)
+		fmt.Fprintf(&out, "// %s values\n", e.name)
+		out.WriteString("const (\n")
+		for i, value := range e.values {
+			fmt.Fprintf(&out, "\t%s %s = %q\n", e.consts[i], e.name, value)
+		}
+		out.WriteString(")\n\n")
+	}
+
+	out.Write(f.body.Bytes())
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return 0, fmt.Errorf("cannot format generated code: %w", err)
+	}
+	n, err := w.Write(formatted)
+	return int64(n), err
+}
+
+// isStd returns whether an import path is from the standard library
+func isStd(importPath string) bool {
+	first, _, _ := strings.Cut(importPath, "/")
+	return !strings.Contains(first, ".")
+}
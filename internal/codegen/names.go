@@ -0,0 +1,31 @@
+package codegen
+
+import (
+	"go/token"
+	"strconv"
+
+	"github.com/xdrm-io/aicra/internal/config"
+	"github.com/xdrm-io/aicra/internal/naming"
+)
+
+// serviceNames returns a unique go name for every service
+func serviceNames(services []*config.Service) []string {
+	var (
+		names = make([]string, len(services))
+		used  = make(map[string]int, len(services))
+	)
+	for i, service := range services {
+		var name = naming.ServiceName(service)
+		used[name]++
+		if n := used[name]; n > 1 {
+			name = name + strconv.Itoa(n)
+		}
+		names[i] = name
+	}
+	return names
+}
+
+// isExported returns whether a name is a valid exported go identifier
+func isExported(name string) bool {
+	return token.IsIdentifier(name) && token.IsExported(name)
+}
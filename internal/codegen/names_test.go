@@ -0,0 +1,25 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/xdrm-io/aicra/internal/config"
+)
+
+func TestServiceNamesUnique(t *testing.T) {
+	t.Parallel()
+
+	services := []*config.Service{
+		{Method: "GET", Pattern: "/user-id"},
+		{Method: "GET", Pattern: "/user/id"},
+		{Method: "GET", Pattern: "/users"},
+	}
+	names := serviceNames(services)
+
+	expect := []string{"GetUserID", "GetUserID2", "GetUsers"}
+	for i := range expect {
+		if names[i] != expect[i] {
+			t.Fatalf("invalid name %d\nactual: %q\nexpect: %q", i, names[i], expect[i])
+		}
+	}
+}
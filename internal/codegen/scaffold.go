@@ -0,0 +1,239 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/xdrm-io/aicra/internal/config"
+)
+
+const (
+	contextImport = "context"
+	apiImport     = "github.com/xdrm-io/aicra/api"
+)
+
+// GenerateBindings writes the request and response types of every service
+// along with a Bind() function binding the handler methods of `receiver` to
+// the builder, e.g. for "GET /user/{id}" the GetUserByIDReq and
+// GetUserByIDRes types and the receiver's GetUserByID method. Handlers are
+// bound with generated codecs instead of reflection when `codecs` is set.
+func GenerateBindings(w io.Writer, srv *config.Server, types *Types, pkg, receiver string, codecs bool) error {
+	if !token.IsIdentifier(receiver) {
+		return fmt.Errorf("%q: %w", receiver, ErrInvalidReceiver)
+	}
+
+	var f = newFile(pkg, types)
+	f.use(aicraImport)
+
+	if err := f.registerNamedTypes(srv); err != nil {
+		return err
+	}
+
+	var names = serviceNames(srv.Services)
+	for i, service := range srv.Services {
+		in, out, err := f.writeTypes(names[i], service)
+		if err != nil {
+			return fmt.Errorf("%s %q: %w", service.Method, service.Pattern, err)
+		}
+		if codecs {
+			f.writeCodec(names[i], service, in, out)
+		}
+	}
+
+	f.printf("// Bind binds the handlers of every service to the builder\n")
+	f.printf("func Bind(b *aicra.Builder, h *%s) error {\n", receiver)
+	for i, service := range srv.Services {
+		if codecs {
+			f.printf("if err := aicra.BindCodec(b, %q, %q, h.%s, %sCodec); err != nil {\n", service.Method, service.Pattern, names[i], names[i])
+		} else {
+			f.printf("if err := aicra.Bind(b, %q, %q, h.%s); err != nil {\n", service.Method, service.Pattern, names[i])
+		}
+		f.printf("return err\n")
+		f.printf("}\n")
+	}
+	f.printf("return nil\n")
+	f.printf("}\n")
+
+	_, err := f.WriteTo(w)
+	return err
+}
+
+// PackageName returns the package name of the go source `src`, e.g. of an
+// existing handlers file
+func PackageName(src []byte) (string, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.PackageClauseOnly)
+	if err != nil {
+		return "", fmt.Errorf("cannot parse package clause: %w", err)
+	}
+	return file.Name.Name, nil
+}
+
+// ScaffoldHandlers returns the handlers file `src` completed with the
+// `receiver` type and a stub method for every service that does not have one.
+// Existing code is kept as is so that it can be run again when services are
+// added ; `src` is returned unchanged when nothing is missing. An empty `src`
+// creates a new file in the package `pkg`.
+func ScaffoldHandlers(src []byte, srv *config.Server, pkg, receiver string) ([]byte, error) {
+	if !token.IsIdentifier(receiver) {
+		return nil, fmt.Errorf("%q: %w", receiver, ErrInvalidReceiver)
+	}
+	if len(bytes.TrimSpace(src)) < 1 {
+		src = []byte(fmt.Sprintf("package %s\n", pkg))
+	}
+
+	var fset = token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse handlers: %w", err)
+	}
+
+	var (
+		declared, methods = declarations(file, receiver)
+		ctxName, ctxFound = importName(file, contextImport)
+		apiName, apiFound = importName(file, apiImport)
+		stubs             bytes.Buffer
+	)
+
+	if !declared {
+		fmt.Fprintf(&stubs, "// %s implements the services of the configuration\n", receiver)
+		fmt.Fprintf(&stubs, "type %s struct{}\n\n", receiver)
+	}
+
+	var (
+		names = serviceNames(srv.Services)
+		added int
+	)
+	for i, service := range srv.Services {
+		if _, exists := methods[names[i]]; exists {
+			continue
+		}
+		added++
+		fmt.Fprintf(&stubs, "// %s handles %s\n", names[i], route(service))
+		fmt.Fprintf(&stubs, "func (h *%s) %s(ctx %s.Context, req %sReq) (*%sRes, error) {\n", receiver, names[i], ctxName, names[i], names[i])
+		fmt.Fprintf(&stubs, "return nil, %s.ErrNotImplemented\n", apiName)
+		fmt.Fprintf(&stubs, "}\n\n")
+	}
+
+	if stubs.Len() < 1 {
+		return src, nil
+	}
+
+	// missing imports are added to the first import block or after the
+	// package clause when there is none ; standard packages come first
+	var std, others []string
+	if !ctxFound && added > 0 {
+		std = append(std, strconv.Quote(contextImport))
+	}
+	if !apiFound && added > 0 {
+		others = append(others, strconv.Quote(apiImport))
+	}
+
+	var out bytes.Buffer
+	if block := importBlock(file); block != nil {
+		var (
+			lparen = fset.Position(block.Lparen).Offset + 1
+			rparen = fset.Position(block.Rparen).Offset
+		)
+		out.Write(src[:lparen])
+		for _, imp := range std {
+			out.WriteString("\n" + imp)
+		}
+		out.Write(src[lparen:rparen])
+		if len(others) > 0 {
+			out.WriteString("\n\n" + strings.Join(others, "\n") + "\n")
+		}
+		out.Write(src[rparen:])
+	} else {
+		var offset = fset.Position(file.Name.End()).Offset
+		out.Write(src[:offset])
+		if len(std)+len(others) > 0 {
+			out.WriteString("\n\nimport (\n")
+			out.WriteString(strings.Join(std, "\n"))
+			if len(std) > 0 && len(others) > 0 {
+				out.WriteString("\n\n")
+			}
+			out.WriteString(strings.Join(others, "\n"))
+			out.WriteString("\n)")
+		}
+		out.Write(src[offset:])
+	}
+	out.WriteString("\n")
+	out.Write(stubs.Bytes())
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("cannot format handlers: %w", err)
+	}
+	return formatted, nil
+}
+
+// declarations returns whether the `receiver` type is declared in a file and
+// the names of its methods
+func declarations(file *ast.File, receiver string) (bool, map[string]struct{}) {
+	var (
+		declared bool
+		methods  = make(map[string]struct{})
+	)
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				if spec.(*ast.TypeSpec).Name.Name == receiver {
+					declared = true
+				}
+			}
+
+		case *ast.FuncDecl:
+			if decl.Recv == nil || len(decl.Recv.List) != 1 {
+				continue
+			}
+			var expr = decl.Recv.List[0].Type
+			if star, ok := expr.(*ast.StarExpr); ok {
+				expr = star.X
+			}
+			if ident, ok := expr.(*ast.Ident); ok && ident.Name == receiver {
+				methods[decl.Name.Name] = struct{}{}
+			}
+		}
+	}
+	return declared, methods
+}
+
+// importName returns the name used to refer to an imported package in a file,
+// it defaults to the last element of the import path when not imported
+func importName(file *ast.File, importPath string) (string, bool) {
+	for _, spec := range file.Imports {
+		if p, err := strconv.Unquote(spec.Path.Value); err != nil || p != importPath {
+			continue
+		}
+		if spec.Name == nil {
+			return path.Base(importPath), true
+		}
+		if spec.Name.Name != "_" && spec.Name.Name != "." {
+			return spec.Name.Name, true
+		}
+	}
+	return path.Base(importPath), false
+}
+
+// importBlock returns the first parenthesized import declaration of a file
+func importBlock(file *ast.File) *ast.GenDecl {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if ok && gen.Tok == token.IMPORT && gen.Lparen.IsValid() {
+			return gen
+		}
+	}
+	return nil
+}
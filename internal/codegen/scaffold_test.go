@@ -0,0 +1,330 @@
+package codegen
+
+import (
+	"bytes"
+	"errors"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/xdrm-io/aicra/internal/config"
+)
+
+const scaffoldConfig = `[
+	{
+		"method": "GET",
+		"path": "/user/{id}",
+		"info": "info",
+		"in": {
+			"{id}": { "info": "info", "type": "uint", "name": "ID" }
+		},
+		"out": {
+			"name": { "info": "info", "type": "string", "name": "Name" }
+		}
+	},
+	{
+		"method": "DELETE",
+		"path": "/user/{id}",
+		"info": "info",
+		"in": {
+			"{id}": { "info": "info", "type": "uint", "name": "ID" }
+		}
+	}
+]`
+
+func parseScaffoldConfig(t *testing.T, conf string) *config.Server {
+	t.Helper()
+	var types = &Types{}
+	srv := types.Server()
+	if err := srv.Parse(strings.NewReader(conf)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return srv
+}
+
+func TestGenerateBindings(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		codecs bool
+		expect []string
+	}{
+		{
+			name:   "reflection",
+			codecs: false,
+			expect: []string{
+				"type GetUserByIDReq struct {",
+				"type DeleteUserByIDRes struct {",
+				"func Bind(b *aicra.Builder, h *Handlers) error {",
+				`if err := aicra.Bind(b, "GET", "/user/{id}", h.GetUserByID); err != nil {`,
+				`if err := aicra.Bind(b, "DELETE", "/user/{id}", h.DeleteUserByID); err != nil {`,
+			},
+		},
+		{
+			name:   "codecs",
+			codecs: true,
+			expect: []string{
+				"var GetUserByIDCodec = aicra.Codec[GetUserByIDReq, GetUserByIDRes]{",
+				`if err := aicra.BindCodec(b, "GET", "/user/{id}", h.GetUserByID, GetUserByIDCodec); err != nil {`,
+			},
+		},
+	}
+
+	srv := parseScaffoldConfig(t, scaffoldConfig)
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var code bytes.Buffer
+			if err := GenerateBindings(&code, srv, &Types{}, "api", "Handlers", tc.codecs); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if _, err := parser.ParseFile(token.NewFileSet(), "bindings.go", code.Bytes(), 0); err != nil {
+				t.Fatalf("invalid go code: %s\n%s", err, code.String())
+			}
+			for _, snippet := range tc.expect {
+				if !strings.Contains(code.String(), snippet) {
+					t.Fatalf("missing code %q in\n%s", snippet, code.String())
+				}
+			}
+		})
+	}
+}
+
+func TestScaffoldHandlers(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		src    string
+		expect string
+	}{
+		{
+			name: "new file",
+			src:  "",
+			expect: `package api
+
+import (
+	"context"
+
+	"github.com/xdrm-io/aicra/api"
+)
+
+// Handlers implements the services of the configuration
+type Handlers struct{}
+
+// GetUserByID handles GET /user/{id}
+func (h *Handlers) GetUserByID(ctx context.Context, req GetUserByIDReq) (*GetUserByIDRes, error) {
+	return nil, api.ErrNotImplemented
+}
+
+// DeleteUserByID handles DELETE /user/{id}
+func (h *Handlers) DeleteUserByID(ctx context.Context, req DeleteUserByIDReq) (*DeleteUserByIDRes, error) {
+	return nil, api.ErrNotImplemented
+}
+`,
+		},
+		{
+			name: "missing service",
+			src: `package users
+
+import (
+	"context"
+
+	"github.com/xdrm-io/aicra/api"
+)
+
+// Handlers wraps the database
+type Handlers struct {
+	db *DB
+}
+
+// GetUserByID fetches a user
+func (h Handlers) GetUserByID(ctx context.Context, req GetUserByIDReq) (*GetUserByIDRes, error) {
+	name, err := h.db.Fetch(req.ID)
+	if err != nil {
+		return nil, api.ErrNotFound
+	}
+	return &GetUserByIDRes{Name: name}, nil
+}
+`,
+			expect: `package users
+
+import (
+	"context"
+
+	"github.com/xdrm-io/aicra/api"
+)
+
+// Handlers wraps the database
+type Handlers struct {
+	db *DB
+}
+
+// GetUserByID fetches a user
+func (h Handlers) GetUserByID(ctx context.Context, req GetUserByIDReq) (*GetUserByIDRes, error) {
+	name, err := h.db.Fetch(req.ID)
+	if err != nil {
+		return nil, api.ErrNotFound
+	}
+	return &GetUserByIDRes{Name: name}, nil
+}
+
+// DeleteUserByID handles DELETE /user/{id}
+func (h *Handlers) DeleteUserByID(ctx context.Context, req DeleteUserByIDReq) (*DeleteUserByIDRes, error) {
+	return nil, api.ErrNotImplemented
+}
+`,
+		},
+		{
+			name: "missing imports",
+			src: `package api
+
+import (
+	"fmt"
+)
+
+type Handlers struct{}
+
+func (h *Handlers) String() string {
+	return fmt.Sprintf("handlers")
+}
+`,
+			expect: `package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xdrm-io/aicra/api"
+)
+
+type Handlers struct{}
+
+func (h *Handlers) String() string {
+	return fmt.Sprintf("handlers")
+}
+
+// GetUserByID handles GET /user/{id}
+func (h *Handlers) GetUserByID(ctx context.Context, req GetUserByIDReq) (*GetUserByIDRes, error) {
+	return nil, api.ErrNotImplemented
+}
+
+// DeleteUserByID handles DELETE /user/{id}
+func (h *Handlers) DeleteUserByID(ctx context.Context, req DeleteUserByIDReq) (*DeleteUserByIDRes, error) {
+	return nil, api.ErrNotImplemented
+}
+`,
+		},
+		{
+			name: "renamed imports",
+			src: `package api
+
+import (
+	stdctx "context"
+
+	aicra "github.com/xdrm-io/aicra/api"
+)
+
+type Handlers struct{}
+
+func (h *Handlers) DeleteUserByID(stdctx.Context, DeleteUserByIDReq) (*DeleteUserByIDRes, error) {
+	return nil, aicra.ErrNotFound
+}
+`,
+			expect: `package api
+
+import (
+	stdctx "context"
+
+	aicra "github.com/xdrm-io/aicra/api"
+)
+
+type Handlers struct{}
+
+func (h *Handlers) DeleteUserByID(stdctx.Context, DeleteUserByIDReq) (*DeleteUserByIDRes, error) {
+	return nil, aicra.ErrNotFound
+}
+
+// GetUserByID handles GET /user/{id}
+func (h *Handlers) GetUserByID(ctx stdctx.Context, req GetUserByIDReq) (*GetUserByIDRes, error) {
+	return nil, aicra.ErrNotImplemented
+}
+`,
+		},
+	}
+
+	srv := parseScaffoldConfig(t, scaffoldConfig)
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := ScaffoldHandlers([]byte(tc.src), srv, "api", "Handlers")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(out) != tc.expect {
+				t.Fatalf("invalid handlers\nactual:\n%s\nexpect:\n%s", out, tc.expect)
+			}
+
+			// running again must not change anything
+			again, err := ScaffoldHandlers(out, srv, "api", "Handlers")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !bytes.Equal(again, out) {
+				t.Fatalf("scaffolding is not idempotent\nactual:\n%s\nexpect:\n%s", again, out)
+			}
+		})
+	}
+}
+
+func TestScaffoldHandlersErrors(t *testing.T) {
+	t.Parallel()
+
+	srv := parseScaffoldConfig(t, scaffoldConfig)
+
+	_, err := ScaffoldHandlers(nil, srv, "api", "invalid-name")
+	if !errors.Is(err, ErrInvalidReceiver) {
+		t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, ErrInvalidReceiver)
+	}
+
+	_, err = ScaffoldHandlers([]byte("package api\nfunc {"), srv, "api", "Handlers")
+	if err == nil {
+		t.Fatalf("expected a parse error")
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		src    string
+		expect string
+		err    bool
+	}{
+		{name: "package clause", src: "package users\n", expect: "users"},
+		{name: "documented file", src: "// Package users.\npackage users\n\nfunc f() {}\n", expect: "users"},
+		{name: "no package clause", src: "func f() {}\n", err: true},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pkg, err := PackageName([]byte(tc.src))
+			if (err != nil) != tc.err {
+				t.Fatalf("invalid error\nactual: %v\nexpect: %t", err, tc.err)
+			}
+			if pkg != tc.expect {
+				t.Fatalf("invalid package\nactual: %q\nexpect: %q", pkg, tc.expect)
+			}
+		})
+	}
+}
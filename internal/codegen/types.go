@@ -0,0 +1,139 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/xdrm-io/aicra/internal/config"
+	"github.com/xdrm-io/aicra/validator"
+)
+
+// External is a go type defined outside of the generated code, it is used for
+// types provided by custom validators
+type External struct {
+	// Import path of the package defining the type, empty for predeclared types
+	Import string
+	// Name is the qualified type name, e.g. "uuid.UUID" or "int64"
+	Name string
+}
+
+// ParseExternal parses a go type reference: either a predeclared type, e.g.
+// "int64", or a type from an import path, e.g. "time.Time" or
+// "github.com/google/uuid.UUID"
+func ParseExternal(ref string) (External, error) {
+	var dot = strings.LastIndexByte(ref, '.')
+	if dot < 0 {
+		if !token.IsIdentifier(ref) {
+			return External{}, fmt.Errorf("%q: %w", ref, ErrInvalidTypeRef)
+		}
+		return External{Name: ref}, nil
+	}
+
+	var (
+		importPath = ref[:dot]
+		name       = ref[dot+1:]
+		pkg        = path.Base(importPath)
+	)
+	if len(importPath) < 1 || !token.IsIdentifier(name) || !token.IsExported(name) || !token.IsIdentifier(pkg) {
+		return External{}, fmt.Errorf("%q: %w", ref, ErrInvalidTypeRef)
+	}
+	return External{Import: importPath, Name: pkg + "." + name}, nil
+}
+
+// Types lists the types available in configurations: builtin validators and
+// custom types registered with their go type
+type Types struct {
+	// opaque validators of custom types
+	opaque []validator.Type
+	// external types indexed by the go type of their opaque validator
+	external map[reflect.Type]External
+}
+
+// Add makes a custom typename available, `ext` is its go type in the
+// generated code. It handles the typename with or without arguments, e.g.
+// "varchar" also handles "varchar(20)".
+func (t *Types) Add(typename string, ext External) {
+	if t.external == nil {
+		t.external = make(map[reflect.Type]External)
+	}
+	// unique type for each typename
+	goType := reflect.StructOf([]reflect.StructField{{
+		Name: "Opaque",
+		Type: reflect.TypeOf(0),
+		Tag:  reflect.StructTag(fmt.Sprintf(`aicra:%q`, typename)),
+	}})
+	t.external[goType] = ext
+	t.opaque = append(t.opaque, opaqueType{name: typename, goType: goType})
+}
+
+// lookup returns the external type associated with a go type
+func (t *Types) lookup(goType reflect.Type) (External, bool) {
+	if t == nil || t.external == nil {
+		return External{}, false
+	}
+	ext, ok := t.external[goType]
+	return ext, ok
+}
+
+// Server returns a server ready to parse configurations using builtin and
+// custom types. Input types are also available as output types.
+func (t *Types) Server() *config.Server {
+	srv := &config.Server{}
+	if t != nil {
+		for _, opaque := range t.opaque {
+			srv.AddInputValidator(opaque)
+		}
+	}
+	for _, builtin := range []validator.Type{
+		validator.AnyType{},
+		validator.BoolType{},
+		validator.FloatType{},
+		validator.IntType{},
+		validator.StringType{},
+		validator.UintType{},
+		validator.SliceType{},
+		validator.MapType{},
+		validator.EnumType{},
+	} {
+		srv.AddInputValidator(builtin)
+	}
+	srv.Output = srv.Input
+	return srv
+}
+
+// opaqueType handles a custom typename without its actual validator. Any value
+// is considered valid, config.OpaqueType ensures uri captures using it do not
+// collide with static segments.
+type opaqueType struct {
+	name   string
+	goType reflect.Type
+}
+
+// GoType implements validator.Type
+func (o opaqueType) GoType() reflect.Type {
+	return o.goType
+}
+
+// Validator implements validator.Type
+func (o opaqueType) Validator(typename string, avail ...validator.Type) validator.ValidateFunc {
+	var withArgs = strings.HasPrefix(typename, o.name+"(") && strings.HasSuffix(typename, ")")
+	if typename != o.name && !withArgs {
+		return nil
+	}
+	return func(value interface{}) (interface{}, bool) {
+		return reflect.Zero(o.goType).Interface(), true
+	}
+}
+
+// Opaque implements config.OpaqueType
+func (o opaqueType) Opaque(typename string) bool {
+	return o.Validator(typename) != nil
+}
+
+// Schema implements validator.SchemaType, values of custom types are unknown
+func (o opaqueType) Schema(string, ...validator.Type) *validator.Schema {
+	return &validator.Schema{}
+}
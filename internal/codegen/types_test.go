@@ -0,0 +1,151 @@
+package codegen
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xdrm-io/aicra/internal/config"
+)
+
+func TestParseExternal(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		ref    string
+		expect External
+		err    error
+	}{
+		{ref: "int64", expect: External{Name: "int64"}},
+		{ref: "time.Time", expect: External{Import: "time", Name: "time.Time"}},
+		{ref: "github.com/google/uuid.UUID", expect: External{Import: "github.com/google/uuid", Name: "uuid.UUID"}},
+		{ref: "", err: ErrInvalidTypeRef},
+		{ref: "1nt", err: ErrInvalidTypeRef},
+		{ref: "time.", err: ErrInvalidTypeRef},
+		{ref: ".Time", err: ErrInvalidTypeRef},
+		{ref: "time.time", err: ErrInvalidTypeRef},
+		{ref: "github.com/go-uuid.UUID", err: ErrInvalidTypeRef},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.ref, func(t *testing.T) {
+			t.Parallel()
+
+			ext, err := ParseExternal(tc.ref)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, tc.err)
+			}
+			if ext != tc.expect {
+				t.Fatalf("invalid type\nactual: %#v\nexpect: %#v", ext, tc.expect)
+			}
+		})
+	}
+}
+
+func TestTypesOpaque(t *testing.T) {
+	t.Parallel()
+
+	var types = &Types{}
+	types.Add("uuid", External{Import: "github.com/google/uuid", Name: "uuid.UUID"})
+	types.Add("varchar", External{Name: "string"})
+
+	srv := types.Server()
+	if len(srv.Input) != len(srv.Output) {
+		t.Fatalf("output types must match input types")
+	}
+
+	tt := []struct {
+		typename string
+		known    bool
+	}{
+		{"uuid", true},
+		{"varchar", true},
+		{"varchar(3,20)", true},
+		{"varchar(", false},
+		{"uuid2", false},
+	}
+	for _, tc := range tt {
+		var validator = types.opaque[0].Validator(tc.typename)
+		if validator == nil {
+			validator = types.opaque[1].Validator(tc.typename)
+		}
+		if known := validator != nil; known != tc.known {
+			t.Fatalf("%q: invalid validator\nactual: %t\nexpect: %t", tc.typename, known, tc.known)
+		}
+	}
+
+	// values are unknown, e.g. default values
+	validate := types.opaque[0].Validator("uuid")
+	for _, value := range []interface{}{"static", 12, nil} {
+		if _, valid := validate(value); !valid {
+			t.Fatalf("%#v must be valid", value)
+		}
+	}
+
+	ext, ok := types.lookup(types.opaque[0].GoType())
+	if !ok || ext.Name != "uuid.UUID" {
+		t.Fatalf("invalid lookup\nactual: %#v\nexpect: %q", ext, "uuid.UUID")
+	}
+	if types.opaque[0].GoType() == types.opaque[1].GoType() {
+		t.Fatalf("opaque types must be unique")
+	}
+}
+
+func TestTypesOpaqueConfig(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		conf   string
+		expect error
+	}{
+		{
+			name: "string default",
+			conf: `[{
+				"method": "GET", "path": "/user", "info": "info",
+				"in": { "GET@id": { "info": "info", "type": "?uuid", "name": "ID", "default": "00000000-0000-0000-0000-000000000000" } }
+			}]`,
+		},
+		{
+			name: "capture and static segment",
+			conf: `[
+				{ "method": "GET", "path": "/user/{id}", "info": "info", "in": { "{id}": { "info": "info", "type": "uuid", "name": "ID" } } },
+				{ "method": "GET", "path": "/user/me", "info": "info" }
+			]`,
+		},
+		{
+			name: "named type capture and static segment",
+			conf: `{
+				"types": { "UserID": { "info": "info", "type": "uuid" } },
+				"services": [
+					{ "method": "GET", "path": "/user/{id}", "info": "info", "in": { "{id}": { "info": "info", "type": "UserID", "name": "ID" } } },
+					{ "method": "GET", "path": "/user/me", "info": "info" }
+				]
+			}`,
+		},
+		{
+			name: "captures",
+			conf: `[
+				{ "method": "GET", "path": "/user/{id}", "info": "info", "in": { "{id}": { "info": "info", "type": "uuid", "name": "ID" } } },
+				{ "method": "GET", "path": "/user/{name}", "info": "info", "in": { "{name}": { "info": "info", "type": "string", "name": "Name" } } }
+			]`,
+			expect: config.ErrPatternCollision,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var types = &Types{}
+			types.Add("uuid", External{Import: "github.com/google/uuid", Name: "uuid.UUID"})
+
+			err := types.Server().Parse(strings.NewReader(tc.conf))
+			if !errors.Is(err, tc.expect) {
+				t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, tc.expect)
+			}
+		})
+	}
+}
@@ -0,0 +1,421 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xdrm-io/aicra/internal/config"
+	"github.com/xdrm-io/aicra/internal/naming"
+	"github.com/xdrm-io/aicra/validator"
+)
+
+// tsNames are declared by the generated typescript client and cannot be used
+// by named types
+var tsNames = []string{"ApiError", "BaseClient", "Client", "Params"}
+
+// GenerateTypeScript writes typescript definitions of the api along with a
+// fetch-based client: the request and response interfaces of every service,
+// whose properties are the parameters renames and are optional for optional
+// parameters, and a Client class with a method calling each service, e.g. for
+// "GET /user/{id}" the GetUserByIDReq and GetUserByIDRes interfaces and the
+// getUserByID method.
+//
+// Named types are exported type aliases, object schemas use their field keys
+// as they are sent as is. Cookies cannot be set by fetch and are left to the
+// browser. Error responses are thrown as ApiError.
+func GenerateTypeScript(w io.Writer, srv *config.Server) error {
+	var ts = tsFile{srv: srv}
+
+	var defs = make([]string, 0, len(srv.Types))
+	for name := range srv.Types {
+		defs = append(defs, name)
+	}
+	sort.Strings(defs)
+	for _, name := range defs {
+		for _, reserved := range tsNames {
+			if naming.GoName(name) == reserved {
+				return fmt.Errorf("type %q: %w", name, ErrReservedName)
+			}
+		}
+	}
+
+	ts.printf("// Code generated by aicra; DO NOT EDIT.\n\n")
+	for _, name := range defs {
+		var def = srv.Types[name]
+		if len(def.Description) > 0 {
+			ts.printf("/** %s */\n", tsComment(def.Description))
+		}
+		ts.printf("export type %s = %s;\n\n", naming.GoName(name), ts.typeExpr(def.Type, def.Fields, srv.Input, 0))
+	}
+
+	var names = serviceNames(srv.Services)
+	for i, service := range srv.Services {
+		ts.writeInterface(names[i]+"Req", service, tsInputs(service), srv.Input)
+		ts.writeInterface(names[i]+"Res", service, service.Output, srv.Output)
+	}
+
+	ts.printf("%s\n", tsClientSource)
+	ts.printf("export class Client extends BaseClient {\n")
+	for i, service := range srv.Services {
+		if i > 0 {
+			ts.printf("\n")
+		}
+		ts.writeMethod(names[i], service)
+	}
+	ts.printf("}\n")
+
+	_, err := w.Write(ts.body.Bytes())
+	return err
+}
+
+// tsFile builds a typescript source file
+type tsFile struct {
+	srv  *config.Server
+	body bytes.Buffer
+}
+
+// printf writes formatted code into the file's body
+func (ts *tsFile) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&ts.body, format, args...)
+}
+
+// tsInputs returns the input parameters that can be sent by fetch, cookies
+// are excluded
+func tsInputs(service *config.Service) map[string]*config.Parameter {
+	var inputs = make(map[string]*config.Parameter, len(service.Input))
+	for key, param := range service.Input {
+		if strings.HasPrefix(key, "COOKIE@") {
+			continue
+		}
+		inputs[key] = param
+	}
+	return inputs
+}
+
+// writeInterface writes the interface of parameters, properties are the
+// parameters renames
+func (ts *tsFile) writeInterface(name string, service *config.Service, params map[string]*config.Parameter, avail []validator.Type) {
+	ts.printf("/** %s of %s */\n", name, route(service))
+	ts.printf("export interface %s {\n", name)
+	for _, param := range sortedByRename(params) {
+		if len(param.Description) > 0 {
+			ts.printf("  /** %s */\n", tsComment(param.Description))
+		}
+		var optional string
+		if param.Optional {
+			optional = "?"
+		}
+		ts.printf("  %s%s: %s;\n", tsKey(param.Rename), optional, ts.typeExpr(param.Type, param.Fields, avail, 1))
+	}
+	ts.printf("}\n\n")
+}
+
+// writeMethod writes the Client method calling a service
+func (ts *tsFile) writeMethod(name string, service *config.Service) {
+	var method = strings.ToLower(name[:1]) + name[1:]
+	if len(service.Description) > 0 {
+		ts.printf("  /** %s: %s */\n", route(service), tsComment(service.Description))
+	} else {
+		ts.printf("  /** %s */\n", route(service))
+	}
+	var params bytes.Buffer
+	for _, group := range []struct {
+		name   string
+		params map[string]*config.Parameter
+	}{
+		{"query", service.Query},
+		{"headers", service.Header},
+		{"form", service.Form},
+	} {
+		if len(group.params) < 1 {
+			continue
+		}
+		fmt.Fprintf(&params, "      %s: {", group.name)
+		for i, key := range sortedParams(group.params) {
+			if i > 0 {
+				params.WriteString(",")
+			}
+			fmt.Fprintf(&params, " %s: %s", strconv.Quote(key), tsAccess("req", group.params[key].Rename))
+		}
+		params.WriteString(" },\n")
+	}
+
+	// unused requests are prefixed for linters
+	var req = "req"
+	if params.Len() < 1 && len(service.Captures) < 1 {
+		req = "_req"
+	}
+
+	if len(service.Output) > 0 {
+		params.WriteString("      out: {")
+		for i, param := range sortedByRename(service.Output) {
+			if i > 0 {
+				params.WriteString(",")
+			}
+			fmt.Fprintf(&params, " %s: %q", tsKey(param.Rename), outputKey(service, param))
+		}
+		params.WriteString(" },\n")
+	}
+
+	ts.printf("  %s(%s: %sReq): Promise<%sRes> {\n", method, req, name, name)
+	if params.Len() < 1 {
+		ts.printf("    return this.call(%q, %s, {}) as Promise<%sRes>;\n", service.Method, tsPath(service), name)
+	} else {
+		ts.printf("    return this.call(%q, %s, {\n%s    }) as Promise<%sRes>;\n", service.Method, tsPath(service), params.String(), name)
+	}
+	ts.printf("  }\n")
+}
+
+// typeExpr returns the typescript type of a typename, `fields` are those of
+// inline object schemas and `depth` the indentation level
+func (ts *tsFile) typeExpr(typename string, fields map[string]*config.Parameter, avail []validator.Type, depth int) string {
+	switch {
+	case strings.HasPrefix(typename, "[]"):
+		elem := ts.typeExpr(typename[2:], fields, avail, depth)
+		if strings.ContainsAny(elem, " |") {
+			return "Array<" + elem + ">"
+		}
+		return elem + "[]"
+
+	case strings.HasPrefix(typename, "map[string]"):
+		return "Record<string, " + ts.typeExpr(typename[len("map[string]"):], fields, avail, depth) + ">"
+
+	case typename == "object" && fields != nil:
+		var (
+			b      strings.Builder
+			indent = strings.Repeat("  ", depth+1)
+			keys   = sortedParams(fields)
+		)
+		b.WriteString("{\n")
+		for _, key := range keys {
+			var field = fields[key]
+			var optional string
+			if field.Optional {
+				optional = "?"
+			}
+			fmt.Fprintf(&b, "%s%s%s: %s;\n", indent, tsKey(key), optional, ts.typeExpr(field.Type, field.Fields, avail, depth+1))
+		}
+		b.WriteString(strings.Repeat("  ", depth) + "}")
+		return b.String()
+	}
+
+	if _, named := ts.srv.Types[typename]; named {
+		return naming.GoName(typename)
+	}
+
+	switch typename {
+	case "any":
+		return "unknown"
+	case "bool":
+		return "boolean"
+	case "int", "uint", "float", "float64":
+		return "number"
+	}
+
+	// described or resolved custom types
+	if schema := validator.Describe(typename, avail...); schema != nil {
+		switch schema.Type {
+		case "string":
+			if len(schema.Enum) > 0 {
+				return tsUnion(schema.Enum)
+			}
+			return "string"
+		case "integer", "number":
+			return "number"
+		case "boolean":
+			return "boolean"
+		}
+	}
+	_, goType := validator.Resolve(typename, avail...)
+	return tsGoType(goType)
+}
+
+// tsUnion returns the union of literal values, e.g. `"asc" | "desc"`
+func tsUnion(values []interface{}) string {
+	var literals = make([]string, 0, len(values))
+	for _, value := range values {
+		literal, err := json.Marshal(value)
+		if err != nil {
+			return "unknown"
+		}
+		literals = append(literals, string(literal))
+	}
+	return strings.Join(literals, " | ")
+}
+
+// tsGoType returns the typescript type of the json values of a go type
+func tsGoType(t reflect.Type) string {
+	if t == nil {
+		return "unknown"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Ptr:
+		return tsGoType(t.Elem())
+	case reflect.Slice:
+		// raw bytes are base64 strings
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "string"
+		}
+		return "Array<" + tsGoType(t.Elem()) + ">"
+	case reflect.Map:
+		return "Record<string, " + tsGoType(t.Elem()) + ">"
+	}
+	return "unknown"
+}
+
+// tsKey returns a property name, quoted when it is not an identifier
+func tsKey(key string) string {
+	for i, r := range key {
+		if r == '_' || r == '$' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9') {
+			continue
+		}
+		return strconv.Quote(key)
+	}
+	return key
+}
+
+// tsAccess returns the expression accessing a property of an object
+func tsAccess(object, key string) string {
+	if quoted := tsKey(key); quoted != key {
+		return object + "[" + quoted + "]"
+	}
+	return object + "." + key
+}
+
+// tsComment escapes the end of comments in a description
+func tsComment(description string) string {
+	return strings.ReplaceAll(description, "*/", "*\\/")
+}
+
+// tsPath returns the template literal of the path of a service request, e.g.
+// `/user/${encodeURIComponent(String(req.ID))}` for "/user/{id}"
+func tsPath(service *config.Service) string {
+	var captures = make(map[int]string, len(service.Captures))
+	for _, capture := range service.Captures {
+		captures[capture.Index] = capture.Ref.Rename
+	}
+
+	var b strings.Builder
+	b.WriteString("`")
+	for i, segment := range config.SplitURI(service.Pattern) {
+		b.WriteString("/")
+		if rename, isCapture := captures[i]; isCapture {
+			fmt.Fprintf(&b, "${encodeURIComponent(String(%s))}", tsAccess("req", rename))
+			continue
+		}
+		b.WriteString(segment)
+	}
+	if b.Len() < 2 {
+		b.WriteString("/")
+	}
+	b.WriteString("`")
+	return b.String()
+}
+
+// outputKey returns the key of an output parameter in the response
+func outputKey(service *config.Service, param *config.Parameter) string {
+	for key, p := range service.Output {
+		if p == param {
+			return key
+		}
+	}
+	return param.Rename
+}
+
+// sortedByRename returns parameters sorted by their rename
+func sortedByRename(params map[string]*config.Parameter) []*config.Parameter {
+	var sorted = make([]*config.Parameter, 0, len(params))
+	for _, param := range params {
+		sorted = append(sorted, param)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Rename < sorted[j].Rename
+	})
+	return sorted
+}
+
+// tsClientSource is the code shared by the methods of the generated client
+const tsClientSource = `/** ApiError is thrown for error responses, message is the response status */
+export class ApiError extends Error {
+  constructor(readonly status: number, message: string) {
+    super(message);
+    this.name = "ApiError";
+  }
+}
+
+/** Params of a request, keys are the parameter names of the configuration */
+interface Params {
+  query?: Record<string, unknown>;
+  headers?: Record<string, unknown>;
+  form?: Record<string, unknown>;
+  /** response keys indexed by property */
+  out?: Record<string, string>;
+}
+
+class BaseClient {
+  /**
+   * @param baseURL url of the api, e.g. "https://example.com/api"
+   * @param init options of every request, e.g. credentials or headers
+   */
+  constructor(readonly baseURL: string, readonly init: RequestInit = {}) {}
+
+  protected async call(method: string, path: string, params: Params): Promise<unknown> {
+    let url = this.baseURL.replace(/\/+$/, "") + path;
+
+    const query = new URLSearchParams();
+    for (const [key, value] of Object.entries(params.query ?? {})) {
+      if (value === undefined) continue;
+      for (const item of Array.isArray(value) ? value : [value]) {
+        query.append(key, String(item));
+      }
+    }
+    if (query.toString().length > 0) {
+      url += "?" + query.toString();
+    }
+
+    const headers = new Headers(this.init.headers);
+    for (const [key, value] of Object.entries(params.headers ?? {})) {
+      if (value === undefined) continue;
+      for (const item of Array.isArray(value) ? value : [value]) {
+        headers.append(key, String(item));
+      }
+    }
+
+    const form: Record<string, unknown> = {};
+    for (const [key, value] of Object.entries(params.form ?? {})) {
+      if (value !== undefined) form[key] = value;
+    }
+    let body: string | undefined;
+    if (Object.keys(form).length > 0) {
+      body = JSON.stringify(form);
+      headers.set("Content-Type", "application/json");
+    }
+
+    const res = await fetch(url, { ...this.init, method, headers, body });
+    const data: Record<string, unknown> = await res.json().catch(() => ({}));
+    if (!res.ok) {
+      throw new ApiError(res.status, typeof data["status"] === "string" ? data["status"] : res.statusText);
+    }
+
+    const out: Record<string, unknown> = {};
+    for (const [property, key] of Object.entries(params.out ?? {})) {
+      if (key in data) out[property] = data[key];
+    }
+    return out;
+  }
+}
+`
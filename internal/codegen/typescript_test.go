@@ -0,0 +1,145 @@
+package codegen
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerateTypeScript(t *testing.T) {
+	t.Parallel()
+
+	var types = &Types{}
+	srv := types.Server()
+	if err := srv.Parse(strings.NewReader(clientConfig)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var code bytes.Buffer
+	if err := GenerateTypeScript(&code, srv); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expect := []string{
+		"// Code generated by aicra; DO NOT EDIT.",
+		"export type Address = {\n  city: string;\n};",
+		"export interface PutUserByIDTagsReq {",
+		"  Address?: Address;",
+		"  ID: number;",
+		"  IDs?: number[];",
+		"  Limit?: number;",
+		"  Name: string;",
+		"  Tenant: string;",
+		"export interface PutUserByIDTagsRes {",
+		"  FullName: string;",
+		"export class ApiError extends Error {",
+		"export class Client extends BaseClient {",
+		"  putUserByIDTags(req: PutUserByIDTagsReq): Promise<PutUserByIDTagsRes> {",
+		"    return this.call(\"PUT\", `/user/${encodeURIComponent(String(req.ID))}/tags`, {",
+		`      query: { "ids": req.IDs },`,
+		`      headers: { "X-Tenant": req.Tenant },`,
+		`      form: { "address": req.Address, "limit": req.Limit, "name": req.Name },`,
+		`      out: { FullName: "full-name" },`,
+		"  getRoot(_req: GetRootReq): Promise<GetRootRes> {",
+		"    return this.call(\"GET\", `/`, {}) as Promise<GetRootRes>;",
+	}
+	for _, snippet := range expect {
+		if !strings.Contains(code.String(), snippet) {
+			t.Fatalf("missing code %q in\n%s", snippet, code.String())
+		}
+	}
+
+	// cookies are left to the browser
+	if strings.Contains(code.String(), "Session") {
+		t.Fatalf("unexpected cookie parameter in\n%s", code.String())
+	}
+}
+
+func TestGenerateTypeScriptReservedName(t *testing.T) {
+	t.Parallel()
+
+	var types = &Types{}
+	srv := types.Server()
+	err := srv.Parse(strings.NewReader(`{
+		"types": { "ApiError": "string" },
+		"services": []
+	}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	err = GenerateTypeScript(&bytes.Buffer{}, srv)
+	if !errors.Is(err, ErrReservedName) {
+		t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, ErrReservedName)
+	}
+}
+
+func TestTypeScriptTypes(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		typename string
+		expect   string
+	}{
+		{"any", "unknown"},
+		{"bool", "boolean"},
+		{"int", "number"},
+		{"uint", "number"},
+		{"float", "number"},
+		{"string", "string"},
+		{"string(3,20)", "string"},
+		{"[]int", "number[]"},
+		{"[][]string", "string[][]"},
+		{"map[string]bool", "Record<string, boolean>"},
+		{"[]map[string]int", "Array<Record<string, number>>"},
+		{"enum(asc,desc)", `"asc" | "desc"`},
+		{"[]enum(a,b)", `Array<"a" | "b">`},
+		{"int(0,10)", "number"},
+		{"uuid", "unknown"},
+	}
+
+	var types = &Types{}
+	types.Add("uuid", External{Import: "github.com/google/uuid", Name: "uuid.UUID"})
+	srv := types.Server()
+	ts := tsFile{srv: srv}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.typename, func(t *testing.T) {
+			t.Parallel()
+			if actual := ts.typeExpr(tc.typename, nil, srv.Input, 0); actual != tc.expect {
+				t.Fatalf("invalid type\nactual: %s\nexpect: %s", actual, tc.expect)
+			}
+		})
+	}
+}
+
+func TestTypeScriptKey(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		key    string
+		expect string
+		access string
+	}{
+		{"name", "name", "req.name"},
+		{"Name2", "Name2", "req.Name2"},
+		{"_id", "_id", "req._id"},
+		{"full-name", `"full-name"`, `req["full-name"]`},
+		{"2fa", `"2fa"`, `req["2fa"]`},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.key, func(t *testing.T) {
+			t.Parallel()
+			if actual := tsKey(tc.key); actual != tc.expect {
+				t.Fatalf("invalid key\nactual: %s\nexpect: %s", actual, tc.expect)
+			}
+			if actual := tsAccess("req", tc.key); actual != tc.access {
+				t.Fatalf("invalid access\nactual: %s\nexpect: %s", actual, tc.access)
+			}
+		})
+	}
+}
@@ -1,11 +1,14 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/xdrm-io/aicra/validator"
@@ -16,10 +19,35 @@ type Server struct {
 	// Input type validators available
 	Input []validator.Type
 	// Output types (no-op) validators available
-	Output   []validator.Type
+	Output []validator.Type
+	// Methods lists custom http methods available in addition to the standard
+	// ones
+	Methods []string
+	// Types lists named type definitions from the configuration
+	Types    map[string]*TypeDef
 	Services []*Service
 }
 
+// AddMethod makes a custom http method available for services, e.g. "PURGE".
+// It must be called before Parse() or will be ignored
+func (s *Server) AddMethod(method string) error {
+	if !methodRegex.MatchString(method) {
+		return fmt.Errorf("%q: %w", method, ErrInvalidMethod)
+	}
+	for _, available := range availableHTTPMethods {
+		if method == available {
+			return nil
+		}
+	}
+	for _, available := range s.Methods {
+		if method == available {
+			return nil
+		}
+	}
+	s.Methods = append(s.Methods, method)
+	return nil
+}
+
 // AddInputValidator makes a new type available for services "in". It must be
 // called before Parse() or will be ignored
 func (s *Server) AddInputValidator(v validator.Type) {
@@ -41,42 +69,124 @@ func (s *Server) AddOutputValidator(typename string, goType reflect.Type) {
 
 // Parse a configuration into a server. Server.Validators must be set beforehand
 // to make datatypes available when checking and formatting the configuration.
+//
+// The configuration is either a list of services or an object featuring named
+// type definitions ("types") and the list of services ("services").
+//
+// Errors feature every issue found in the configuration as Errors, located in
+// the configuration file.
 func (s *Server) Parse(r io.Reader) error {
-	err := json.NewDecoder(r).Decode(&s.Services)
+	data, err := io.ReadAll(r)
 	if err != nil {
 		return fmt.Errorf("%s: %w", ErrRead, err)
 	}
+	var raw json.RawMessage
+	err = json.NewDecoder(bytes.NewReader(data)).Decode(&raw)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrRead, locateReadError(data, err))
+	}
 
-	err = s.validate()
+	var isObject = false
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '{' {
+		isObject = true
+		var conf struct {
+			Types    map[string]*TypeDef `json:"types"`
+			Services []*Service          `json:"services"`
+		}
+		err = json.Unmarshal(raw, &conf)
+		s.Types, s.Services = conf.Types, conf.Services
+	} else {
+		err = json.Unmarshal(raw, &s.Services)
+	}
 	if err != nil {
-		return fmt.Errorf("%s: %w", ErrFormat, err)
+		return fmt.Errorf("%s: %w", ErrRead, locateReadError(raw, err))
 	}
-	return nil
-}
 
-// validate all services
-func (s Server) validate() error {
-	for _, service := range s.Services {
-		err := service.validate(s.Input, s.Output)
-		if err != nil {
-			return fmt.Errorf("%s %q: %w", service.Method, service.Pattern, err)
+	errs := s.validate()
+	if len(errs) < 1 {
+		return nil
+	}
+
+	// locate errors in the configuration
+	var positions = newPositions(raw)
+	for _, err := range errs {
+		var path = err.path
+		// services are at the root of the array format
+		if !isObject && len(path) > 0 && path[0] == "services" {
+			path = path[1:]
 		}
+		err.Line, err.Column = positions.locate(path)
 	}
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].Line != errs[j].Line {
+			return errs[i].Line < errs[j].Line
+		}
+		return errs[i].Column < errs[j].Column
+	})
+	return fmt.Errorf("%s: %w", ErrFormat, errs)
+}
 
-	if err := s.collide(); err != nil {
-		return fmt.Errorf("%s: %w", ErrFormat, err)
+// locateReadError returns json syntax and type errors as located Errors
+func locateReadError(raw []byte, err error) error {
+	var offset int64 = -1
+
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+	if errors.As(err, &syntaxErr) {
+		// the offset follows the invalid character
+		offset = syntaxErr.Offset - 1
+	} else if errors.As(err, &typeErr) {
+		offset = typeErr.Offset
 	}
-	return nil
+	if offset < 0 || raw == nil {
+		return err
+	}
+
+	located := &Error{Err: err}
+	located.Line, located.Column = (&positions{raw: raw}).lineColumn(offset)
+	return Errors{located}
 }
 
-// Find a service matching an incoming HTTP request
-func (s Server) Find(r *http.Request) *Service {
-	for _, service := range s.Services {
-		if matches := service.Match(r); matches {
-			return service
+// validate named types and all services, it returns every error found
+func (s Server) validate() Errors {
+	types, errs := newNamedTypes(s.Types, s.Input)
+	var input = append(types, s.Input...)
+
+	var valid = make([]int, 0, len(s.Services))
+	for i, service := range s.Services {
+		serviceErrs := service.validate(s.Methods, input, s.Output)
+		if len(serviceErrs) < 1 {
+			valid = append(valid, i)
+			continue
+		}
+		for _, err := range serviceErrs {
+			err.Service = fmt.Sprintf("%s %q", service.Method, service.Pattern)
+			err.path = servicePath(i, err.Field, err.Param)
+			errs = append(errs, err)
 		}
 	}
-	return nil
+
+	// collisions can only be checked among valid services
+	return append(errs, s.collide(valid)...)
+}
+
+// servicePath returns the path of a service field in the configuration
+func servicePath(index int, field, param string) []string {
+	var path = []string{"services", strconv.Itoa(index)}
+	if len(field) < 1 {
+		return path
+	}
+	// fields are named after their json key but the description
+	if field == "description" {
+		field = "info"
+	}
+	path = append(path, field)
+	if len(param) > 0 {
+		path = append(path, param)
+	}
+	return path
 }
 
 // collide returns if there is collision between any service for the same method
@@ -88,14 +198,17 @@ func (s Server) Find(r *http.Request) *Service {
 //    a string so as "articles"
 //  - example 3: `/user/{name}` and `/user/{id}` will collide as {name} and {id}
 //    cannot be checked against their potential values
-func (s *Server) collide() error {
-	length := len(s.Services)
+//
+// `indexes` are the indexes of the services to check, the error is located at
+// the second service of each colliding pair
+func (s *Server) collide(indexes []int) Errors {
+	var errs Errors
 
 	// for each service combination
-	for a := 0; a < length; a++ {
-		for b := a + 1; b < length; b++ {
-			aService := s.Services[a]
-			bService := s.Services[b]
+	for a := 0; a < len(indexes); a++ {
+		for b := a + 1; b < len(indexes); b++ {
+			aService := s.Services[indexes[a]]
+			bService := s.Services[indexes[b]]
 
 			if aService.Method != bService.Method {
 				continue
@@ -109,12 +222,16 @@ func (s *Server) collide() error {
 
 			err := checkURICollision(aURIParts, bURIParts, aService.Input, bService.Input)
 			if err != nil {
-				return fmt.Errorf("(%s %q) vs (%s %q): %w", aService.Method, aService.Pattern, bService.Method, bService.Pattern, err)
+				errs = append(errs, &Error{
+					Service: fmt.Sprintf("%s %q", bService.Method, bService.Pattern),
+					Field:   "path",
+					Err:     fmt.Errorf("collides with %s %q: %w", aService.Method, aService.Pattern, err),
+					path:    servicePath(indexes[b], "path", ""),
+				})
 			}
 		}
 	}
-
-	return nil
+	return errs
 }
 
 // check if uri of services A and B collide
@@ -132,8 +249,12 @@ func checkURICollision(aURI, bURI []string, aInput, bInput map[string]*Parameter
 			bIsCapture = len(bSeg) > 1 && bSeg[0] == '{'
 		)
 
-		// both captures -> as we cannot check, consider a collision
+		// both captures -> collision unless they accept disjoint values, e.g.
+		// numeric ranges
 		if aIsCapture && bIsCapture {
+			if disjoint(aInput[aSeg], bInput[bSeg]) {
+				return nil
+			}
 			err = fmt.Errorf("%w (path %s and %s)", ErrPatternCollision, aSeg, bSeg)
 			continue
 		}
@@ -160,16 +281,84 @@ func checkURICollision(aURI, bURI []string, aInput, bInput map[string]*Parameter
 	return err
 }
 
+// OpaqueType is implemented by types whose values are unknown, e.g. custom
+// types known by their name only. Their validator accepts any value, uri
+// captures using them are assumed not to match static segments.
+type OpaqueType interface {
+	validator.Type
+	Opaque(typename string) bool
+}
+
+// isOpaque returns whether the type resolving a typename is opaque
+func isOpaque(typename string, validators ...validator.Type) bool {
+	for _, v := range validators {
+		if v.Validator(typename, validators...) == nil {
+			continue
+		}
+		opaque, ok := v.(OpaqueType)
+		return ok && opaque.Opaque(typename)
+	}
+	return false
+}
+
 // validates returns whether a parameter validates a given value
 func validates(params map[string]*Parameter, checkerName, value string) bool {
 	checker, exists := params[checkerName]
 	if !exists || checker.Validator == nil {
 		panic(fmt.Errorf("invalid validator %q", checkerName))
 	}
+	if checker.opaque {
+		return false
+	}
 	_, valid := checker.Validator(value)
 	return valid
 }
 
+// disjoint returns whether two parameters accept no common value, it is the
+// case of:
+//   - enums whose values are all rejected by the other parameter
+//   - patterns anchored at the start with distinct literal prefixes
+//   - numeric parameters with disjoint bounds, a numeric value being the same
+//     whatever its type
+func disjoint(a, b *Parameter) bool {
+	if a == nil || b == nil || a.schema == nil || b.schema == nil {
+		return false
+	}
+	if len(a.schema.Enum) > 0 {
+		return !validatesAny(b, a.schema.Enum)
+	}
+	if len(b.schema.Enum) > 0 {
+		return !validatesAny(a, b.schema.Enum)
+	}
+	if len(a.schema.Pattern) > 0 && len(b.schema.Pattern) > 0 {
+		return disjointPatterns(a.schema.Pattern, b.schema.Pattern)
+	}
+
+	aInterval, ok := numericInterval(a.schema)
+	if !ok {
+		return false
+	}
+	bInterval, ok := numericInterval(b.schema)
+	if !ok {
+		return false
+	}
+	return aInterval.intersect(bInterval).empty()
+}
+
+// validatesAny returns whether a parameter validates one of the string values
+func validatesAny(param *Parameter, values []interface{}) bool {
+	for _, value := range values {
+		str, ok := value.(string)
+		if !ok || param.Validator == nil {
+			return true
+		}
+		if _, valid := param.Validator(str); valid {
+			return true
+		}
+	}
+	return false
+}
+
 // SplitURI without empty sets
 func SplitURI(uri string) []string {
 	if len(uri) == 0 || uri == "/" {
@@ -245,6 +245,26 @@ func TestAvailableMethods(t *testing.T) {
 			conf:        `[ { "method": "DELETE", "path": "/", "info": "valid-description" }]`,
 			validMethod: true,
 		},
+		{
+			name:        "patch",
+			conf:        `[ { "method": "PATCH", "path": "/", "info": "valid-description" }]`,
+			validMethod: true,
+		},
+		{
+			name:        "head",
+			conf:        `[ { "method": "HEAD", "path": "/", "info": "valid-description" }]`,
+			validMethod: true,
+		},
+		{
+			name:        "options",
+			conf:        `[ { "method": "OPTIONS", "path": "/", "info": "valid-description" }]`,
+			validMethod: true,
+		},
+		{
+			name:        "custom method not added",
+			conf:        `[ { "method": "PURGE", "path": "/", "info": "valid-description" }]`,
+			validMethod: false,
+		},
 		{
 			name:        "valid description",
 			conf:        `[ { "method": "get", "path": "/", "info": "valid-description" }]`,
@@ -270,6 +290,102 @@ func TestAvailableMethods(t *testing.T) {
 		})
 	}
 }
+func TestCustomMethods(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		method string
+		err    error
+	}{
+		{name: "custom", method: "PURGE"},
+		{name: "custom with dash", method: "VERSION-CONTROL"},
+		{name: "standard", method: http.MethodGet},
+		{name: "lowercase", method: "purge", err: ErrInvalidMethod},
+		{name: "empty", method: "", err: ErrInvalidMethod},
+		{name: "invalid chars", method: "PUR GE", err: ErrInvalidMethod},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := &Server{}
+			err := srv.AddMethod(tc.method)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, tc.err)
+			}
+			if tc.err != nil {
+				return
+			}
+			conf := fmt.Sprintf(`[ { "method": %q, "path": "/", "info": "info" } ]`, tc.method)
+			err = srv.Parse(strings.NewReader(conf))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestRouterHeadFallback(t *testing.T) {
+	t.Parallel()
+
+	srv := &Server{}
+	err := srv.Parse(strings.NewReader(`[
+		{ "method": "GET",  "path": "/a", "info": "get-a" },
+		{ "method": "GET",  "path": "/b", "info": "get-b" },
+		{ "method": "HEAD", "path": "/b", "info": "head-b" },
+		{ "method": "POST", "path": "/c", "info": "post-c" }
+	]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	router := NewRouter(srv.Services)
+
+	tt := map[string]string{"/a": "get-a", "/b": "head-b", "/c": ""}
+	for uri, info := range tt {
+		svc := router.Find(httptest.NewRequest(http.MethodHead, uri, nil))
+		if svc == nil && len(info) > 0 {
+			t.Fatalf("%s: expected to find a service", uri)
+		}
+		if svc != nil && svc.Description != info {
+			t.Fatalf("%s: invalid description\nactual: %q\nexpect: %q", uri, svc.Description, info)
+		}
+	}
+}
+
+func TestServiceCORS(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		cors string
+		err  error
+	}{
+		{name: "empty", cors: `{}`},
+		{name: "valid", cors: `{ "origins": ["*"], "headers": ["X-A"], "exposed": ["X-B"], "credentials": false, "max_age": 0 }`},
+		{name: "empty origin", cors: `{ "origins": [""] }`, err: ErrInvalidCORSOrigin},
+		{name: "empty header", cors: `{ "headers": [""] }`, err: ErrInvalidCORSHeader},
+		{name: "empty exposed header", cors: `{ "exposed": [""] }`, err: ErrInvalidCORSHeader},
+		{name: "negative max age", cors: `{ "max_age": -1 }`, err: ErrInvalidCORSMaxAge},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := &Server{}
+			conf := fmt.Sprintf(`[ { "method": "GET", "path": "/", "info": "info", "cors": %s } ]`, tc.cors)
+			err := srv.Parse(strings.NewReader(conf))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, tc.err)
+			}
+		})
+	}
+}
+
 func TestParseEmpty(t *testing.T) {
 	t.Parallel()
 	r := strings.NewReader(`[]`)
@@ -481,6 +597,21 @@ func TestMissingParam(t *testing.T) {
 			}]`,
 			match: "field 'out': out1:",
 		},
+		{
+			name: "slice of any",
+			in:   []validator.Type{validator.SliceType{}, validator.AnyType{}},
+			out:  map[string]interface{}{},
+			config: `[{
+				"method": "GET",
+				"path": "/",
+				"info": "info",
+				"in": {
+					"in1": { "info": "info", "type": "[]any" }
+				},
+				"out": {}
+			}]`,
+			match: "field 'in': in1:",
+		},
 	}
 
 	for _, tc := range tt {
@@ -548,6 +679,90 @@ func TestOptionalParam(t *testing.T) {
 	}
 
 }
+func TestParamDefault(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		in     string
+		out    string
+		err    error
+		expect interface{}
+	}{
+		{
+			name:   "optional int default",
+			in:     `{ "info": "info", "type": "?int", "default": 20 }`,
+			expect: int(20),
+		},
+		{
+			name:   "optional string default",
+			in:     `{ "info": "info", "type": "?string", "default": "asc" }`,
+			expect: "asc",
+		},
+		{
+			name:   "no default",
+			in:     `{ "info": "info", "type": "?int" }`,
+			expect: nil,
+		},
+		{
+			name: "invalid default",
+			in:   `{ "info": "info", "type": "?int", "default": "abc" }`,
+			err:  ErrInvalidDefault,
+		},
+		{
+			name: "invalid float default",
+			in:   `{ "info": "info", "type": "?int", "default": 1.5 }`,
+			err:  ErrInvalidDefault,
+		},
+		{
+			name: "mandatory default",
+			in:   `{ "info": "info", "type": "int", "default": 20 }`,
+			err:  ErrMandatoryDefault,
+		},
+		{
+			name: "output default",
+			in:   `{ "info": "info", "type": "int" }`,
+			out:  `{ "info": "info", "type": "int", "default": 20 }`,
+			err:  ErrOutputDefault,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			out := "{}"
+			if len(tc.out) > 0 {
+				out = fmt.Sprintf(`{ "Out": %s }`, tc.out)
+			}
+			conf := fmt.Sprintf(`[ {
+				"method": "GET",
+				"path": "/",
+				"info": "info",
+				"in": { "Param": %s },
+				"out": %s
+			} ]`, tc.in, out)
+
+			srv := &Server{}
+			srv.AddInputValidator(validator.IntType{})
+			srv.AddInputValidator(validator.StringType{})
+			srv.AddOutputValidator("int", reflect.TypeOf(int(0)))
+			err := srv.Parse(strings.NewReader(conf))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, tc.err)
+			}
+			if err != nil {
+				return
+			}
+			param := srv.Services[0].Input["Param"]
+			if param.Default != tc.expect {
+				t.Fatalf("invalid default\nactual: (%T) %v\nexpect: (%T) %v", param.Default, param.Default, tc.expect, tc.expect)
+			}
+		})
+	}
+}
+
 func TestParseParameters(t *testing.T) {
 	t.Parallel()
 
@@ -820,6 +1035,117 @@ func TestParseParameters(t *testing.T) {
 			} ]`,
 			err: ErrUndefinedBraceCapture,
 		},
+		{
+			name: "header param without rename",
+			conf: `[ {
+				"method": "GET",
+				"path": "/",
+				"info": "info",
+				"in": {
+					"HEADER@X-Tenant-ID": { "info": "info", "type": "any" }
+				}
+			} ]`,
+			err: ErrMandatoryRename,
+		},
+		{
+			name: "header param",
+			conf: `[ {
+				"method": "GET",
+				"path": "/",
+				"info": "info",
+				"in": {
+					"HEADER@X-Tenant-ID": { "info": "info", "type": "?any", "name": "TenantID" }
+				}
+			} ]`,
+			err: nil,
+		},
+		{
+			name: "invalid header param name",
+			conf: `[ {
+				"method": "GET",
+				"path": "/",
+				"info": "info",
+				"in": {
+					"HEADER@X Tenant": { "info": "info", "type": "any", "name": "TenantID" }
+				}
+			} ]`,
+			err: ErrIllegalParamName,
+		},
+		{
+			name: "cookie param without rename",
+			conf: `[ {
+				"method": "GET",
+				"path": "/",
+				"info": "info",
+				"in": {
+					"COOKIE@session": { "info": "info", "type": "any" }
+				}
+			} ]`,
+			err: ErrMandatoryRename,
+		},
+		{
+			name: "cookie param",
+			conf: `[ {
+				"method": "GET",
+				"path": "/",
+				"info": "info",
+				"in": {
+					"COOKIE@session": { "info": "info", "type": "any", "name": "Session" }
+				}
+			} ]`,
+			err: nil,
+		},
+		{
+			name: "invalid cookie param name",
+			conf: `[ {
+				"method": "GET",
+				"path": "/",
+				"info": "info",
+				"in": {
+					"COOKIE@": { "info": "info", "type": "any", "name": "Session" }
+				}
+			} ]`,
+			err: ErrIllegalParamName,
+		},
+		{
+			name: "header rename conflict",
+			conf: `[ {
+				"method": "GET",
+				"path": "/",
+				"info": "info",
+				"in": {
+					"HEADER@X-A":  { "info": "info", "type": "any", "name": "A" },
+					"COOKIE@a":    { "info": "info", "type": "any", "name": "A" }
+				}
+			} ]`,
+			err: ErrParamNameConflict,
+		},
+		{
+			name: "header case conflict",
+			conf: `[ {
+				"method": "GET",
+				"path": "/",
+				"info": "info",
+				"in": {
+					"HEADER@x-tenant": { "info": "info", "type": "any", "name": "A" },
+					"HEADER@X-Tenant": { "info": "info", "type": "any", "name": "B" }
+				}
+			} ]`,
+			err: ErrParamNameConflict,
+		},
+		{
+			name: "cookie case",
+			conf: `[ {
+				"method": "GET",
+				"path": "/",
+				"info": "info",
+				"in": {
+					"COOKIE@session": { "info": "info", "type": "any", "name": "A" },
+					"COOKIE@Session": { "info": "info", "type": "any", "name": "B" }
+				}
+			} ]`,
+			err: nil,
+		},
 	}
 
 	for _, tc := range tt {
@@ -980,56 +1306,215 @@ func TestServiceCollision(t *testing.T) {
 			srv2: service{method: "GET", path: "/a/{var}/c", params: map[string]string{"{var}": "uint"}},
 			err:  ErrPatternCollision,
 		},
-	}
-
-	for _, tc := range tt {
-		t.Run(tc.name, func(t *testing.T) {
-			srv := &Server{}
-			srv.AddInputValidator(validator.StringType{})
-			srv.AddInputValidator(validator.UintType{})
-
-			var (
-				params1 = serializeParams(t, tc.srv1.params)
-				params2 = serializeParams(t, tc.srv2.params)
-			)
-
-			raw := fmt.Sprintf(`[
-					{ "method": "%s", "path": "%s", "info": "info", "in": %s },
-					{ "method": "%s", "path": "%s", "info": "info", "in": %s }
-				]`,
-				tc.srv1.method, tc.srv1.path, params1,
-				tc.srv2.method, tc.srv2.path, params2,
-			)
-
-			err := srv.Parse(strings.NewReader(raw))
-			if !errors.Is(err, tc.err) {
-				t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, tc.err)
-			}
-		})
-	}
-}
-
-func TestServiceCollisionPanic(t *testing.T) {
-	t.Parallel()
-
-	type service struct {
-		method string
-		path   string
-		params map[string]string // name: type
-	}
-
-	tt := []struct {
-		name       string
-		srv1, srv2 service
-		panics     bool
-	}{
 		{
-			name: "same 1-part",
-			srv1: service{method: "GET", path: "/a"},
-			srv2: service{method: "GET", path: "/a"},
+			name: "disjoint int ranges",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "int(0,9)"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "int(10,)"}},
+			err:  nil,
 		},
 		{
-			name:   "collide 2nd part",
+			name: "overlapping int ranges",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "int(0,10)"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "int(10,)"}},
+			err:  ErrPatternCollision,
+		},
+		{
+			name: "disjoint exclusive int ranges",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "int(0,<10)"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "int(10,)"}},
+			err:  nil,
+		},
+		{
+			name: "disjoint uint and negative int",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "uint"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "int(,-1)"}},
+			err:  nil,
+		},
+		{
+			name: "overlapping uint and int",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "uint"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "int(,0)"}},
+			err:  ErrPatternCollision,
+		},
+		{
+			name: "disjoint float and int ranges",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "float(0,<1)"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "int(1,5)"}},
+			err:  nil,
+		},
+		{
+			name: "float range without integer",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "float(>0.5,0.7)"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "int"}},
+			err:  nil,
+		},
+		{
+			name: "overlapping float ranges",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "float(0,1)"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "float(1,2)"}},
+			err:  ErrPatternCollision,
+		},
+		{
+			name: "disjoint exclusive float ranges",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "float(0,<1)"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "float(1,2)"}},
+			err:  nil,
+		},
+		{
+			name: "int range and string",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "int(0,9)"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "string"}},
+			err:  ErrPatternCollision,
+		},
+		{
+			name: "enum and static",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "enum(x,y)"}},
+			srv2: service{method: "GET", path: "/a/b"},
+			err:  nil,
+		},
+		{
+			name: "enum capturing static",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "enum(b,c)"}},
+			srv2: service{method: "GET", path: "/a/b"},
+			err:  ErrPatternCollision,
+		},
+		{
+			name: "disjoint enums",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "enum(x,y)"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "enum(z)"}},
+			err:  nil,
+		},
+		{
+			name: "overlapping enums",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "enum(x,y)"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "enum(y,z)"}},
+			err:  ErrPatternCollision,
+		},
+		{
+			name: "enum and uint",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "enum(new,old)"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "uint"}},
+			err:  nil,
+		},
+		{
+			name: "numeric enum and uint",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "uint"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "enum(new,1)"}},
+			err:  ErrPatternCollision,
+		},
+		{
+			name: "enum and string",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "enum(x)"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "string"}},
+			err:  ErrPatternCollision,
+		},
+		{
+			name: "pattern excluding static",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "string(/^[0-9]+$/)"}},
+			srv2: service{method: "GET", path: "/a/new"},
+			err:  nil,
+		},
+		{
+			name: "pattern capturing static",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "string(/^[a-z-]+$/)"}},
+			srv2: service{method: "GET", path: "/a/new"},
+			err:  ErrPatternCollision,
+		},
+		{
+			name: "anchored patterns with distinct prefixes",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "string(/^sku-[0-9]+$/)"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "string(/^ref-[0-9]+$/)"}},
+			err:  nil,
+		},
+		{
+			name: "anchored patterns with shared prefix",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "string(/^sku-[0-9]+$/)"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "string(/^sku-[0-9a-f]+$/)"}},
+			err:  ErrPatternCollision,
+		},
+		{
+			name: "anchored alternate patterns",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "string(/^sku-[0-9]+$/)"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "string(/^(ref|sku)-[0-9]+$/)"}},
+			err:  ErrPatternCollision,
+		},
+		{
+			name: "unanchored patterns",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "string(/sku-[0-9]+$/)"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "string(/^ref-[0-9]+$/)"}},
+			err:  ErrPatternCollision,
+		},
+		{
+			name: "pattern and enum",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "string(/^[0-9]+$/)"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "enum(new,top)"}},
+			err:  nil,
+		},
+		{
+			name: "pattern and string",
+			srv1: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "string(/^sku-[0-9]+$/)"}},
+			srv2: service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "string"}},
+			err:  ErrPatternCollision,
+		},
+		{
+			name: "disjoint ranges then colliding captures",
+			srv1: service{method: "GET", path: "/a/{var}/{other}", params: map[string]string{"{var}": "int(0,9)", "{other}": "string"}},
+			srv2: service{method: "GET", path: "/a/{var}/{other}", params: map[string]string{"{var}": "int(10,19)", "{other}": "string"}},
+			err:  nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := &Server{}
+			srv.AddInputValidator(validator.StringType{})
+			srv.AddInputValidator(validator.UintType{})
+			srv.AddInputValidator(validator.IntType{})
+			srv.AddInputValidator(validator.FloatType{})
+			srv.AddInputValidator(validator.EnumType{})
+
+			var (
+				params1 = serializeParams(t, tc.srv1.params)
+				params2 = serializeParams(t, tc.srv2.params)
+			)
+
+			raw := fmt.Sprintf(`[
+					{ "method": "%s", "path": "%s", "info": "info", "in": %s },
+					{ "method": "%s", "path": "%s", "info": "info", "in": %s }
+				]`,
+				tc.srv1.method, tc.srv1.path, params1,
+				tc.srv2.method, tc.srv2.path, params2,
+			)
+
+			err := srv.Parse(strings.NewReader(raw))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestServiceCollisionPanic(t *testing.T) {
+	t.Parallel()
+
+	type service struct {
+		method string
+		path   string
+		params map[string]string // name: type
+	}
+
+	tt := []struct {
+		name       string
+		srv1, srv2 service
+		panics     bool
+	}{
+		{
+			name: "same 1-part",
+			srv1: service{method: "GET", path: "/a"},
+			srv2: service{method: "GET", path: "/a"},
+		},
+		{
+			name:   "collide 2nd part",
 			srv1:   service{method: "GET", path: "/a/b"},
 			srv2:   service{method: "GET", path: "/a/{var}", params: map[string]string{"{var}": "string"}},
 			panics: true,
@@ -1103,7 +1588,7 @@ func TestServiceCollisionPanic(t *testing.T) {
 					t.Fatalf("unexpected panic")
 				}
 			}()
-			srv.collide()
+			srv.collide([]int{0, 1})
 		})
 
 		t.Run("nil validator:"+tc.name, func(t *testing.T) {
@@ -1144,165 +1629,9 @@ func TestServiceCollisionPanic(t *testing.T) {
 					t.Fatalf("unexpected panic")
 				}
 			}()
-			srv.collide()
-		})
-	}
-}
-
-func TestMatchSimple(t *testing.T) {
-	t.Parallel()
-	tt := []struct {
-		name  string
-		conf  string
-		uri   string
-		match bool
-	}{
-		{
-			name: "incomplete uri",
-			conf: `[ {
-				"method": "GET",
-				"path": "/a",
-				"info": "info",
-				"in": {}
-			} ]`,
-			uri:   "/",
-			match: false,
-		},
-		{
-			name: "additional parts",
-			conf: `[ {
-				"method": "GET",
-				"path": "/",
-				"info": "info",
-				"in": {}
-			} ]`,
-			uri:   "/a",
-			match: false,
-		},
-		{
-			name: "root match",
-			conf: `[ {
-				"method": "GET",
-				"path": "/",
-				"info": "info",
-				"in": {}
-			} ]`,
-			uri:   "/",
-			match: true,
-		},
-		{
-			name: "1part uri match",
-			conf: `[ {
-				"method": "GET",
-				"path": "/a",
-				"info": "info",
-				"in": {}
-			} ]`,
-			uri:   "/a",
-			match: true,
-		},
-		{
-			name: "1part uri match ending slash",
-			conf: `[ {
-				"method": "GET",
-				"path": "/a",
-				"info": "info",
-				"in": {}
-			} ]`,
-			uri:   "/a/",
-			match: true,
-		},
-		{
-			name: "1part uri match ending slashes",
-			conf: `[ {
-				"method": "GET",
-				"path": "/a",
-				"info": "info",
-				"in": {}
-			} ]`,
-			uri:   "/a///////",
-			match: true,
-		},
-		{
-			name: "1part ignored query",
-			conf: `[ {
-				"method": "GET",
-				"path": "/a",
-				"info": "info",
-				"in": {}
-			} ]`,
-			uri:   "/a?param=value",
-			match: true,
-		},
-		{
-			name: "2part mismatching bool",
-			conf: `[ {
-				"method": "GET",
-				"path": "/a/{id}",
-				"info": "info",
-				"in": {
-					"{id}": { "info": "info", "type": "bool", "name": "id"
-					}
-				}
-			} ]`,
-			uri:   "/a/12/",
-			match: false,
-		},
-		{
-			name: "2part matching int",
-			conf: `[ {
-				"method": "GET",
-				"path": "/a/{id}",
-				"info": "info",
-				"in": {
-					"{id}": { "info": "info", "type": "int", "name": "id" }
-				}
-			} ]`,
-			uri:   "/a/12/",
-			match: true,
-		},
-		{
-			name: "2part matching bool",
-			conf: `[ {
-				"method": "GET",
-				"path": "/a/{valid}",
-				"info": "info",
-				"in": {
-					"{valid}": { "info": "info", "type": "bool", "name": "valid" }
-				}
-			} ]`,
-			uri:   "/a/true/",
-			match: true,
-		},
-	}
-
-	for _, tc := range tt {
-		t.Run(tc.name, func(t *testing.T) {
-			srv := &Server{}
-			srv.AddInputValidator(validator.AnyType{})
-			srv.AddInputValidator(validator.IntType{})
-			srv.AddInputValidator(validator.BoolType{})
-			err := srv.Parse(strings.NewReader(tc.conf))
-
-			if err != nil {
-				t.Fatalf("unexpected error: %q", err)
-			}
-
-			if len(srv.Services) != 1 {
-				t.Fatalf("expected to have 1 service, got %d", len(srv.Services))
-			}
-
-			req := httptest.NewRequest(http.MethodGet, tc.uri, nil)
-			match := srv.Services[0].Match(req)
-			if tc.match && !match {
-				t.Fatalf("expected %q to match", tc.uri)
-			}
-			if !tc.match && match {
-				t.Fatalf("expected %q NOT to match", tc.uri)
-			}
+			srv.collide([]int{0, 1})
 		})
 	}
-
 }
 
 func TestFindPriority(t *testing.T) {
@@ -1388,7 +1717,7 @@ func TestFindPriority(t *testing.T) {
 			}
 
 			req := httptest.NewRequest(http.MethodGet, tc.uri, nil)
-			svc := srv.Find(req)
+			svc := NewRouter(srv.Services).Find(req)
 			if svc == nil && tc.match {
 				t.Fatalf("expected to find a service")
 			}
@@ -1405,14 +1734,145 @@ func TestFindPriority(t *testing.T) {
 	}
 }
 
-func TestScopeVars(t *testing.T) {
+func TestRouterFind(t *testing.T) {
 	t.Parallel()
+	conf := `[
+		{ "method": "GET",  "path": "/a/{id}",        "info": "get-id",     "in": { "{id}":   { "info": "info", "type": "int",  "name": "ID"   } } },
+		{ "method": "PUT",  "path": "/a/{flag}",      "info": "put-flag",   "in": { "{flag}": { "info": "info", "type": "bool", "name": "Flag" } } },
+		{ "method": "GET",  "path": "/a/b",           "info": "get-static" },
+		{ "method": "POST", "path": "/a/{name}",      "info": "post-name",  "in": { "{name}": { "info": "info", "type": "any",  "name": "Name" } } },
+		{ "method": "GET",  "path": "/a/{id}/c",      "info": "get-id-c",   "in": { "{id}":   { "info": "info", "type": "int",  "name": "ID"   } } },
+		{ "method": "GET",  "path": "/a/{name}/c/d",  "info": "get-name-d", "in": { "{name}": { "info": "info", "type": "any",  "name": "Name" } } }
+	]`
 
 	tt := []struct {
-		name string
+		name   string
+		method string
+		uri    string
+		info   string
+	}{
+		{name: "static", method: http.MethodGet, uri: "/a/b", info: "get-static"},
+		{name: "int capture", method: http.MethodGet, uri: "/a/12", info: "get-id"},
+		{name: "bool capture", method: http.MethodPut, uri: "/a/true", info: "put-flag"},
+		{name: "invalid capture", method: http.MethodGet, uri: "/a/abc"},
+		{name: "same type other method", method: http.MethodPost, uri: "/a/12", info: "post-name"},
+		{name: "other method", method: http.MethodPost, uri: "/a/b", info: "post-name"},
+		{name: "unknown method", method: http.MethodDelete, uri: "/a/12"},
+		{name: "deeper capture", method: http.MethodGet, uri: "/a/12/c", info: "get-id-c"},
+		{name: "backtrack to next capture", method: http.MethodGet, uri: "/a/12/c/d", info: "get-name-d"},
+		{name: "too short", method: http.MethodGet, uri: "/a"},
+		{name: "too long", method: http.MethodGet, uri: "/a/12/c/d/e"},
+	}
 
-		scope [][]string
-		vars  map[string]string
+	srv := &Server{}
+	srv.AddInputValidator(validator.AnyType{})
+	srv.AddInputValidator(validator.IntType{})
+	srv.AddInputValidator(validator.BoolType{})
+	err := srv.Parse(strings.NewReader(conf))
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+	router := NewRouter(srv.Services)
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(tc.method, tc.uri, nil)
+			svc := router.Find(req)
+			if svc == nil && len(tc.info) > 0 {
+				t.Fatalf("expected to find a service")
+			}
+			if svc != nil && len(tc.info) == 0 {
+				t.Fatalf("expected to find no service, got %q", svc.Description)
+			}
+			if svc != nil && svc.Description != tc.info {
+				t.Fatalf("invalid description\nactual: %q\nexpect: %q", svc.Description, tc.info)
+			}
+		})
+	}
+}
+
+func TestRouterCaptureOrder(t *testing.T) {
+	t.Parallel()
+	conf := `{
+		"types": { "ID": { "info": "info", "type": "uint" } },
+		"services": [
+			{ "method": "GET", "path": "/a/{any}/any",         "info": "info", "in": { "{any}":  { "info": "info", "type": "any",           "name": "Any"  } } },
+			{ "method": "GET", "path": "/a/{name}/string",     "info": "info", "in": { "{name}": { "info": "info", "type": "string",        "name": "Name" } } },
+			{ "method": "GET", "path": "/a/{id}/uint",         "info": "info", "in": { "{id}":   { "info": "info", "type": "uint",          "name": "ID"   } } },
+			{ "method": "GET", "path": "/a/{slug}/pattern",    "info": "info", "in": { "{slug}": { "info": "info", "type": "string(/^x/)",  "name": "Slug" } } },
+			{ "method": "GET", "path": "/a/{ref}/named",       "info": "info", "in": { "{ref}":  { "info": "info", "type": "ID",            "name": "Ref"  } } },
+			{ "method": "GET", "path": "/a/{list}/enum",       "info": "info", "in": { "{list}": { "info": "info", "type": "enum(new,top)", "name": "List" } } },
+			{ "method": "GET", "path": "/a/{other}/any/other", "info": "info", "in": { "{other}": { "info": "info", "type": "any",          "name": "Other" } } }
+		]
+	}`
+
+	srv := &Server{}
+	srv.AddInputValidator(validator.AnyType{})
+	srv.AddInputValidator(validator.StringType{})
+	srv.AddInputValidator(validator.UintType{})
+	srv.AddInputValidator(validator.EnumType{})
+	err := srv.Parse(strings.NewReader(conf))
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+	router := NewRouter(srv.Services)
+
+	var captures = router.root.static["a"].captures
+	var actual = make([]string, 0, len(captures))
+	for _, capture := range captures {
+		actual = append(actual, capture.typename)
+	}
+	expect := []string{"enum(new,top)", "uint", "ID", "string(/^x/)", "string", "any"}
+	if !reflect.DeepEqual(actual, expect) {
+		t.Fatalf("invalid capture order\nactual: %v\nexpect: %v", actual, expect)
+	}
+}
+
+func TestRouterAllowed(t *testing.T) {
+	t.Parallel()
+
+	srv := &Server{}
+	srv.AddInputValidator(validator.IntType{})
+	err := srv.Parse(strings.NewReader(`[
+		{ "method": "GET",    "path": "/a",        "info": "info" },
+		{ "method": "POST",   "path": "/a",        "info": "info" },
+		{ "method": "PUT",    "path": "/a/{id}",   "info": "info", "in": { "{id}": { "info": "info", "type": "int", "name": "ID" } } },
+		{ "method": "DELETE", "path": "/a/b",      "info": "info" }
+	]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	router := NewRouter(srv.Services)
+
+	tt := []struct {
+		uri     string
+		allowed []string
+	}{
+		{uri: "/", allowed: []string{}},
+		{uri: "/a", allowed: []string{"GET", "HEAD", "POST"}},
+		{uri: "/a/12", allowed: []string{"PUT"}},
+		{uri: "/a/b", allowed: []string{"DELETE"}},
+		{uri: "/a/c", allowed: []string{}},
+	}
+	for _, tc := range tt {
+		allowed := router.Allowed(httptest.NewRequest(http.MethodOptions, tc.uri, nil))
+		if !reflect.DeepEqual(allowed, tc.allowed) {
+			t.Fatalf("%s: invalid methods\nactual: %v\nexpect: %v", tc.uri, allowed, tc.allowed)
+		}
+	}
+}
+
+func TestScopeVars(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+
+		scope [][]string
+		vars  map[string]string
 
 		// expected scopes according to the vars
 		expect [][]string
@@ -1523,3 +1983,714 @@ func TestScopeVars(t *testing.T) {
 		})
 	}
 }
+
+func TestParamObject(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		in     string
+		out    string
+		err    error
+		gotype reflect.Type
+	}{
+		{
+			name: "object",
+			in: `{ "info": "info", "type": "object", "fields": {
+				"city": { "info": "info", "type": "string", "name": "City" },
+				"zip":  { "info": "info", "type": "?int", "name": "Zip" }
+			} }`,
+			gotype: reflect.TypeOf(struct {
+				City string `json:"city"`
+				Zip  *int   `json:"zip"`
+			}{}),
+		},
+		{
+			name: "field default",
+			in: `{ "info": "info", "type": "object", "fields": {
+				"Zip": { "info": "info", "type": "?int", "default": 75000 }
+			} }`,
+			gotype: reflect.TypeOf(struct {
+				Zip int `json:"Zip"`
+			}{}),
+		},
+		{
+			name: "slice of objects",
+			in: `{ "info": "info", "type": "[]object", "fields": {
+				"id": { "info": "info", "type": "int", "name": "ID" }
+			} }`,
+			gotype: reflect.TypeOf([]struct {
+				ID int `json:"id"`
+			}{}),
+		},
+		{
+			name: "map of objects",
+			in: `{ "info": "info", "type": "map[string]object", "fields": {
+				"id": { "info": "info", "type": "int", "name": "ID" }
+			} }`,
+			gotype: reflect.TypeOf(map[string]struct {
+				ID int `json:"id"`
+			}{}),
+		},
+		{
+			name: "nested object",
+			in: `{ "info": "info", "type": "object", "fields": {
+				"address": { "info": "info", "type": "?object", "name": "Address", "fields": {
+					"city": { "info": "info", "type": "string", "name": "City" }
+				} }
+			} }`,
+			gotype: reflect.TypeOf(struct {
+				Address *struct {
+					City string `json:"city"`
+				} `json:"address"`
+			}{}),
+		},
+		{
+			name:   "map",
+			in:     `{ "info": "info", "type": "map[string]int" }`,
+			gotype: reflect.TypeOf(map[string]int{}),
+		},
+		{
+			name: "any field",
+			in: `{ "info": "info", "type": "object", "fields": {
+				"data":  { "info": "info", "type": "any", "name": "Data" },
+				"extra": { "info": "info", "type": "?any", "name": "Extra" }
+			} }`,
+			gotype: reflect.TypeOf(struct {
+				Data  interface{} `json:"data"`
+				Extra interface{} `json:"extra"`
+			}{}),
+		},
+		{
+			name: "slice of any",
+			in:   `{ "info": "info", "type": "[]any" }`,
+			err:  ErrUnknownParamType,
+		},
+		{
+			name: "map of any",
+			in:   `{ "info": "info", "type": "map[string]any" }`,
+			err:  ErrUnknownParamType,
+		},
+		{
+			name: "object without fields",
+			in:   `{ "info": "info", "type": "object" }`,
+			err:  ErrUnknownParamType,
+		},
+		{
+			name: "fields without object",
+			in: `{ "info": "info", "type": "int", "fields": {
+				"id": { "info": "info", "type": "int", "name": "ID" }
+			} }`,
+			err: ErrUnusedFields,
+		},
+		{
+			name: "unexported field name",
+			in: `{ "info": "info", "type": "object", "fields": {
+				"id": { "info": "info", "type": "int" }
+			} }`,
+			err: ErrInvalidFieldName,
+		},
+		{
+			name: "invalid field name",
+			in: `{ "info": "info", "type": "object", "fields": {
+				"id": { "info": "info", "type": "int", "name": "I-D" }
+			} }`,
+			err: ErrInvalidFieldName,
+		},
+		{
+			name: "field name conflict",
+			in: `{ "info": "info", "type": "object", "fields": {
+				"a": { "info": "info", "type": "int", "name": "ID" },
+				"b": { "info": "info", "type": "int", "name": "ID" }
+			} }`,
+			err: ErrParamNameConflict,
+		},
+		{
+			name: "invalid field pattern",
+			in: `{ "info": "info", "type": "object", "fields": {
+				"slug": { "info": "info", "type": "[]string(/a(/)", "name": "Slug" }
+			} }`,
+			err: ErrInvalidParamType,
+		},
+		{
+			name: "unknown field type",
+			in: `{ "info": "info", "type": "object", "fields": {
+				"id": { "info": "info", "type": "unknown", "name": "ID" }
+			} }`,
+			err: ErrUnknownParamType,
+		},
+		{
+			name: "missing field description",
+			in: `{ "info": "info", "type": "object", "fields": {
+				"id": { "type": "int", "name": "ID" }
+			} }`,
+			err: ErrMissingParamDesc,
+		},
+		{
+			name: "output fields",
+			in:   `{ "info": "info", "type": "int" }`,
+			out: `{ "info": "info", "type": "object", "fields": {
+				"id": { "info": "info", "type": "int", "name": "ID" }
+			} }`,
+			err: ErrOutputFields,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			out := "{}"
+			if len(tc.out) > 0 {
+				out = fmt.Sprintf(`{ "Out": %s }`, tc.out)
+			}
+			conf := fmt.Sprintf(`[ {
+				"method": "POST",
+				"path": "/",
+				"info": "info",
+				"in": { "Param": %s },
+				"out": %s
+			} ]`, tc.in, out)
+
+			srv := &Server{}
+			srv.AddInputValidator(validator.SliceType{})
+			srv.AddInputValidator(validator.MapType{})
+			srv.AddInputValidator(validator.AnyType{})
+			srv.AddInputValidator(validator.IntType{})
+			srv.AddInputValidator(validator.StringType{})
+			srv.AddOutputValidator("int", reflect.TypeOf(int(0)))
+			err := srv.Parse(strings.NewReader(conf))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, tc.err)
+			}
+			if err != nil {
+				return
+			}
+			param := srv.Services[0].Input["Param"]
+			if param.GoType != tc.gotype {
+				t.Fatalf("invalid go type\nactual: %v\nexpect: %v", param.GoType, tc.gotype)
+			}
+		})
+	}
+}
+
+func TestParamObjectValues(t *testing.T) {
+	t.Parallel()
+
+	const conf = `[ {
+		"method": "POST",
+		"path": "/",
+		"info": "info",
+		"in": {
+			"Param": { "info": "info", "type": "object", "fields": {
+				"city":  { "info": "info", "type": "string", "name": "City" },
+				"data":  { "info": "info", "type": "?any", "name": "Data" },
+				"zip":   { "info": "info", "type": "?int", "name": "Zip" },
+				"state": { "info": "info", "type": "?string", "name": "State", "default": "none" },
+				"tags":  { "info": "info", "type": "?[]string", "name": "Tags" }
+			} }
+		}
+	} ]`
+	type object struct {
+		City  string      `json:"city"`
+		Data  interface{} `json:"data"`
+		State string      `json:"state"`
+		Tags  *[]string   `json:"tags"`
+		Zip   *int        `json:"zip"`
+	}
+	var zip = 75000
+
+	tt := []struct {
+		name   string
+		value  interface{}
+		valid  bool
+		expect interface{}
+	}{
+		{
+			name:   "mandatory only",
+			value:  map[string]interface{}{"city": "Paris"},
+			valid:  true,
+			expect: object{City: "Paris", State: "none"},
+		},
+		{
+			name:   "all fields",
+			value:  map[string]interface{}{"city": "Paris", "zip": float64(75000), "state": "IDF", "tags": []interface{}{"a"}},
+			valid:  true,
+			expect: object{City: "Paris", State: "IDF", Zip: &zip, Tags: &[]string{"a"}},
+		},
+		{
+			name:   "any field",
+			value:  map[string]interface{}{"city": "Paris", "data": map[string]interface{}{"a": float64(1)}},
+			valid:  true,
+			expect: object{City: "Paris", State: "none", Data: map[string]interface{}{"a": float64(1)}},
+		},
+		{
+			name:   "unknown fields are ignored",
+			value:  map[string]interface{}{"city": "Paris", "unknown": 1},
+			valid:  true,
+			expect: object{City: "Paris", State: "none"},
+		},
+		{
+			name:  "missing mandatory field",
+			value: map[string]interface{}{"zip": 75000},
+		},
+		{
+			name:  "invalid field",
+			value: map[string]interface{}{"city": "Paris", "zip": "abc"},
+		},
+		{
+			name:  "not an object",
+			value: "Paris",
+		},
+		{
+			name:  "nil",
+			value: nil,
+		},
+	}
+
+	srv := &Server{}
+	srv.AddInputValidator(validator.AnyType{})
+	srv.AddInputValidator(validator.SliceType{})
+	srv.AddInputValidator(validator.IntType{})
+	srv.AddInputValidator(validator.StringType{})
+	if err := srv.Parse(strings.NewReader(conf)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	param := srv.Services[0].Input["Param"]
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cast, valid := param.Validator(tc.value)
+			if valid != tc.valid {
+				t.Fatalf("invalid validity\nactual: %t\nexpect: %t", valid, tc.valid)
+			}
+			if !valid {
+				return
+			}
+			var actual = reflect.ValueOf(cast).Convert(reflect.TypeOf(object{})).Interface()
+			if !reflect.DeepEqual(actual, tc.expect) {
+				t.Fatalf("invalid value\nactual: %#v\nexpect: %#v", actual, tc.expect)
+			}
+		})
+	}
+}
+
+func TestParamObjectDefaultCopy(t *testing.T) {
+	t.Parallel()
+
+	const conf = `[ {
+		"method": "POST",
+		"path": "/",
+		"info": "info",
+		"in": {
+			"Param": { "info": "info", "type": "object", "fields": {
+				"tags": { "info": "info", "type": "?[]string", "name": "Tags", "default": ["a"] }
+			} }
+		}
+	} ]`
+	type object struct {
+		Tags []string `json:"tags"`
+	}
+
+	srv := &Server{}
+	srv.AddInputValidator(validator.SliceType{})
+	srv.AddInputValidator(validator.StringType{})
+	if err := srv.Parse(strings.NewReader(conf)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	param := srv.Services[0].Input["Param"]
+
+	for i := 0; i < 2; i++ {
+		cast, valid := param.Validator(map[string]interface{}{})
+		if !valid {
+			t.Fatalf("request %d: unexpected invalid value", i)
+		}
+		var actual = reflect.ValueOf(cast).Convert(reflect.TypeOf(object{})).Interface().(object)
+		if !reflect.DeepEqual(actual.Tags, []string{"a"}) {
+			t.Fatalf("request %d: invalid tags\nactual: %v\nexpect: %v", i, actual.Tags, []string{"a"})
+		}
+
+		// handler modifying its input
+		actual.Tags[0] = "mutated"
+	}
+}
+
+func TestNamedTypes(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		types  string
+		in     string
+		err    error
+		gotype reflect.Type
+		valid  []interface{}
+	}{
+		{
+			name:   "alias",
+			types:  `{ "username": "string(3,5)" }`,
+			in:     `{ "info": "info", "type": "username" }`,
+			gotype: reflect.TypeOf(""),
+			valid:  []interface{}{"abc"},
+		},
+		{
+			name:   "optional alias",
+			types:  `{ "username": "string(3,5)" }`,
+			in:     `{ "info": "info", "type": "?username" }`,
+			gotype: reflect.TypeOf(""),
+		},
+		{
+			name:   "slice of alias",
+			types:  `{ "username": "string(3,5)" }`,
+			in:     `{ "info": "info", "type": "[]username" }`,
+			gotype: reflect.TypeOf([]string{}),
+			valid:  []interface{}{[]interface{}{"abc", "abcd"}},
+		},
+		{
+			name:   "alias of alias",
+			types:  `{ "usernames": "[]username", "username": "string(3,5)" }`,
+			in:     `{ "info": "info", "type": "usernames" }`,
+			gotype: reflect.TypeOf([]string{}),
+		},
+		{
+			name: "object",
+			types: `{
+				"user": { "info": "info", "type": "object", "fields": {
+					"name": { "info": "info", "type": "username", "name": "Name" }
+				} },
+				"username": "string(3,5)"
+			}`,
+			in: `{ "info": "info", "type": "[]user" }`,
+			gotype: reflect.TypeOf([]struct {
+				Name string `json:"name"`
+			}{}),
+			valid: []interface{}{[]interface{}{map[string]interface{}{"name": "abc"}}},
+		},
+		{
+			name:   "enum",
+			types:  `{ "order": { "info": "sort order", "type": "enum(asc,desc)" } }`,
+			in:     `{ "info": "info", "type": "?order" }`,
+			gotype: reflect.TypeOf(""),
+			valid:  []interface{}{"asc", "desc"},
+		},
+		{
+			name:   "pattern",
+			types:  `{ "slug": "string(/^[a-z0-9-]+$/)" }`,
+			in:     `{ "info": "info", "type": "[]slug" }`,
+			gotype: reflect.TypeOf([]string{}),
+			valid:  []interface{}{[]interface{}{"my-slug", "slug-2"}},
+		},
+		{
+			name:  "invalid pattern",
+			types: `{ "slug": "string(/^[a-z0-9-+$/)" }`,
+			in:    `{ "info": "info", "type": "int" }`,
+			err:   ErrInvalidParamType,
+		},
+		{
+			name:  "unknown type",
+			types: `{ "username": "unknown" }`,
+			in:    `{ "info": "info", "type": "int" }`,
+			err:   ErrUnknownParamType,
+		},
+		{
+			name:  "unknown named type",
+			types: `{ "username": "string" }`,
+			in:    `{ "info": "info", "type": "user" }`,
+			err:   ErrUnknownParamType,
+		},
+		{
+			name:  "empty definition",
+			types: `{ "username": "" }`,
+			in:    `{ "info": "info", "type": "int" }`,
+			err:   ErrMissingParamType,
+		},
+		{
+			name:  "optional definition",
+			types: `{ "username": "?string" }`,
+			in:    `{ "info": "info", "type": "int" }`,
+			err:   ErrOptionalTypeDef,
+		},
+		{
+			name:  "invalid name",
+			types: `{ "user-name": "string" }`,
+			in:    `{ "info": "info", "type": "int" }`,
+			err:   ErrInvalidTypeName,
+		},
+		{
+			name:  "shadowing",
+			types: `{ "int": "string" }`,
+			in:    `{ "info": "info", "type": "int" }`,
+			err:   ErrTypeNameConflict,
+		},
+		{
+			name:  "self reference",
+			types: `{ "a": "[]a" }`,
+			in:    `{ "info": "info", "type": "int" }`,
+			err:   ErrRecursiveType,
+		},
+		{
+			name:  "cross reference",
+			types: `{ "a": "[]b", "b": "[]a" }`,
+			in:    `{ "info": "info", "type": "int" }`,
+			err:   ErrRecursiveType,
+		},
+		{
+			name: "recursive object",
+			types: `{ "user": { "type": "object", "fields": {
+				"friends": { "info": "info", "type": "[]user", "name": "Friends" }
+			} } }`,
+			in:  `{ "info": "info", "type": "int" }`,
+			err: ErrRecursiveType,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			conf := fmt.Sprintf(`{
+				"types": %s,
+				"services": [ {
+					"method": "POST",
+					"path": "/",
+					"info": "info",
+					"in": { "Param": %s }
+				} ]
+			}`, tc.types, tc.in)
+
+			srv := &Server{}
+			srv.AddInputValidator(validator.SliceType{})
+			srv.AddInputValidator(validator.IntType{})
+			srv.AddInputValidator(validator.StringType{})
+			srv.AddInputValidator(validator.EnumType{})
+			err := srv.Parse(strings.NewReader(conf))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, tc.err)
+			}
+			if err != nil {
+				return
+			}
+			param := srv.Services[0].Input["Param"]
+			if param.GoType != tc.gotype {
+				t.Fatalf("invalid go type\nactual: %v\nexpect: %v", param.GoType, tc.gotype)
+			}
+			for _, value := range tc.valid {
+				if _, valid := param.Validator(value); !valid {
+					t.Fatalf("expect %v to be valid", value)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFormats(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		conf     string
+		err      bool
+		services int
+	}{
+		{
+			name:     "array",
+			conf:     `[ { "method": "GET", "path": "/", "info": "info" } ]`,
+			services: 1,
+		},
+		{
+			name:     "object",
+			conf:     `{ "services": [ { "method": "GET", "path": "/", "info": "info" } ] }`,
+			services: 1,
+		},
+		{
+			name:     "object with types",
+			conf:     `{ "types": { "id": "int" }, "services": [ { "method": "GET", "path": "/", "info": "info" } ] }`,
+			services: 1,
+		},
+		{
+			name:     "object without services",
+			conf:     `{ "types": { "id": "int" } }`,
+			services: 0,
+		},
+		{
+			name: "invalid types",
+			conf: `{ "types": [], "services": [] }`,
+			err:  true,
+		},
+		{
+			name: "invalid format",
+			conf: `"services"`,
+			err:  true,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := &Server{}
+			srv.AddInputValidator(validator.IntType{})
+			err := srv.Parse(strings.NewReader(tc.conf))
+			if (err != nil) != tc.err {
+				t.Fatalf("invalid error\nactual: %v\nexpect error: %t", err, tc.err)
+			}
+			if err != nil {
+				return
+			}
+			if len(srv.Services) != tc.services {
+				t.Fatalf("invalid services count\nactual: %d\nexpect: %d", len(srv.Services), tc.services)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	type located struct {
+		line, column int
+		service      string
+		param        string
+		err          error
+	}
+
+	tt := []struct {
+		name   string
+		conf   string
+		expect []located
+	}{
+		{
+			name: "syntax error",
+			conf: "[\n\t{ \"method\": \"GET\" \"path\": \"/\" }\n]",
+			expect: []located{
+				{line: 2, column: 20},
+			},
+		},
+		{
+			name: "type error",
+			conf: "[\n\t{ \"method\": 12 }\n]",
+			expect: []located{
+				{line: 2, column: 16},
+			},
+		},
+		{
+			name: "every service and parameter",
+			conf: `[
+	{
+		"method": "GET",
+		"path": "/a",
+		"info": "",
+		"in": {
+			"a": { "info": "info", "type": "unknown" },
+			"b": { "info": "", "type": "int" }
+		}
+	},
+	{
+		"method": "UNKNOWN",
+		"path": "/b",
+		"info": "info"
+	},
+	{
+		"method": "GET",
+		"path": "/c/{id}",
+		"info": "info",
+		"in": {
+			"{id}": { "info": "info", "type": "int", "name": "ID" }
+		}
+	},
+	{
+		"method": "GET",
+		"path": "/c/{id}",
+		"info": "info",
+		"in": {
+			"{id}": { "info": "info", "type": "int", "name": "ID" }
+		},
+		"out": {
+			"a": { "info": "info", "type": "unknown" }
+		}
+	},
+	{
+		"method": "GET",
+		"path": "/c/{name}",
+		"info": "info",
+		"in": {
+			"{name}": { "info": "info", "type": "string", "name": "Name" }
+		}
+	}
+]`,
+			expect: []located{
+				{line: 5, column: 3, service: `GET "/a"`, err: ErrMissingDescription},
+				{line: 7, column: 4, service: `GET "/a"`, param: "a", err: ErrUnknownParamType},
+				{line: 8, column: 4, service: `GET "/a"`, param: "b", err: ErrMissingParamDesc},
+				{line: 12, column: 3, service: `UNKNOWN "/b"`, err: ErrUnknownMethod},
+				{line: 32, column: 4, service: `GET "/c/{id}"`, param: "a", err: ErrUnknownParamType},
+				{line: 37, column: 3, service: `GET "/c/{name}"`, err: ErrPatternCollision},
+			},
+		},
+		{
+			name: "types",
+			conf: `{
+	"types": {
+		"a": "unknown",
+		"b-c": "int"
+	},
+	"services": [
+		{
+			"method": "GET",
+			"path": "/",
+			"info": "info",
+			"in": {
+				"GET@a": { "info": "info", "type": "a" }
+			}
+		}
+	]
+}`,
+			expect: []located{
+				{line: 3, column: 3, param: "a", err: ErrUnknownParamType},
+				{line: 4, column: 3, param: "b-c", err: ErrInvalidTypeName},
+				{line: 12, column: 5, service: `GET "/"`, param: "GET@a", err: ErrMandatoryRename},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := &Server{}
+			srv.AddInputValidator(validator.IntType{})
+			srv.AddInputValidator(validator.StringType{})
+			err := srv.Parse(strings.NewReader(tc.conf))
+
+			var errs Errors
+			if !errors.As(err, &errs) {
+				t.Fatalf("expected Errors, got %v", err)
+			}
+			if len(errs) != len(tc.expect) {
+				t.Fatalf("invalid errors count\nactual: %d (%v)\nexpect: %d", len(errs), errs, len(tc.expect))
+			}
+			for i, expect := range tc.expect {
+				var actual = errs[i]
+				if actual.Line != expect.line || actual.Column != expect.column {
+					t.Errorf("[%d] invalid position\nactual: %d:%d (%v)\nexpect: %d:%d", i, actual.Line, actual.Column, actual, expect.line, expect.column)
+				}
+				if actual.Service != expect.service {
+					t.Errorf("[%d] invalid service\nactual: %s\nexpect: %s", i, actual.Service, expect.service)
+				}
+				if actual.Param != expect.param {
+					t.Errorf("[%d] invalid param\nactual: %s\nexpect: %s", i, actual.Param, expect.param)
+				}
+				if expect.err != nil && !errors.Is(actual, expect.err) {
+					t.Errorf("[%d] invalid error\nactual: %v\nexpect: %v", i, actual, expect.err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package config
+
+// CORS defines service-specific overrides of the server's cross-origin
+// resource sharing policy. Empty fields keep the server's policy.
+type CORS struct {
+	// Origins replaces the allowed origins, "*" allows any origin
+	Origins []string `json:"origins,omitempty"`
+	// Headers lists request headers allowed in addition to the server's
+	Headers []string `json:"headers,omitempty"`
+	// Exposed replaces the response headers exposed to browsers
+	Exposed []string `json:"exposed,omitempty"`
+	// Credentials overrides whether credentials are allowed
+	Credentials *bool `json:"credentials,omitempty"`
+	// MaxAge overrides how long (in seconds) preflight results can be cached
+	MaxAge *int `json:"max_age,omitempty"`
+}
+
+// validate the cors overrides
+func (c *CORS) validate() error {
+	for _, origin := range c.Origins {
+		if len(origin) < 1 {
+			return ErrInvalidCORSOrigin
+		}
+	}
+	for _, header := range append(c.Headers, c.Exposed...) {
+		if len(header) < 1 {
+			return ErrInvalidCORSHeader
+		}
+	}
+	if c.MaxAge != nil && *c.MaxAge < 0 {
+		return ErrInvalidCORSMaxAge
+	}
+	return nil
+}
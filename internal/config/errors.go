@@ -1,5 +1,11 @@
 package config
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
 // Err allows you to create constant "const" error with type boxing.
 type Err string
 
@@ -14,6 +20,9 @@ const (
 	// ErrUnknownMethod - unknown http method
 	ErrUnknownMethod = Err("unknown HTTP method")
 
+	// ErrInvalidMethod - custom http method is not a valid method name
+	ErrInvalidMethod = Err("invalid HTTP method name")
+
 	// ErrFormat - invalid format
 	ErrFormat = Err("invalid config format")
 
@@ -32,8 +41,8 @@ const (
 	// ErrUndefinedBraceCapture - missing capturing brace definition
 	ErrUndefinedBraceCapture = Err("missing uri parameter definition")
 
-	// ErrMandatoryRename - capture/query parameters must be renamed
-	ErrMandatoryRename = Err("uri and query parameters must be renamed")
+	// ErrMandatoryRename - capture/query/header/cookie parameters must be renamed
+	ErrMandatoryRename = Err("uri, query, header and cookie parameters must be renamed")
 
 	// ErrMissingDescription - a service is missing its description
 	ErrMissingDescription = Err("missing description")
@@ -50,6 +59,10 @@ const (
 	// ErrUnknownParamType - unknown parameter type
 	ErrUnknownParamType = Err("unknown parameter datatype")
 
+	// ErrInvalidParamType - parameter type recognized but invalid, e.g. a
+	// malformed pattern
+	ErrInvalidParamType = Err("invalid parameter datatype")
+
 	// ErrIllegalParamName - illegal parameter name
 	ErrIllegalParamName = Err("illegal parameter name")
 
@@ -58,4 +71,123 @@ const (
 
 	// ErrParamNameConflict - name/rename conflict
 	ErrParamNameConflict = Err("parameter name conflict")
+
+	// ErrMandatoryDefault - default value on a mandatory parameter
+	ErrMandatoryDefault = Err("default value requires an optional parameter")
+
+	// ErrInvalidDefault - default value does not satisfy the parameter type
+	ErrInvalidDefault = Err("default value does not match the parameter type")
+
+	// ErrOutputDefault - default value on an output parameter
+	ErrOutputDefault = Err("output cannot have a default value")
+
+	// ErrUnusedFields - fields defined on a non-object parameter
+	ErrUnusedFields = Err("fields require an object type")
+
+	// ErrInvalidFieldName - object field name is not an exported go name
+	ErrInvalidFieldName = Err("object field name must be an exported go identifier")
+
+	// ErrOutputFields - object schema on an output parameter
+	ErrOutputFields = Err("output cannot define fields")
+
+	// ErrInvalidTypeName - invalid named type name
+	ErrInvalidTypeName = Err("invalid type name")
+
+	// ErrTypeNameConflict - named type shadows an available type
+	ErrTypeNameConflict = Err("type name conflicts with an available type")
+
+	// ErrOptionalTypeDef - named type defined as optional
+	ErrOptionalTypeDef = Err("type definition cannot be optional")
+
+	// ErrRecursiveType - named type refers to itself
+	ErrRecursiveType = Err("recursive type definition")
+
+	// ErrInvalidCORSOrigin - empty cors origin
+	ErrInvalidCORSOrigin = Err("invalid cors origin")
+
+	// ErrInvalidCORSHeader - empty cors header
+	ErrInvalidCORSHeader = Err("invalid cors header")
+
+	// ErrInvalidCORSMaxAge - negative cors max age
+	ErrInvalidCORSMaxAge = Err("cors max age cannot be negative")
 )
+
+// Error is a configuration error located in the configuration file
+type Error struct {
+	// Service is the method and path of the service, e.g. `GET "/users"`,
+	// it is empty for errors that do not relate to a service
+	Service string
+	// Field is the name of the service field, e.g. "in", "path"
+	Field string
+	// Param is the key of the parameter or named type
+	Param string
+	// Line and Column locate the error in the configuration file starting at 1,
+	// they are zero when the location is unknown
+	Line   int
+	Column int
+	// Err is the actual error
+	Err error
+
+	// path of the json value in the configuration, e.g.
+	// ["services", "0", "in", "GET@id"], used to locate the error
+	path []string
+}
+
+// Error implements error
+func (err *Error) Error() string {
+	var b strings.Builder
+	if err.Line > 0 {
+		fmt.Fprintf(&b, "line %d, column %d: ", err.Line, err.Column)
+	}
+	if len(err.Service) > 0 {
+		b.WriteString(err.Service)
+		b.WriteString(": ")
+	}
+	if len(err.Field) > 0 {
+		fmt.Fprintf(&b, "field '%s': ", err.Field)
+	}
+	if len(err.Param) > 0 {
+		b.WriteString(err.Param)
+		b.WriteString(": ")
+	}
+	b.WriteString(err.Err.Error())
+	return b.String()
+}
+
+// Unwrap implements errors.Unwrap
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// Errors lists every error of a configuration, ordered as they appear in the
+// configuration
+type Errors []*Error
+
+// Error implements error, it features an error per line
+func (errs Errors) Error() string {
+	var lines = make([]string, 0, len(errs))
+	for _, err := range errs {
+		lines = append(lines, err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Is implements errors.Is, it matches when any error matches
+func (errs Errors) Is(target error) bool {
+	for _, err := range errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As implements errors.As, it matches the first error that matches
+func (errs Errors) As(target interface{}) bool {
+	for _, err := range errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"math"
+
+	"github.com/xdrm-io/aicra/validator"
+)
+
+// interval defines the numeric values accepted by a parameter, bounds are
+// infinite when open
+type interval struct {
+	min, max                   float64
+	minExclusive, maxExclusive bool
+	// integer intervals only contain integers
+	integer bool
+}
+
+// numericInterval returns the interval described by a numeric schema
+func numericInterval(schema *validator.Schema) (interval, bool) {
+	if schema == nil || (schema.Type != "integer" && schema.Type != "number") {
+		return interval{}, false
+	}
+	var i = interval{
+		min:     math.Inf(-1),
+		max:     math.Inf(1),
+		integer: schema.Type == "integer",
+	}
+	if schema.Minimum != nil {
+		i.min = *schema.Minimum
+	}
+	if schema.ExclusiveMinimum != nil && *schema.ExclusiveMinimum >= i.min {
+		i.min, i.minExclusive = *schema.ExclusiveMinimum, true
+	}
+	if schema.Maximum != nil {
+		i.max = *schema.Maximum
+	}
+	if schema.ExclusiveMaximum != nil && *schema.ExclusiveMaximum <= i.max {
+		i.max, i.maxExclusive = *schema.ExclusiveMaximum, true
+	}
+	return i, true
+}
+
+// intersect returns the values of both intervals
+func (i interval) intersect(other interval) interval {
+	var out = i
+	out.integer = i.integer || other.integer
+	if other.min > out.min || (other.min == out.min && other.minExclusive) {
+		out.min, out.minExclusive = other.min, other.minExclusive
+	}
+	if other.max < out.max || (other.max == out.max && other.maxExclusive) {
+		out.max, out.maxExclusive = other.max, other.maxExclusive
+	}
+	return out
+}
+
+// empty returns whether the interval contains no value
+func (i interval) empty() bool {
+	if !i.integer {
+		return i.min > i.max || (i.min == i.max && (i.minExclusive || i.maxExclusive))
+	}
+
+	// closest integers within the bounds
+	min, max := math.Ceil(i.min), math.Floor(i.max)
+	if i.minExclusive && min == i.min {
+		min++
+	}
+	if i.maxExclusive && max == i.max {
+		max--
+	}
+	return min > max
+}
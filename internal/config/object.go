@@ -0,0 +1,173 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"reflect"
+	"sort"
+
+	"github.com/xdrm-io/aicra/validator"
+)
+
+// objectTypename is the typename of inline object schemas defined with the
+// "fields" attribute of a parameter. It can be nested in composite types,
+// e.g. "[]object" or "map[string]object"
+const objectTypename = "object"
+
+var (
+	errNotObject    = errors.New("not an object")
+	errMissingField = errors.New("missing")
+)
+
+// objectType validates json objects against the inline schema of a parameter.
+// It implements validator.Type so that composite types (slices, maps) can
+// resolve "object" among the available types.
+//
+// Objects are cast into an anonymous struct featuring a field for each schema
+// field named after its "name" attribute. Optional fields without default value
+// are pointers.
+type objectType struct {
+	// fields indexed by their json key
+	fields map[string]*Parameter
+	// keys sorted alphabetically, used as the struct fields order
+	keys   []string
+	goType reflect.Type
+}
+
+// newObjectType validates the fields of an inline object schema and builds the
+// associated type
+func newObjectType(fields map[string]*Parameter, validators ...validator.Type) (*objectType, error) {
+	var object = &objectType{
+		fields: fields,
+		keys:   make([]string, 0, len(fields)),
+	}
+
+	for key, field := range fields {
+		if len(key) < 1 {
+			return nil, fmt.Errorf("%s: %w", key, ErrIllegalParamName)
+		}
+		// fallback to key when Rename is not provided
+		if len(field.Rename) < 1 {
+			field.Rename = key
+		}
+		if !token.IsIdentifier(field.Rename) || !token.IsExported(field.Rename) {
+			return nil, fmt.Errorf("%s: %w", key, ErrInvalidFieldName)
+		}
+		if err := field.validate(validators...); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		if err := nameConflicts(key, field, fields); err != nil {
+			return nil, err
+		}
+		object.keys = append(object.keys, key)
+	}
+	sort.Strings(object.keys)
+
+	var structFields = make([]reflect.StructField, 0, len(fields))
+	for _, key := range object.keys {
+		structFields = append(structFields, reflect.StructField{
+			Name: fields[key].Rename,
+			Type: fields[key].fieldType(),
+			Tag:  reflect.StructTag(fmt.Sprintf(`json:%q`, key)),
+		})
+	}
+	object.goType = reflect.StructOf(structFields)
+	return object, nil
+}
+
+// fieldType returns the go type of an object field, a pointer when optional
+// without default value
+func (param *Parameter) fieldType() reflect.Type {
+	// "any" has no go type
+	if param.GoType == nil {
+		return reflect.TypeOf((*interface{})(nil)).Elem()
+	}
+	if param.Optional && param.Default == nil {
+		return reflect.PtrTo(param.GoType)
+	}
+	return param.GoType
+}
+
+// GoType implements validator.Type
+func (o *objectType) GoType() reflect.Type {
+	return o.goType
+}
+
+// Validator implements validator.Type
+func (o *objectType) Validator(typename string, avail ...validator.Type) validator.ValidateFunc {
+	if typename != objectTypename {
+		return nil
+	}
+	return o.validate
+}
+
+// Checker implements validator.CheckType
+func (o *objectType) Checker(typename string, avail ...validator.Type) validator.CheckFunc {
+	if typename != objectTypename {
+		return nil
+	}
+	return o.check
+}
+
+// validate a json object against the schema and cast it into the object's go
+// type
+func (o *objectType) validate(value interface{}) (interface{}, bool) {
+	cast, err := o.check(value)
+	return cast, err == nil
+}
+
+// check a json object against the schema and cast it into the object's go
+// type, it describes the first invalid field
+func (o *objectType) check(value interface{}) (interface{}, error) {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return reflect.Zero(o.goType).Interface(), errNotObject
+	}
+
+	var cast = reflect.New(o.goType).Elem()
+	for i, key := range o.keys {
+		var field = o.fields[key]
+
+		raw, exists := object[key]
+		if !exists {
+			if !field.Optional {
+				return reflect.Zero(o.goType).Interface(), fmt.Errorf("field '%s': %w", key, errMissingField)
+			}
+			if field.Default != nil {
+				cast.Field(i).Set(reflect.ValueOf(field.DefaultValue()).Convert(field.fieldType()))
+			}
+			continue
+		}
+
+		value, err := field.Check(raw)
+		if err != nil {
+			return reflect.Zero(o.goType).Interface(), fmt.Errorf("field '%s': %w", key, err)
+		}
+		if value == nil {
+			continue
+		}
+
+		// "any" fields have no go type to convert into
+		if field.GoType == nil {
+			cast.Field(i).Set(reflect.ValueOf(value))
+			continue
+		}
+
+		vvalue := reflect.ValueOf(value)
+		if !vvalue.Type().ConvertibleTo(field.GoType) {
+			return reflect.Zero(o.goType).Interface(), fmt.Errorf("field '%s': %w", key, validator.ErrInvalidValue)
+		}
+		vvalue = vvalue.Convert(field.GoType)
+
+		// optional field without default value
+		if field.fieldType() != field.GoType {
+			ptr := reflect.New(field.GoType)
+			ptr.Elem().Set(vvalue)
+			cast.Field(i).Set(ptr)
+			continue
+		}
+		cast.Field(i).Set(vvalue)
+	}
+	return cast.Interface(), nil
+}
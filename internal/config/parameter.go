@@ -1,7 +1,9 @@
 package config
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/xdrm-io/aicra/validator"
 )
@@ -12,10 +14,102 @@ type Parameter struct {
 	Type        string `json:"type"`
 	Rename      string `json:"name,omitempty"`
 	Optional    bool   `json:"-"`
+	// Default value of an optional input parameter when it is not provided,
+	// it is cast by the Validator when the configuration is validated
+	Default interface{} `json:"default,omitempty"`
+	// Fields defines an inline object schema used by the "object" type, e.g.
+	// "object", "[]object" or "map[string]object". Keys are json keys
+	Fields map[string]*Parameter `json:"fields,omitempty"`
 	// GoType is the type the Validator will cast into
 	GoType reflect.Type `json:"-"`
 	// Validator is inferred from the "type" property
 	Validator validator.ValidateFunc `json:"-"`
+	// Checker is the error-returning counterpart of the Validator, it describes
+	// why values are invalid
+	Checker validator.CheckFunc `json:"-"`
+
+	// schema describes the values accepted by the Validator, nil when unknown.
+	// It is used to detect collisions between URI captures
+	schema *validator.Schema
+	// opaque is set when the type is an OpaqueType
+	opaque bool
+}
+
+// DefaultValue returns a copy of the default value, nil when there is none.
+// Slices and maps are copied so that a handler modifying its input cannot
+// alter the default value of the next requests.
+func (param *Parameter) DefaultValue() interface{} {
+	if param.Default == nil {
+		return nil
+	}
+	return copyValue(reflect.ValueOf(param.Default)).Interface()
+}
+
+// copyValue returns a deep copy of slices and maps, other values are returned
+// as is
+func copyValue(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		return copyValue(value.Elem())
+
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		cp := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			cp.Index(i).Set(copyValue(value.Index(i)))
+		}
+		return cp
+
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		cp := reflect.MakeMapWithSize(value.Type(), value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			cp.SetMapIndex(iter.Key(), copyValue(iter.Value()))
+		}
+		return cp
+
+	case reflect.Struct:
+		cp := reflect.New(value.Type()).Elem()
+		cp.Set(value)
+		for i := 0; i < value.NumField(); i++ {
+			if cp.Field(i).CanSet() {
+				cp.Field(i).Set(copyValue(value.Field(i)))
+			}
+		}
+		return cp
+
+	case reflect.Ptr:
+		if value.IsNil() {
+			return value
+		}
+		cp := reflect.New(value.Type().Elem())
+		cp.Elem().Set(copyValue(value.Elem()))
+		return cp
+
+	default:
+		return value
+	}
+}
+
+// Check casts a value like the Validator or returns why it is invalid.
+// It falls back to the Validator when there is no Checker.
+func (param *Parameter) Check(value interface{}) (interface{}, error) {
+	if param.Checker != nil {
+		return param.Checker(value)
+	}
+	cast, valid := param.Validator(value)
+	if !valid {
+		return cast, validator.ErrInvalidValue
+	}
+	return cast, nil
 }
 
 func (param *Parameter) validate(validators ...validator.Type) error {
@@ -33,16 +127,39 @@ func (param *Parameter) validate(validators ...validator.Type) error {
 		param.Type = param.Type[1:]
 	}
 
-	// find validator
-	for _, validator := range validators {
-		param.Validator = validator.Validator(param.Type, validators...)
-		param.GoType = validator.GoType()
-		if param.Validator != nil {
-			break
+	// inline object schema
+	if param.Fields != nil {
+		if !strings.HasSuffix(param.Type, objectTypename) {
+			return ErrUnusedFields
 		}
+		object, err := newObjectType(param.Fields, validators...)
+		if err != nil {
+			return err
+		}
+		validators = append([]validator.Type{object}, validators...)
 	}
+
+	// find validator
+	param.Validator, param.GoType = validator.Resolve(param.Type, validators...)
 	if param.Validator == nil {
+		if reason := validator.Explain(param.Type, validators...); reason != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidParamType, reason)
+		}
 		return ErrUnknownParamType
 	}
+	param.Checker = validator.Check(param.Type, validators...)
+	param.schema = validator.Describe(param.Type, validators...)
+	param.opaque = isOpaque(param.Type, validators...)
+
+	if param.Default != nil {
+		if !param.Optional {
+			return ErrMandatoryDefault
+		}
+		cast, valid := param.Validator(param.Default)
+		if !valid {
+			return ErrInvalidDefault
+		}
+		param.Default = cast
+	}
 	return nil
 }
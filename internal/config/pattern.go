@@ -0,0 +1,56 @@
+package config
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+// disjointPatterns returns whether two patterns cannot match the same string.
+// It is only the case of patterns anchored at the start whose literal prefixes
+// differ, e.g. "^sku-" and "^ref-", other patterns are considered overlapping
+func disjointPatterns(a, b string) bool {
+	aPrefix, ok := anchoredPrefix(a)
+	if !ok {
+		return false
+	}
+	bPrefix, ok := anchoredPrefix(b)
+	if !ok {
+		return false
+	}
+	return !strings.HasPrefix(aPrefix, bPrefix) && !strings.HasPrefix(bPrefix, aPrefix)
+}
+
+// anchoredPrefix returns the literal prefix of every string matching a pattern
+// anchored at the start, it fails when the pattern is not anchored
+func anchoredPrefix(pattern string) (string, bool) {
+	tree, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil || !anchoredStart(tree) {
+		return "", false
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", false
+	}
+	prefix, _ := re.LiteralPrefix()
+	return prefix, true
+}
+
+// anchoredStart returns whether every match of an expression begins at the
+// start of the text
+func anchoredStart(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpBeginText:
+		return true
+	case syntax.OpConcat, syntax.OpCapture:
+		return len(re.Sub) > 0 && anchoredStart(re.Sub[0])
+	case syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			if !anchoredStart(sub) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// positions indexes the offset of every json value of a configuration by its
+// path, e.g. "services/0/in/GET@id". Object members are located at their key.
+type positions struct {
+	raw     []byte
+	offsets map[string]int64
+}
+
+// newPositions indexes the offsets of a valid json document
+func newPositions(raw []byte) *positions {
+	p := &positions{
+		raw:     raw,
+		offsets: make(map[string]int64),
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	// errors are ignored as the document is valid, values are located as long
+	// as possible otherwise
+	_ = p.walk(decoder, "", true)
+	return p
+}
+
+// next returns the offset of the next token
+func (p *positions) next(decoder *json.Decoder) int64 {
+	var offset = decoder.InputOffset()
+	for offset < int64(len(p.raw)) {
+		switch p.raw[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+			continue
+		}
+		break
+	}
+	return offset
+}
+
+// walk the next json value at `path`
+func (p *positions) walk(decoder *json.Decoder, path string, record bool) error {
+	if record {
+		p.offsets[path] = p.next(decoder)
+	}
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		for decoder.More() {
+			var offset = p.next(decoder)
+			key, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			var child = join(path, key.(string))
+			p.offsets[child] = offset
+			if err := p.walk(decoder, child, false); err != nil {
+				return err
+			}
+		}
+		_, err = decoder.Token()
+		return err
+
+	case json.Delim('['):
+		for i := 0; decoder.More(); i++ {
+			if err := p.walk(decoder, join(path, strconv.Itoa(i)), true); err != nil {
+				return err
+			}
+		}
+		_, err = decoder.Token()
+		return err
+	}
+	return nil
+}
+
+// locate returns the line and column of a path, the closest parent is located
+// when the path does not exist. It returns zeros if nothing can be located.
+func (p *positions) locate(path []string) (int, int) {
+	for i := len(path); i > 0; i-- {
+		if offset, ok := p.offsets[strings.Join(path[:i], "/")]; ok {
+			return p.lineColumn(offset)
+		}
+	}
+	return 0, 0
+}
+
+// lineColumn converts an offset into a line and column starting at 1
+func (p *positions) lineColumn(offset int64) (int, int) {
+	if offset > int64(len(p.raw)) {
+		offset = int64(len(p.raw))
+	}
+	var (
+		before  = p.raw[:offset]
+		line    = bytes.Count(before, []byte{'\n'}) + 1
+		lineEnd = bytes.LastIndexByte(before, '\n')
+	)
+	return line, int(offset) - lineEnd
+}
+
+// join path segments
+func join(path, segment string) string {
+	if len(path) < 1 {
+		return segment
+	}
+	return path + "/" + segment
+}
+
+// sortedKeys returns the keys of a parameter map in alphabetical order
+func sortedKeys(params map[string]*Parameter) []string {
+	var keys = make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
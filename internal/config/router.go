@@ -0,0 +1,209 @@
+package config
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/xdrm-io/aicra/validator"
+)
+
+// Router is a precompiled routing tree of services. Every path segment is a
+// level in the tree so that finding a service only depends on the depth of the
+// requested URI and not on the number of services.
+type Router struct {
+	root *node
+}
+
+// node of the routing tree, it features:
+//   - static children indexed by their exact segment value
+//   - capture children ordered by validator, c.f. captureRank(), captures
+//     sharing the same type are merged into the same child so that their
+//     validator is only executed once
+//   - services ending at this node indexed by their http method
+type node struct {
+	static   map[string]*node
+	captures []*captureNode
+	services map[string]*Service
+}
+
+// captureNode is a node reached by validating an uri segment against its type
+type captureNode struct {
+	typename  string
+	validator validator.ValidateFunc
+	rank      int
+	*node
+}
+
+func newNode() *node {
+	return &node{
+		static:   make(map[string]*node),
+		services: make(map[string]*Service),
+	}
+}
+
+// NewRouter builds the routing tree from a list of services. Services must
+// have been validated beforehand.
+func NewRouter(services []*Service) *Router {
+	r := &Router{root: newNode()}
+	for _, service := range services {
+		r.add(service)
+	}
+	return r
+}
+
+// add a service to the tree
+func (r *Router) add(service *Service) {
+	var current = r.root
+
+	for _, part := range SplitURI(service.Pattern) {
+		isCapture := len(part) > 0 && part[0] == '{'
+
+		if !isCapture {
+			child, exists := current.static[part]
+			if !exists {
+				child = newNode()
+				current.static[part] = child
+			}
+			current = child
+			continue
+		}
+
+		var param = service.Input[part]
+		current = current.capture(param)
+	}
+
+	// first service wins in case of duplicates as it used to be when services
+	// were matched in order
+	if _, exists := current.services[service.Method]; !exists {
+		current.services[service.Method] = service
+	}
+}
+
+// capture returns the child capturing a parameter's type, it is created when
+// missing and inserted after the children of the same or a lower rank
+func (n *node) capture(param *Parameter) *node {
+	for _, child := range n.captures {
+		if child.typename == param.Type {
+			return child.node
+		}
+	}
+	child := &captureNode{
+		typename:  param.Type,
+		validator: param.Validator,
+		rank:      captureRank(param.schema),
+		node:      newNode(),
+	}
+	var i = sort.Search(len(n.captures), func(i int) bool {
+		return n.captures[i].rank > child.rank
+	})
+	n.captures = append(n.captures, nil)
+	copy(n.captures[i+1:], n.captures[i:])
+	n.captures[i] = child
+	return child.node
+}
+
+// captureRank orders captures by validator from the most restrictive to the
+// broadest one: enums, numbers and booleans, constrained strings and custom
+// types, strings and finally "any" or composite types. Captures of the same
+// rank keep the order of their first appearance in the configuration.
+func captureRank(schema *validator.Schema) int {
+	switch {
+	case schema == nil:
+		// custom types without description
+		return 2
+	case len(schema.Enum) > 0:
+		return 0
+	case schema.Type == "integer", schema.Type == "number", schema.Type == "boolean":
+		return 1
+	case schema.Type == "string" && (len(schema.Pattern) > 0 || schema.MinLength != nil || schema.MaxLength != nil):
+		return 2
+	case schema.Type == "string":
+		return 3
+	}
+	return 4
+}
+
+// Find a service matching an incoming HTTP request. HEAD requests fall back to
+// the GET service of the same path when no HEAD service is defined.
+func (r *Router) Find(req *http.Request) *Service {
+	return r.Lookup(req.Method, req.URL.Path)
+}
+
+// Lookup the service matching an http method and an uri, it behaves like Find
+func (r *Router) Lookup(method, uri string) *Service {
+	var (
+		parts   = SplitURI(uri)
+		service = r.root.find(method, parts)
+	)
+	if service == nil && method == http.MethodHead {
+		service = r.root.find(http.MethodGet, parts)
+	}
+	return service
+}
+
+// find the service matching a method and the remaining uri segments. Static
+// segments are tried first, then captures ordered by validator: when two
+// captures of different types validate the same segment, the subtree of the
+// most restrictive one is tried first, e.g. "uint" before "string".
+func (n *node) find(method string, parts []string) *Service {
+	if len(parts) == 0 {
+		return n.services[method]
+	}
+
+	var part = parts[0]
+
+	if child, exists := n.static[part]; exists {
+		if service := child.find(method, parts[1:]); service != nil {
+			return service
+		}
+	}
+
+	for _, child := range n.captures {
+		if _, valid := child.validator(part); !valid {
+			continue
+		}
+		if service := child.find(method, parts[1:]); service != nil {
+			return service
+		}
+	}
+	return nil
+}
+
+// Allowed returns the sorted list of http methods that have a service matching
+// the request's path whatever its method. HEAD is included when GET is.
+func (r *Router) Allowed(req *http.Request) []string {
+	var methods = make(map[string]struct{})
+	r.root.collect(SplitURI(req.URL.Path), methods)
+	if _, hasGet := methods[http.MethodGet]; hasGet {
+		methods[http.MethodHead] = struct{}{}
+	}
+
+	var allowed = make([]string, 0, len(methods))
+	for method := range methods {
+		allowed = append(allowed, method)
+	}
+	sort.Strings(allowed)
+	return allowed
+}
+
+// collect the methods of every service matching the remaining uri segments
+func (n *node) collect(parts []string, methods map[string]struct{}) {
+	if len(parts) == 0 {
+		for method := range n.services {
+			methods[method] = struct{}{}
+		}
+		return
+	}
+
+	var part = parts[0]
+
+	if child, exists := n.static[part]; exists {
+		child.collect(parts[1:], methods)
+	}
+
+	for _, child := range n.captures {
+		if _, valid := child.validator(part); valid {
+			child.collect(parts[1:], methods)
+		}
+	}
+}
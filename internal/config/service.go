@@ -12,7 +12,13 @@ import (
 var (
 	captureRegex         = regexp.MustCompile(`^{([A-Za-z_-]+)}$`)
 	queryRegex           = regexp.MustCompile(`^GET@([A-Za-z_-]+)$`)
-	availableHTTPMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete}
+	headerRegex          = regexp.MustCompile(`^HEADER@([A-Za-z0-9_-]+)$`)
+	cookieRegex          = regexp.MustCompile(`^COOKIE@([A-Za-z0-9_-]+)$`)
+	methodRegex          = regexp.MustCompile(`^[A-Z][A-Z_-]*$`)
+	availableHTTPMethods = []string{
+		http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete,
+		http.MethodPatch, http.MethodHead, http.MethodOptions,
+	}
 )
 
 // ScopeVar lists all scope positions that need to be replaced with an uri input
@@ -29,6 +35,7 @@ type Service struct {
 	Description string                `json:"info"`
 	Input       map[string]*Parameter `json:"in"`
 	Output      map[string]*Parameter `json:"out"`
+	CORS        *CORS                 `json:"cors,omitempty"`
 
 	// Captures contains references to URI parameters from the `Input` map.
 	// The format for those parameter names is "{paramName}"
@@ -39,8 +46,18 @@ type Service struct {
 	// names, e.g. "paramName"
 	Query map[string]*Parameter
 
-	// Form references form parameters from the `Input` map (all but Captures
-	// and Query).
+	// Header contains references to HTTP header parameters from the `Input`
+	// map. Header parameters names are "HEADER@Header-Name", this map contains
+	// canonical header names, e.g. "Header-Name"
+	Header map[string]*Parameter
+
+	// Cookie contains references to HTTP cookie parameters from the `Input`
+	// map. Cookie parameters names are "COOKIE@cookieName", this map contains
+	// escaped names, e.g. "cookieName"
+	Cookie map[string]*Parameter
+
+	// Form references form parameters from the `Input` map (all but Captures,
+	// Query, Header and Cookie).
 	Form map[string]*Parameter
 
 	// Pattern uri parts (c.f. SplitURL)
@@ -58,99 +75,60 @@ type BraceCapture struct {
 	Ref   *Parameter
 }
 
-// Match returns if this service would handle this HTTP request
-func (svc *Service) Match(req *http.Request) bool {
-	return req.Method == svc.Method && svc.matchPattern(req.URL.Path)
-}
-
-// checks if an uri matches the service's pattern
-func (svc *Service) matchPattern(uri string) bool {
-	var parts = SplitURI(uri)
-
-	if len(parts) != len(svc.parts) {
-		return false
-	}
-
-	// root url '/'
-	if len(svc.parts) == 0 && len(parts) == 0 {
-		return true
-	}
-
-	// check part by part
-	for i, part := range svc.parts {
-		uripart := parts[i]
-
-		isCapture := len(part) > 0 && part[0] == '{'
-
-		// if no capture -> check equality
-		if !isCapture {
-			if part != uripart {
-				return false
-			}
-			continue
-		}
-
-		param, exists := svc.Input[part]
-
-		// fail if no validator
-		if !exists || param.Validator == nil {
-			return false
-		}
-
-		// fail if not type-valid
-		if _, valid := param.Validator(uripart); !valid {
-			return false
-		}
-	}
-
-	return true
-}
+// validate the service configuration, it returns every error found
+func (svc *Service) validate(methods []string, input []validator.Type, output []validator.Type) Errors {
+	var errs Errors
 
-// validate the service configuration
-func (svc *Service) validate(input []validator.Type, output []validator.Type) error {
-	err := svc.checkMethod()
-	if err != nil {
-		return fmt.Errorf("field 'method': %w", err)
+	if err := svc.checkMethod(methods); err != nil {
+		errs = append(errs, &Error{Field: "method", Err: err})
 	}
 
 	svc.Pattern = strings.Trim(svc.Pattern, " \t\r\n")
-	err = svc.checkPattern()
-	if err != nil {
-		return fmt.Errorf("field 'path': %w", err)
+	if err := svc.checkPattern(); err != nil {
+		errs = append(errs, &Error{Field: "path", Err: err})
 	}
 
 	if len(strings.Trim(svc.Description, " \t\r\n")) < 1 {
-		return fmt.Errorf("field 'description': %w", ErrMissingDescription)
+		errs = append(errs, &Error{Field: "description", Err: ErrMissingDescription})
 	}
 
-	err = svc.checkInput(input)
-	if err != nil {
-		return fmt.Errorf("field 'in': %w", err)
-	}
+	errs = append(errs, svc.checkInput(input)...)
 
 	// fail when a brace capture remains undefined
 	for _, capture := range svc.Captures {
 		if capture.Ref == nil {
-			return fmt.Errorf("field 'in': %s: %w", capture.Name, ErrUndefinedBraceCapture)
+			errs = append(errs, &Error{Field: "in", Param: capture.Name, Err: ErrUndefinedBraceCapture})
 		}
 	}
 
-	err = svc.checkOutput(output)
-	if err != nil {
-		return fmt.Errorf("field 'out': %w", err)
+	errs = append(errs, svc.checkOutput(output)...)
+
+	if svc.CORS != nil {
+		if err := svc.CORS.validate(); err != nil {
+			errs = append(errs, &Error{Field: "cors", Err: err})
+		}
 	}
 
+	if len(errs) > 0 {
+		return errs
+	}
 	svc.cleanScope()
-
 	return nil
 }
 
-func (svc *Service) checkMethod() error {
+// checkMethod checks that the service method is either a standard http method
+// or a custom method made available beforehand
+func (svc *Service) checkMethod(custom []string) error {
 	for _, available := range availableHTTPMethods {
 		if svc.Method == available {
 			return nil
 		}
 	}
+	for _, available := range custom {
+		if svc.Method == available {
+			return nil
+		}
+	}
 	return ErrUnknownMethod
 }
 
@@ -236,29 +214,38 @@ func (svc *Service) checkPattern() error {
 	return nil
 }
 
-func (svc *Service) checkInput(validators []validator.Type) error {
+func (svc *Service) checkInput(validators []validator.Type) Errors {
 	// no parameter
 	if svc.Input == nil || len(svc.Input) < 1 {
 		svc.Input = map[string]*Parameter{}
 		return nil
 	}
 
+	var errs Errors
+	fail := func(name string, err error) {
+		errs = append(errs, &Error{Field: "in", Param: name, Err: err})
+	}
+
 	// for each parameter
-	for name, p := range svc.Input {
+	for _, name := range sortedKeys(svc.Input) {
+		var p = svc.Input[name]
 		if len(name) < 1 {
-			return fmt.Errorf("%s: %w", name, ErrIllegalParamName)
+			fail(name, ErrIllegalParamName)
+			continue
 		}
 
 		// parse parameters: capture (uri), query or form and update the service
 		// attributes accordingly
 		ptype, err := svc.parseParam(name, p)
 		if err != nil {
-			return err
+			fail(name, err)
+			continue
 		}
 
-		// Rename mandatory for capture and query
-		if len(p.Rename) < 1 && (ptype == captureParam || ptype == queryParam) {
-			return fmt.Errorf("%s: %w", name, ErrMandatoryRename)
+		// Rename mandatory for all but form parameters
+		if len(p.Rename) < 1 && ptype != formParam {
+			fail(name, ErrMandatoryRename)
+			continue
 		}
 
 		// fallback to name when Rename is not provided
@@ -268,32 +255,41 @@ func (svc *Service) checkInput(validators []validator.Type) error {
 
 		err = p.validate(validators...)
 		if err != nil {
-			return fmt.Errorf("%s: %w", name, err)
+			fail(name, err)
+			continue
 		}
 
 		// capture parameter cannot be optional
 		if p.Optional && ptype == captureParam {
-			return fmt.Errorf("%s: %w", name, ErrIllegalOptionalURIParam)
+			fail(name, ErrIllegalOptionalURIParam)
+			continue
 		}
 
 		err = nameConflicts(name, p, svc.Input)
 		if err != nil {
-			return err
+			fail(name, err)
 		}
 	}
-	return nil
+	return errs
 }
 
-func (svc *Service) checkOutput(validators []validator.Type) error {
+func (svc *Service) checkOutput(validators []validator.Type) Errors {
 	// no parameter
 	if svc.Output == nil || len(svc.Output) < 1 {
 		svc.Output = make(map[string]*Parameter, 0)
 		return nil
 	}
 
-	for name, p := range svc.Output {
+	var errs Errors
+	fail := func(name string, err error) {
+		errs = append(errs, &Error{Field: "out", Param: name, Err: err})
+	}
+
+	for _, name := range sortedKeys(svc.Output) {
+		var p = svc.Output[name]
 		if len(name) < 1 {
-			return fmt.Errorf("%s: %w", name, ErrIllegalParamName)
+			fail(name, ErrIllegalParamName)
+			continue
 		}
 
 		// fallback to name when Rename is not provided
@@ -301,21 +297,32 @@ func (svc *Service) checkOutput(validators []validator.Type) error {
 			p.Rename = name
 		}
 
+		if p.Default != nil {
+			fail(name, ErrOutputDefault)
+			continue
+		}
+		if p.Fields != nil {
+			fail(name, ErrOutputFields)
+			continue
+		}
+
 		err := p.validate(validators...)
 		if err != nil {
-			return fmt.Errorf("%s: %w", name, err)
+			fail(name, err)
+			continue
 		}
 
 		if p.Optional {
-			return fmt.Errorf("%s: %w", name, ErrOptionalOption)
+			fail(name, ErrOptionalOption)
+			continue
 		}
 
 		err = nameConflicts(name, p, svc.Output)
 		if err != nil {
-			return err
+			fail(name, err)
 		}
 	}
-	return nil
+	return errs
 }
 
 type paramType int
@@ -323,6 +330,8 @@ type paramType int
 const (
 	captureParam paramType = iota
 	queryParam
+	headerParam
+	cookieParam
 	formParam
 )
 
@@ -331,6 +340,8 @@ const (
 //    the pattern definition, e.g. `/some/path/with/{paramName}/somewhere`
 // - `GET@paramName` is an uri query that is received from the http query format
 //    in the uri, e.g. `http://domain.com/uri?paramName=paramValue&param2=value2`
+// - `HEADER@Header-Name` is an http header, e.g. `X-Tenant-ID: value`
+// - `COOKIE@cookieName` is an http cookie, e.g. `Cookie: cookieName=value`
 // - any other name that contains valid characters is considered a Form
 //   parameter; it is extracted from the http request's body as: json, multipart
 //   or using the x-www-form-urlencoded format.
@@ -338,8 +349,9 @@ const (
 // Special notes:
 // - capture params MUST be found in the pattern definition.
 // - capture params MUST NOT be optional as they are in the pattern anyways.
-// - capture and query params MUST be renamed because the `{param}` or
-//   `GET@param` name formats cannot be translated to a valid go exported name.
+// - capture, query, header and cookie params MUST be renamed because the
+//   `{param}`, `GET@param`, `HEADER@param` or `COOKIE@param` name formats
+//   cannot be translated to a valid go exported name.
 //    c.f. the `dynfunc` package that creates a handler func() signature from
 //    the service definitions (i.e. input and output parameters).
 func (svc *Service) parseParam(name string, p *Parameter) (paramType, error) {
@@ -362,7 +374,7 @@ func (svc *Service) parseParam(name string, p *Parameter) (paramType, error) {
 			}
 		}
 		if !found {
-			return captureParam, fmt.Errorf("%s: %w", name, ErrUnspecifiedBraceCapture)
+			return captureParam, ErrUnspecifiedBraceCapture
 		}
 		return captureParam, nil
 	}
@@ -385,6 +397,39 @@ func (svc *Service) parseParam(name string, p *Parameter) (paramType, error) {
 		return queryParam, nil
 	}
 
+	var (
+		headerMatches = headerRegex.FindStringSubmatch(name)
+		cookieMatches = cookieRegex.FindStringSubmatch(name)
+	)
+
+	// Parameter is an http header
+	if len(headerMatches) > 1 {
+		if svc.Header == nil {
+			svc.Header = make(map[string]*Parameter)
+		}
+		// header names are case-insensitive
+		var key = http.CanonicalHeaderKey(headerMatches[1])
+		if _, exists := svc.Header[key]; exists {
+			return headerParam, fmt.Errorf("HEADER@%s: %w", key, ErrParamNameConflict)
+		}
+		svc.Header[key] = p
+		return headerParam, nil
+	}
+
+	// Parameter is an http cookie
+	if len(cookieMatches) > 1 {
+		if svc.Cookie == nil {
+			svc.Cookie = make(map[string]*Parameter)
+		}
+		svc.Cookie[cookieMatches[1]] = p
+		return cookieParam, nil
+	}
+
+	// fail on reserved prefixes that do not match their format
+	if strings.HasPrefix(name, "HEADER@") || strings.HasPrefix(name, "COOKIE@") {
+		return formParam, ErrIllegalParamName
+	}
+
 	// Parameter is a form param
 	if svc.Form == nil {
 		svc.Form = make(map[string]*Parameter)
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"sort"
+
+	"github.com/xdrm-io/aicra/validator"
+)
+
+var typeNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// TypeDef is a named type definition from the "types" section of the
+// configuration. It is either:
+//   - an alias of another typename, e.g. `"username": "string(3,20)"`
+//   - a composite definition, e.g.
+//     `"user": { "type": "object", "fields": { ... } }`
+//
+// Named types can be used in input parameters and in other definitions like
+// any other typename, e.g. "?username" or "[]user".
+type TypeDef struct {
+	Description string                `json:"info,omitempty"`
+	Type        string                `json:"type"`
+	Fields      map[string]*Parameter `json:"fields,omitempty"`
+	// GoType is the resolved go type of the definition
+	GoType reflect.Type `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler to handle aliases
+func (def *TypeDef) UnmarshalJSON(b []byte) error {
+	var alias string
+	if err := json.Unmarshal(b, &alias); err == nil {
+		def.Type = alias
+		return nil
+	}
+
+	// avoid infinite recursion
+	type raw TypeDef
+	return json.Unmarshal(b, (*raw)(def))
+}
+
+// namedType makes a type definition available as a validator.Type. The
+// definition is resolved the first time it is requested so that definitions
+// can refer to each other whatever their order.
+type namedType struct {
+	name string
+	def  *TypeDef
+
+	// param holds the resolved validator and go type
+	param *Parameter
+	// resolving is set while the definition is being resolved, it detects
+	// recursive definitions
+	resolving bool
+	recursive bool
+	err       error
+}
+
+// newNamedTypes validates type definitions and returns the associated types,
+// `validators` being the input validators available. Invalid definitions are
+// not returned.
+func newNamedTypes(defs map[string]*TypeDef, validators []validator.Type) ([]validator.Type, Errors) {
+	var (
+		names = make([]string, 0, len(defs))
+		errs  Errors
+	)
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fail := func(name string, err error) {
+		errs = append(errs, &Error{
+			Field: "types",
+			Param: name,
+			Err:   err,
+			path:  []string{"types", name},
+		})
+	}
+
+	var types = make([]*namedType, 0, len(defs))
+	for _, name := range names {
+		if !typeNameRegex.MatchString(name) {
+			fail(name, ErrInvalidTypeName)
+			continue
+		}
+		// named types cannot shadow available types
+		if validate, _ := validator.Resolve(name, validators...); validate != nil {
+			fail(name, ErrTypeNameConflict)
+			continue
+		}
+		if defs[name] == nil {
+			fail(name, ErrMissingParamType)
+			continue
+		}
+		types = append(types, &namedType{name: name, def: defs[name]})
+	}
+
+	// named types are available along with input validators
+	var avail = make([]validator.Type, 0, len(types)+len(validators))
+	for _, t := range types {
+		avail = append(avail, t)
+	}
+	avail = append(avail, validators...)
+
+	var resolved = make([]validator.Type, 0, len(types))
+	for _, t := range types {
+		if t.Validator(t.name, avail...) == nil {
+			fail(t.name, t.err)
+			continue
+		}
+		resolved = append(resolved, t)
+	}
+	return resolved, errs
+}
+
+// resolve the definition against available types
+func (t *namedType) resolve(avail ...validator.Type) {
+	if len(t.def.Type) > 0 && t.def.Type[0] == '?' {
+		t.err = ErrOptionalTypeDef
+		return
+	}
+
+	var description = t.def.Description
+	if len(description) < 1 {
+		description = t.name
+	}
+	param := &Parameter{
+		Description: description,
+		Type:        t.def.Type,
+		Fields:      t.def.Fields,
+	}
+
+	t.resolving = true
+	err := param.validate(avail...)
+	t.resolving = false
+
+	if t.recursive {
+		t.err = ErrRecursiveType
+		return
+	}
+	if err != nil {
+		t.err = err
+		return
+	}
+	t.param = param
+	t.def.GoType = param.GoType
+}
+
+// GoType implements validator.Type
+func (t *namedType) GoType() reflect.Type {
+	if t.param == nil {
+		return nil
+	}
+	return t.param.GoType
+}
+
+// Validator implements validator.Type
+func (t *namedType) Validator(typename string, avail ...validator.Type) validator.ValidateFunc {
+	if typename != t.name {
+		return nil
+	}
+	if t.resolving {
+		t.recursive = true
+		return nil
+	}
+	if t.param == nil && t.err == nil {
+		t.resolve(avail...)
+	}
+	if t.param == nil {
+		return nil
+	}
+	return t.param.Validator
+}
+
+// Checker implements validator.CheckType
+func (t *namedType) Checker(typename string, avail ...validator.Type) validator.CheckFunc {
+	if t.Validator(typename, avail...) == nil {
+		return nil
+	}
+	return t.param.Check
+}
+
+// Schema implements validator.SchemaType, it describes the definition's type
+func (t *namedType) Schema(typename string, avail ...validator.Type) *validator.Schema {
+	if t.Validator(typename, avail...) == nil {
+		return nil
+	}
+	return t.param.schema
+}
+
+// Opaque implements OpaqueType, definitions of opaque types are opaque
+func (t *namedType) Opaque(typename string) bool {
+	return typename == t.name && t.param != nil && t.param.opaque
+}
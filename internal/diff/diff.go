@@ -0,0 +1,354 @@
+// Package diff compares two versions of an aicra configuration and classifies
+// every change as breaking clients or not
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/xdrm-io/aicra/internal/config"
+)
+
+// Change is a difference between two versions of a configuration
+type Change struct {
+	// Location of the change, e.g. "GET /users/{id}: query 'limit'"
+	Location string
+	Message  string
+	// Breaking is true when clients of the previous version may fail with the
+	// new one
+	Breaking bool
+	// Behavior is true for non-breaking changes that silently alter what
+	// clients of the previous version get, e.g. a changed default value
+	Behavior bool
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s", c.Location, c.Message)
+}
+
+// Kind returns "breaking", "behavior" or "non-breaking"
+func (c Change) Kind() string {
+	switch {
+	case c.Breaking:
+		return "breaking"
+	case c.Behavior:
+		return "behavior"
+	default:
+		return "non-breaking"
+	}
+}
+
+// Changes is the list of changes between two configurations
+type Changes []Change
+
+// Breaking returns the number of breaking changes
+func (c Changes) Breaking() int {
+	var count int
+	for _, change := range c {
+		if change.Breaking {
+			count++
+		}
+	}
+	return count
+}
+
+// direction of parameters compared, it defines which changes break clients
+type direction int
+
+const (
+	// input parameters break clients when they require more
+	input direction = iota
+	// output parameters break clients when they provide less
+	output
+	// shared parameters (named types) can be used both ways, only optional
+	// additions are safe
+	shared
+)
+
+// Compare returns the changes from the previous configuration to the next one.
+// Both configurations must be parsed. Services are identified by their method
+// and path regardless of the name of their captures:
+//   - removed services, removed or renamed output keys, type changes,
+//     mandatory inputs and tightened scopes are breaking
+//   - added services, outputs and optional inputs, removed inputs, inputs
+//     becoming optional and loosened scopes are not
+//   - changed default values are not breaking but flagged as behavior changes
+//
+// Named types are compared conservatively as they can be used by inputs and
+// outputs alike.
+func Compare(prev, next *config.Server) Changes {
+	var d differ
+
+	var previous = make(map[string]*config.Service, len(prev.Services))
+	for _, service := range prev.Services {
+		previous[serviceKey(service)] = service
+	}
+
+	var found = make(map[string]struct{}, len(next.Services))
+	for _, service := range next.Services {
+		var key = serviceKey(service)
+		found[key] = struct{}{}
+		old, exists := previous[key]
+		if !exists {
+			d.add(serviceName(service), "service added", false)
+			continue
+		}
+		d.service(old, service)
+	}
+	for _, service := range prev.Services {
+		if _, exists := found[serviceKey(service)]; !exists {
+			d.add(serviceName(service), "service removed", true)
+		}
+	}
+
+	d.types(prev.Types, next.Types)
+	return d.changes
+}
+
+// differ collects changes
+type differ struct {
+	changes Changes
+}
+
+func (d *differ) add(location, message string, breaking bool) {
+	d.changes = append(d.changes, Change{Location: location, Message: message, Breaking: breaking})
+}
+
+// service compares two versions of the same service
+func (d *differ) service(prev, next *config.Service) {
+	var name = serviceName(next)
+
+	d.scope(name, prev.Scope, next.Scope)
+
+	for i, capture := range next.Captures {
+		if i >= len(prev.Captures) {
+			break
+		}
+		location := fmt.Sprintf("%s: capture '%s'", name, capture.Name)
+		d.param(location, input, prev.Captures[i].Ref, capture.Ref)
+	}
+	d.params(name+": query", input, prev.Query, next.Query)
+	d.params(name+": header", input, prev.Header, next.Header)
+	d.params(name+": cookie", input, prev.Cookie, next.Cookie)
+	d.params(name+": form", input, prev.Form, next.Form)
+
+	d.outputs(name, prev.Output, next.Output)
+}
+
+// scope compares scopes, a scope is tightened when a set of permissions that
+// granted access does not anymore and loosened when a set of permissions that
+// was refused is granted access
+func (d *differ) scope(location string, prev, next [][]string) {
+	if tightened(prev, next) {
+		d.add(location, fmt.Sprintf("scope tightened from %s to %s", formatScope(prev), formatScope(next)), true)
+		return
+	}
+	if tightened(next, prev) {
+		d.add(location, fmt.Sprintf("scope loosened from %s to %s", formatScope(prev), formatScope(next)), false)
+	}
+}
+
+// params compares parameters from the same location by key
+func (d *differ) params(location string, dir direction, prev, next map[string]*config.Parameter) {
+	for _, key := range sortedKeys(next) {
+		var (
+			param   = next[key]
+			old, ok = prev[key]
+			where   = fmt.Sprintf("%s '%s'", location, key)
+		)
+		switch {
+		case ok:
+			d.param(where, dir, old, param)
+		case param.Optional:
+			d.add(where, "optional parameter added", false)
+		case dir == output:
+			d.add(where, "parameter added", false)
+		default:
+			d.add(where, "mandatory parameter added", true)
+		}
+	}
+	for _, key := range sortedKeys(prev) {
+		if _, ok := next[key]; !ok {
+			d.add(fmt.Sprintf("%s '%s'", location, key), "parameter removed", dir != input)
+		}
+	}
+}
+
+// outputs compares output parameters, a removed output key along with an
+// added key renamed the same is reported as a renamed key
+func (d *differ) outputs(location string, prev, next map[string]*config.Parameter) {
+	var (
+		previous = make(map[string]*config.Parameter, len(prev))
+		current  = make(map[string]*config.Parameter, len(next))
+	)
+	for key, param := range prev {
+		previous[key] = param
+	}
+	for key, param := range next {
+		current[key] = param
+	}
+
+	for _, oldKey := range sortedKeys(prev) {
+		if _, kept := next[oldKey]; kept {
+			continue
+		}
+		for _, newKey := range sortedKeys(next) {
+			if _, existed := prev[newKey]; existed || current[newKey] == nil {
+				continue
+			}
+			if next[newKey].Rename != prev[oldKey].Rename {
+				continue
+			}
+			var where = fmt.Sprintf("%s: output '%s'", location, oldKey)
+			d.add(where, fmt.Sprintf("output key renamed to '%s'", newKey), true)
+			d.param(where, output, prev[oldKey], next[newKey])
+			delete(previous, oldKey)
+			delete(current, newKey)
+			break
+		}
+	}
+	d.params(location+": output", output, previous, current)
+}
+
+// param compares two versions of a parameter
+func (d *differ) param(location string, dir direction, prev, next *config.Parameter) {
+	if prev.Type != next.Type {
+		d.add(location, fmt.Sprintf("type changed from '%s' to '%s'", prev.Type, next.Type), true)
+	}
+
+	switch {
+	case prev.Optional && !next.Optional:
+		d.add(location, "parameter became mandatory", dir != output)
+	case !prev.Optional && next.Optional:
+		d.add(location, "parameter became optional", dir != input)
+	}
+
+	// clients omitting the parameter get another value
+	if !reflect.DeepEqual(prev.Default, next.Default) {
+		d.changes = append(d.changes, Change{
+			Location: location,
+			Message:  fmt.Sprintf("default value changed from %s to %s", formatValue(prev.Default), formatValue(next.Default)),
+			Behavior: true,
+		})
+	}
+
+	if prev.Type == next.Type {
+		d.params(location+" field", dir, prev.Fields, next.Fields)
+	}
+}
+
+// types compares named type definitions
+func (d *differ) types(prev, next map[string]*config.TypeDef) {
+	for _, name := range sortedKeys(next) {
+		var (
+			def      = next[name]
+			old, ok  = prev[name]
+			location = fmt.Sprintf("type '%s'", name)
+		)
+		if !ok {
+			d.add(location, "type added", false)
+			continue
+		}
+		if old.Type != def.Type {
+			d.add(location, fmt.Sprintf("type changed from '%s' to '%s'", old.Type, def.Type), true)
+			continue
+		}
+		d.params(location+" field", shared, old.Fields, def.Fields)
+	}
+	for _, name := range sortedKeys(prev) {
+		if _, ok := next[name]; !ok {
+			d.add(fmt.Sprintf("type '%s'", name), "type removed", true)
+		}
+	}
+}
+
+// tightened returns whether a set of permissions granted access by the
+// previous scope is refused by the next scope
+func tightened(prev, next [][]string) bool {
+	for _, granted := range alternatives(prev) {
+		if !grants(next, granted) {
+			return true
+		}
+	}
+	return false
+}
+
+// grants returns whether a scope grants access to a set of permissions
+func grants(scope [][]string, perms []string) bool {
+	var held = make(map[string]struct{}, len(perms))
+	for _, perm := range perms {
+		held[perm] = struct{}{}
+	}
+
+	for _, required := range alternatives(scope) {
+		var granted = true
+		for _, perm := range required {
+			if _, ok := held[perm]; !ok {
+				granted = false
+				break
+			}
+		}
+		if granted {
+			return true
+		}
+	}
+	return false
+}
+
+// alternatives returns the permission sets of a scope, an empty scope is
+// public, i.e. requires no permission
+func alternatives(scope [][]string) [][]string {
+	if len(scope) < 1 {
+		return [][]string{{}}
+	}
+	return scope
+}
+
+// formatScope returns a readable scope, e.g. "[admin] or [user write]"
+func formatScope(scope [][]string) string {
+	if len(scope) < 1 {
+		return "public"
+	}
+	var sets = make([]string, 0, len(scope))
+	for _, perms := range scope {
+		sets = append(sets, "["+strings.Join(perms, " ")+"]")
+	}
+	return strings.Join(sets, " or ")
+}
+
+// formatValue returns a readable default value
+func formatValue(value interface{}) string {
+	if value == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%#v", value)
+}
+
+// serviceKey identifies a service by its method and path regardless of the
+// name of its captures
+func serviceKey(service *config.Service) string {
+	var parts = config.SplitURI(service.Pattern)
+	for i, part := range parts {
+		if strings.HasPrefix(part, "{") {
+			parts[i] = "{}"
+		}
+	}
+	return service.Method + " /" + strings.Join(parts, "/")
+}
+
+// serviceName returns the readable name of a service, e.g. "GET /users/{id}"
+func serviceName(service *config.Service) string {
+	return service.Method + " " + service.Pattern
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[T any](m map[string]T) []string {
+	var keys = make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,212 @@
+package diff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xdrm-io/aicra/internal/config"
+	"github.com/xdrm-io/aicra/validator"
+)
+
+func parse(t *testing.T, conf string) *config.Server {
+	t.Helper()
+	srv := &config.Server{}
+	for _, v := range []validator.Type{
+		validator.BoolType{},
+		validator.IntType{},
+		validator.StringType{},
+		validator.UintType{},
+	} {
+		srv.AddInputValidator(v)
+	}
+	srv.AddOutputValidator("string", reflect.TypeOf(""))
+	srv.AddOutputValidator("uint", reflect.TypeOf(uint(0)))
+	if err := srv.Parse(strings.NewReader(conf)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return srv
+}
+
+// service returns the configuration of a single service
+func service(path, scope, in, out string) string {
+	return `[{ "method": "GET", "path": "` + path + `", "info": "info", "scope": ` + scope +
+		`, "in": {` + in + `}, "out": {` + out + `} }]`
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	const (
+		id    = `"{id}": { "info": "info", "type": "uint", "name": "ID" }`
+		uid   = `"{uid}": { "info": "info", "type": "uint", "name": "ID" }`
+		sid   = `"{id}": { "info": "info", "type": "string", "name": "ID" }`
+		limit = `"GET@limit": { "info": "info", "type": "?int", "name": "Limit" }`
+		mand  = `"GET@limit": { "info": "info", "type": "int", "name": "Limit" }`
+		def   = `"GET@limit": { "info": "info", "type": "?int", "name": "Limit", "default": 10 }`
+		name  = `"name": { "info": "info", "type": "string", "name": "Name" }`
+		full  = `"full-name": { "info": "info", "type": "string", "name": "Name" }`
+	)
+
+	tt := []struct {
+		name   string
+		prev   string
+		next   string
+		expect []string
+	}{
+		{
+			name:   "no change",
+			prev:   service("/user/{id}", `[]`, id, name),
+			next:   service("/user/{uid}", `[]`, uid, name),
+			expect: nil,
+		},
+		{
+			name: "service added and removed",
+			prev: service("/a", `[]`, ``, ``),
+			next: service("/b", `[]`, ``, ``),
+			expect: []string{
+				"non-breaking GET /b: service added",
+				"breaking GET /a: service removed",
+			},
+		},
+		{
+			name:   "capture type changed",
+			prev:   service("/user/{id}", `[]`, id, ``),
+			next:   service("/user/{id}", `[]`, sid, ``),
+			expect: []string{"breaking GET /user/{id}: capture 'id': type changed from 'uint' to 'string'"},
+		},
+		{
+			name:   "optional input added",
+			prev:   service("/", `[]`, ``, ``),
+			next:   service("/", `[]`, limit, ``),
+			expect: []string{"non-breaking GET /: query 'limit': optional parameter added"},
+		},
+		{
+			name:   "mandatory input added",
+			prev:   service("/", `[]`, ``, ``),
+			next:   service("/", `[]`, mand, ``),
+			expect: []string{"breaking GET /: query 'limit': mandatory parameter added"},
+		},
+		{
+			name:   "input removed",
+			prev:   service("/", `[]`, name, ``),
+			next:   service("/", `[]`, ``, ``),
+			expect: []string{"non-breaking GET /: form 'name': parameter removed"},
+		},
+		{
+			name:   "input became mandatory",
+			prev:   service("/", `[]`, limit, ``),
+			next:   service("/", `[]`, mand, ``),
+			expect: []string{"breaking GET /: query 'limit': parameter became mandatory"},
+		},
+		{
+			name:   "input became optional",
+			prev:   service("/", `[]`, mand, ``),
+			next:   service("/", `[]`, limit, ``),
+			expect: []string{"non-breaking GET /: query 'limit': parameter became optional"},
+		},
+		{
+			name:   "default value added",
+			prev:   service("/", `[]`, limit, ``),
+			next:   service("/", `[]`, def, ``),
+			expect: []string{"behavior GET /: query 'limit': default value changed from none to 10"},
+		},
+		{
+			name:   "output added",
+			prev:   service("/", `[]`, ``, ``),
+			next:   service("/", `[]`, ``, name),
+			expect: []string{"non-breaking GET /: output 'name': parameter added"},
+		},
+		{
+			name:   "output removed",
+			prev:   service("/", `[]`, ``, name),
+			next:   service("/", `[]`, ``, ``),
+			expect: []string{"breaking GET /: output 'name': parameter removed"},
+		},
+		{
+			name:   "output key renamed",
+			prev:   service("/", `[]`, ``, name),
+			next:   service("/", `[]`, ``, full),
+			expect: []string{"breaking GET /: output 'name': output key renamed to 'full-name'"},
+		},
+		{
+			name:   "output type changed",
+			prev:   service("/", `[]`, ``, name),
+			next:   service("/", `[]`, ``, `"name": { "info": "info", "type": "uint", "name": "Name" }`),
+			expect: []string{"breaking GET /: output 'name': type changed from 'string' to 'uint'"},
+		},
+		{
+			name:   "scope added",
+			prev:   service("/", `[]`, ``, ``),
+			next:   service("/", `[["admin"]]`, ``, ``),
+			expect: []string{"breaking GET /: scope tightened from public to [admin]"},
+		},
+		{
+			name:   "scope permission added",
+			prev:   service("/", `[["user"]]`, ``, ``),
+			next:   service("/", `[["user", "write"]]`, ``, ``),
+			expect: []string{"breaking GET /: scope tightened from [user] to [user write]"},
+		},
+		{
+			name:   "scope alternative removed",
+			prev:   service("/", `[["admin"], ["user"]]`, ``, ``),
+			next:   service("/", `[["admin"]]`, ``, ``),
+			expect: []string{"breaking GET /: scope tightened from [admin] or [user] to [admin]"},
+		},
+		{
+			name:   "scope alternative added",
+			prev:   service("/", `[["admin"]]`, ``, ``),
+			next:   service("/", `[["admin"], ["user"]]`, ``, ``),
+			expect: []string{"non-breaking GET /: scope loosened from [admin] to [admin] or [user]"},
+		},
+		{
+			name:   "scope reordered",
+			prev:   service("/", `[["a", "b"], ["c"]]`, ``, ``),
+			next:   service("/", `[["c"], ["b", "a"]]`, ``, ``),
+			expect: nil,
+		},
+		{
+			name: "named type field added",
+			prev: `{ "types": { "user": { "type": "object", "fields": {` + name + `} } }, "services": [] }`,
+			next: `{ "types": { "user": { "type": "object", "fields": {` + name + `,` +
+				`"age": { "info": "info", "type": "?uint", "name": "Age" }, "role": { "info": "info", "type": "string", "name": "Role" }` +
+				`} } }, "services": [] }`,
+			expect: []string{
+				"non-breaking type 'user' field 'age': optional parameter added",
+				"breaking type 'user' field 'role': mandatory parameter added",
+			},
+		},
+		{
+			name: "named type changed",
+			prev: `{ "types": { "username": "string" }, "services": [] }`,
+			next: `{ "types": { "username": "string(3,20)" }, "services": [] }`,
+			expect: []string{
+				"breaking type 'username': type changed from 'string' to 'string(3,20)'",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var actual []string
+			for _, change := range Compare(parse(t, tc.prev), parse(t, tc.next)) {
+				actual = append(actual, change.Kind()+" "+change.String())
+			}
+			if !reflect.DeepEqual(actual, tc.expect) {
+				t.Fatalf("invalid changes\nactual: %q\nexpect: %q", actual, tc.expect)
+			}
+		})
+	}
+}
+
+func TestChangesBreaking(t *testing.T) {
+	t.Parallel()
+
+	changes := Changes{{Breaking: true}, {Breaking: false}, {Breaking: true}}
+	if actual := changes.Breaking(); actual != 2 {
+		t.Fatalf("invalid count\nactual: %d\nexpect: %d", actual, 2)
+	}
+}
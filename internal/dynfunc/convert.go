@@ -0,0 +1,144 @@
+package dynfunc
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var stringType = reflect.TypeOf("")
+
+// assignable checks whether values of the configuration type `expect` can be
+// stored into a handler's struct field of type `actual`.
+//
+// Anonymous structs built from inline object schemas are checked field by
+// field so that handlers can use their own struct types, recursively through
+// pointers, slices and maps. Strings can also be stored into defined string
+// types, e.g. generated enums.
+func assignable(expect, actual reflect.Type) error {
+	if expect.AssignableTo(actual) {
+		return nil
+	}
+	if expect == stringType && actual.Kind() == reflect.String {
+		return nil
+	}
+	var invalid = fmt.Errorf("%w (%s instead of %s)", ErrInvalidType, actual, expect)
+	if expect.Kind() != actual.Kind() {
+		return invalid
+	}
+
+	switch expect.Kind() {
+	case reflect.Ptr, reflect.Slice:
+		if err := assignable(expect.Elem(), actual.Elem()); err != nil {
+			return err
+		}
+		return nil
+
+	case reflect.Map:
+		if expect.Key() != actual.Key() {
+			return invalid
+		}
+		if err := assignable(expect.Elem(), actual.Elem()); err != nil {
+			return err
+		}
+		return nil
+
+	case reflect.Struct:
+		if len(expect.Name()) > 0 {
+			return invalid
+		}
+		for i := 0; i < expect.NumField(); i++ {
+			var expectField = expect.Field(i)
+			actualField, exists := actual.FieldByName(expectField.Name)
+			if !exists {
+				return fmt.Errorf("%s: %w", expectField.Name, ErrMissingField)
+			}
+			if err := assignable(expectField.Type, actualField.Type); err != nil {
+				return fmt.Errorf("%s: %w", expectField.Name, err)
+			}
+		}
+		return nil
+	}
+	return invalid
+}
+
+// convert a value into a handler's struct field type, types are expected to
+// have been checked with assignable() beforehand. Non-pointer values are
+// converted into pointers when required. It returns false when the value cannot
+// be converted.
+func convert(value reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	if value.Type().ConvertibleTo(t) {
+		return value.Convert(t), true
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return reflect.Zero(t), true
+			}
+			value = value.Elem()
+		}
+		elem, ok := convert(value, t.Elem())
+		if !ok {
+			return value, false
+		}
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, true
+
+	case reflect.Slice:
+		if value.Kind() != reflect.Slice {
+			break
+		}
+		if value.IsNil() {
+			return reflect.Zero(t), true
+		}
+		slice := reflect.MakeSlice(t, value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			item, ok := convert(value.Index(i), t.Elem())
+			if !ok {
+				return value, false
+			}
+			slice.Index(i).Set(item)
+		}
+		return slice, true
+
+	case reflect.Map:
+		if value.Kind() != reflect.Map {
+			break
+		}
+		if value.IsNil() {
+			return reflect.Zero(t), true
+		}
+		cast := reflect.MakeMapWithSize(t, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			item, ok := convert(iter.Value(), t.Elem())
+			if !ok {
+				return value, false
+			}
+			cast.SetMapIndex(iter.Key(), item)
+		}
+		return cast, true
+
+	case reflect.Struct:
+		if value.Kind() != reflect.Struct {
+			break
+		}
+		cast := reflect.New(t).Elem()
+		for i := 0; i < value.NumField(); i++ {
+			var name = value.Type().Field(i).Name
+			field := cast.FieldByName(name)
+			if !field.IsValid() {
+				continue
+			}
+			item, ok := convert(value.Field(i), field.Type())
+			if !ok {
+				return value, false
+			}
+			field.Set(item)
+		}
+		return cast, true
+	}
+	return value, false
+}
@@ -26,7 +26,9 @@ type Callable func(context.Context, map[string]interface{}) (map[string]interfac
 // configuration, or the argument key if no "name" is provided.
 //
 // Input struct field types must match the associated validator GoType().
-// Optional input arguments must be pointers to the validator's GoType().
+// Inline object schemas are matched field by field against nested structs.
+// Optional input arguments must be pointers to the validator's GoType(), unless
+// they have a default value.
 // Output struct field types must match output types.
 //
 // Special cases:
@@ -50,6 +52,36 @@ func Build[Req, Res any](service *config.Service, fn HandlerFunc[Req, Res]) (Cal
 	return Wrap(signature, fn), nil
 }
 
+// BuildCodec builds a callable from a generic handler like Build but uses the
+// decode and encode functions to convert data instead of reflection. Request and
+// response types are still checked against the service signature.
+//
+// `decode` builds the request from input data indexed by their "name", `encode`
+// returns output data indexed by their "name" from a non-nil response.
+func BuildCodec[Req, Res any](service *config.Service, fn HandlerFunc[Req, Res], decode func(map[string]interface{}) Req, encode func(*Res) map[string]interface{}) (Callable, error) {
+	var signature = NewSignature(service)
+
+	var (
+		treq = reflect.TypeOf((*Req)(nil)).Elem()
+		tres = reflect.TypeOf((*Res)(nil)).Elem()
+	)
+
+	if err := signature.ValidateRequest(treq); err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+	if err := signature.ValidateResponse(tres); err != nil {
+		return nil, fmt.Errorf("response: %w", err)
+	}
+
+	return func(ctx context.Context, in map[string]interface{}) (map[string]interface{}, error) {
+		res, err := fn(ctx, decode(in))
+		if len(signature.Out) == 0 || res == nil {
+			return nil, err
+		}
+		return encode(res), err
+	}, nil
+}
+
 // Wrap a generic handler into a callable function
 func Wrap[Req, Res any](s *Signature, fn HandlerFunc[Req, Res]) Callable {
 	// preprocess indexes to avoid using FieldByName()
@@ -97,29 +129,11 @@ func Wrap[Req, Res any](s *Signature, fn HandlerFunc[Req, Res]) Callable {
 				continue
 			}
 
-			vvalue := reflect.ValueOf(value)
-			tvalue := reflect.TypeOf(value)
-
-			// convert T to pointer of T
-			if field.Kind() == reflect.Ptr {
-				var tPtr = field.Type().Elem()
-				if !tvalue.ConvertibleTo(tPtr) {
-					panic(fmt.Errorf("cannot convert %v into *%v", tvalue, tPtr))
-				}
-
-				ptr := reflect.New(tPtr)
-				ptr.Elem().Set(vvalue.Convert(tPtr))
-				field.Set(ptr)
-				continue
+			cast, ok := convert(reflect.ValueOf(value), field.Type())
+			if !ok {
+				panic(fmt.Errorf("cannot convert %v into %v", reflect.TypeOf(value), field.Type()))
 			}
-
-			// not convertible
-			if !tvalue.ConvertibleTo(field.Type()) {
-				panic(fmt.Errorf("cannot convert %v into %v", tvalue, field.Type()))
-			}
-
-			// non-pointer values
-			field.Set(vvalue.Convert(field.Type()))
+			field.Set(cast)
 		}
 
 		// call the handler
@@ -142,12 +142,15 @@ func TestInput(t *testing.T) {
 		{
 			name: "*int proxy (nil)",
 			conf: (&fakeConfig{}).withArgs(reflect.TypeOf(new(int))),
-			builder: build(func(ctx context.Context, in intptrstruct) (*intptrstruct, error) {
-				return &intptrstruct{P1: in.P1}, nil
+			builder: build(func(ctx context.Context, in intptrstruct) (*intstruct, error) {
+				if in.P1 != nil {
+					return nil, fmt.Errorf("expected nil input")
+				}
+				return &intstruct{}, nil
 			}),
 			hasCtx: false,
 			in:     []interface{}{},
-			out:    []interface{}{nil},
+			out:    []interface{}{0},
 			err:    nil,
 		},
 		{
@@ -175,6 +178,7 @@ func TestInput(t *testing.T) {
 	}
 
 	for _, tc := range tt {
+		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
@@ -224,6 +228,127 @@ func TestInput(t *testing.T) {
 
 }
 
+func TestInputObject(t *testing.T) {
+	t.Parallel()
+
+	type address struct {
+		Zip  *int
+		City string
+	}
+	type req struct {
+		P1 address
+		P2 []address
+		P3 map[string]address
+	}
+	type res struct {
+		P1 string
+		P2 int
+		P3 int
+	}
+
+	var (
+		zip    = 75000
+		object = reflect.New(addressType).Elem()
+	)
+	object.Field(0).SetString("Paris")
+	object.Field(1).Set(reflect.ValueOf(&zip))
+
+	var (
+		slice   = reflect.Append(reflect.MakeSlice(reflect.SliceOf(addressType), 0, 1), object)
+		mapping = reflect.MakeMap(reflect.MapOf(reflect.TypeOf(""), addressType))
+	)
+	mapping.SetMapIndex(reflect.ValueOf("home"), object)
+
+	service := &config.Service{
+		Input: map[string]*config.Parameter{
+			"P1": {Rename: "P1", GoType: addressType},
+			"P2": {Rename: "P2", GoType: slice.Type()},
+			"P3": {Rename: "P3", GoType: mapping.Type()},
+		},
+		Output: map[string]*config.Parameter{
+			"P1": {Rename: "P1", GoType: reflect.TypeOf("")},
+			"P2": {Rename: "P2", GoType: reflect.TypeOf(int(0))},
+			"P3": {Rename: "P3", GoType: reflect.TypeOf(int(0))},
+		},
+	}
+
+	callable, err := dynfunc.Build(service, func(_ context.Context, in req) (*res, error) {
+		if in.P1.Zip == nil || len(in.P2) != 1 || in.P3["home"].Zip == nil {
+			return nil, fmt.Errorf("unexpected input %#v", in)
+		}
+		return &res{P1: in.P1.City, P2: *in.P2[0].Zip, P3: *in.P3["home"].Zip}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	output, err := callable(context.Background(), map[string]interface{}{
+		"P1": object.Interface(),
+		"P2": slice.Interface(),
+		"P3": mapping.Interface(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expect := map[string]interface{}{"P1": "Paris", "P2": zip, "P3": zip}
+	if !reflect.DeepEqual(output, expect) {
+		t.Fatalf("invalid output\nactual: %v\nexpect: %v", output, expect)
+	}
+}
+
+func TestInputStringType(t *testing.T) {
+	t.Parallel()
+
+	type order string
+	type req struct {
+		P1 order
+		P2 *order
+		P3 []order
+		P4 map[string][]order
+	}
+	type res struct {
+		P1 order
+		P3 []order
+	}
+
+	service := &config.Service{
+		Input: map[string]*config.Parameter{
+			"P1": {Rename: "P1", GoType: reflect.TypeOf("")},
+			"P2": {Rename: "P2", GoType: reflect.TypeOf(new(string))},
+			"P3": {Rename: "P3", GoType: reflect.TypeOf([]string{})},
+			"P4": {Rename: "P4", GoType: reflect.TypeOf(map[string][]string{})},
+		},
+		Output: map[string]*config.Parameter{
+			"P1": {Rename: "P1", GoType: reflect.TypeOf("")},
+			"P3": {Rename: "P3", GoType: reflect.TypeOf([]string{})},
+		},
+	}
+
+	callable, err := dynfunc.Build(service, func(_ context.Context, in req) (*res, error) {
+		if in.P2 == nil || *in.P2 != "desc" || len(in.P4["name"]) != 1 {
+			return nil, fmt.Errorf("unexpected input %#v", in)
+		}
+		return &res{P1: in.P1, P3: in.P3}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	output, err := callable(context.Background(), map[string]interface{}{
+		"P1": "asc",
+		"P2": "desc",
+		"P3": []string{"asc", "desc"},
+		"P4": map[string][]string{"name": {"asc"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expect := map[string]interface{}{"P1": order("asc"), "P3": []order{"asc", "desc"}}
+	if !reflect.DeepEqual(output, expect) {
+		t.Fatalf("invalid output\nactual: %v\nexpect: %v", output, expect)
+	}
+}
+
 func TestUnexpectedErrors(t *testing.T) {
 	t.Parallel()
 
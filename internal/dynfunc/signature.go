@@ -8,6 +8,8 @@ import (
 	"github.com/xdrm-io/aicra/internal/config"
 )
 
+var interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+
 // Signature represents input and output arguments for a specific service of the
 // aicra configuration
 type Signature struct {
@@ -26,23 +28,32 @@ func NewSignature(service *config.Service) *Signature {
 		if len(param.Rename) < 1 {
 			continue
 		}
-		// make a pointer if optional
-		if param.Optional {
-			s.In[param.Rename] = reflect.PtrTo(param.GoType)
+		// make a pointer if optional without default value
+		if param.Optional && param.Default == nil {
+			s.In[param.Rename] = reflect.PtrTo(goType(param))
 			continue
 		}
-		s.In[param.Rename] = param.GoType
+		s.In[param.Rename] = goType(param)
 	}
 
 	for _, param := range service.Output {
 		if len(param.Rename) < 1 {
 			continue
 		}
-		s.Out[param.Rename] = param.GoType
+		s.Out[param.Rename] = goType(param)
 	}
 	return s
 }
 
+// goType returns the go type of a parameter ; types without a specific go
+// type (e.g. "any") are considered as interface{}
+func goType(param *config.Parameter) reflect.Type {
+	if param.GoType == nil {
+		return interfaceType
+	}
+	return param.GoType
+}
+
 // ValidateRequest type of a handler against the service signature
 func (s *Signature) ValidateRequest(treq reflect.Type) error {
 	if treq.Kind() != reflect.Struct {
@@ -69,8 +80,8 @@ func (s *Signature) ValidateRequest(treq reflect.Type) error {
 			return fmt.Errorf("%s: %w", name, ErrMissingField)
 		}
 
-		if !tparam.AssignableTo(field.Type) {
-			return fmt.Errorf("%s: %w (%s instead of %s)", name, ErrInvalidType, field.Type, tparam)
+		if err := assignable(tparam, field.Type); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
 		}
 	}
 	return nil
@@ -102,7 +113,9 @@ func (s Signature) ValidateResponse(tres reflect.Type) error {
 			return fmt.Errorf("%s: %w", name, ErrMissingField)
 		}
 
-		if !field.Type.ConvertibleTo(tparam) {
+		// slices and maps of defined string types, e.g. []Order, are not
+		// convertible into slices and maps of strings
+		if !field.Type.ConvertibleTo(tparam) && assignable(tparam, field.Type) != nil {
 			return fmt.Errorf("%s: %w (%s instead of %s)", name, ErrInvalidType, field.Type, tparam)
 		}
 	}
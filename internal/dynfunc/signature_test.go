@@ -26,6 +26,12 @@ func testIn[Req any]() func(s *dynfunc.Signature) error {
 	}
 }
 
+// addressType is the type of an inline object schema as built by the config
+var addressType = reflect.StructOf([]reflect.StructField{
+	{Name: "City", Type: reflect.TypeOf(""), Tag: `json:"city"`},
+	{Name: "Zip", Type: reflect.TypeOf(new(int)), Tag: `json:"zip"`},
+})
+
 func TestRequestValidation(t *testing.T) {
 	t.Parallel()
 
@@ -124,6 +130,38 @@ func TestRequestValidation(t *testing.T) {
 			test: testIn[struct{ Int int }](),
 			err:  dynfunc.ErrInvalidType,
 		},
+		{
+			name: "1 required 1 string type",
+			config: map[string]reflect.Type{
+				"Order": reflect.TypeOf(""),
+			},
+			test: testIn[struct{ Order CustomString }](),
+			err:  nil,
+		},
+		{
+			name: "1 required string type 1 primitive",
+			config: map[string]reflect.Type{
+				"Order": reflect.TypeOf(CustomString("")),
+			},
+			test: testIn[struct{ Order string }](),
+			err:  dynfunc.ErrInvalidType,
+		},
+		{
+			name: "1 required 1 slice of string type",
+			config: map[string]reflect.Type{
+				"Orders": reflect.TypeOf([]string{}),
+			},
+			test: testIn[struct{ Orders []CustomString }](),
+			err:  nil,
+		},
+		{
+			name: "1 required 1 map of string type slices",
+			config: map[string]reflect.Type{
+				"Orders": reflect.TypeOf(map[string][]string{}),
+			},
+			test: testIn[struct{ Orders map[string][]CustomString }](),
+			err:  nil,
+		},
 
 		{
 			name: "1 optional 0 provided",
@@ -189,6 +227,14 @@ func TestRequestValidation(t *testing.T) {
 			test: testIn[struct{ Int *int }](),
 			err:  dynfunc.ErrInvalidType,
 		},
+		{
+			name: "1 optional 1 string type",
+			config: map[string]reflect.Type{
+				"Order": reflect.PointerTo(reflect.TypeOf("")),
+			},
+			test: testIn[struct{ Order *CustomString }](),
+			err:  nil,
+		},
 
 		{
 			name: "N required 1 missing",
@@ -226,6 +272,128 @@ func TestRequestValidation(t *testing.T) {
 			}](),
 			err: nil,
 		},
+		{
+			name: "object ok",
+			config: map[string]reflect.Type{
+				"Address": addressType,
+			},
+			test: testIn[struct {
+				Address struct {
+					Zip   *int
+					City  string
+					Extra bool
+				}
+			}](),
+			err: nil,
+		},
+		{
+			name: "object named struct ok",
+			config: map[string]reflect.Type{
+				"User": reflect.StructOf([]reflect.StructField{
+					{Name: "ID", Type: reflect.TypeOf(int(0))},
+					{Name: "Username", Type: reflect.TypeOf("")},
+				}),
+			},
+			test: testIn[struct{ User User }](),
+			err:  nil,
+		},
+		{
+			name: "object missing field",
+			config: map[string]reflect.Type{
+				"Address": addressType,
+			},
+			test: testIn[struct {
+				Address struct{ City string }
+			}](),
+			err: dynfunc.ErrMissingField,
+		},
+		{
+			name: "object invalid field type",
+			config: map[string]reflect.Type{
+				"Address": addressType,
+			},
+			test: testIn[struct {
+				Address struct {
+					City string
+					Zip  int
+				}
+			}](),
+			err: dynfunc.ErrInvalidType,
+		},
+		{
+			name: "object not a struct",
+			config: map[string]reflect.Type{
+				"Address": addressType,
+			},
+			test: testIn[struct{ Address string }](),
+			err:  dynfunc.ErrInvalidType,
+		},
+		{
+			name: "optional object",
+			config: map[string]reflect.Type{
+				"Address": reflect.PtrTo(addressType),
+			},
+			test: testIn[struct {
+				Address *struct {
+					City string
+					Zip  *int
+				}
+			}](),
+			err: nil,
+		},
+		{
+			name: "slice of objects",
+			config: map[string]reflect.Type{
+				"Addresses": reflect.SliceOf(addressType),
+			},
+			test: testIn[struct {
+				Addresses []struct {
+					City string
+					Zip  *int
+				}
+			}](),
+			err: nil,
+		},
+		{
+			name: "map of objects",
+			config: map[string]reflect.Type{
+				"Addresses": reflect.MapOf(reflect.TypeOf(""), addressType),
+			},
+			test: testIn[struct {
+				Addresses map[string]struct {
+					City string
+					Zip  *int
+				}
+			}](),
+			err: nil,
+		},
+		{
+			name: "map of objects invalid key",
+			config: map[string]reflect.Type{
+				"Addresses": reflect.MapOf(reflect.TypeOf(""), addressType),
+			},
+			test: testIn[struct {
+				Addresses map[int]struct {
+					City string
+					Zip  *int
+				}
+			}](),
+			err: dynfunc.ErrInvalidType,
+		},
+		{
+			name: "named struct type",
+			config: map[string]reflect.Type{
+				"User": reflect.TypeOf(User{}),
+			},
+			test: testIn[struct {
+				User struct {
+					ID       int
+					Username string
+					Email    string
+				}
+			}](),
+			err: nil,
+		},
 	}
 
 	for _, tc := range tt {
@@ -349,6 +517,22 @@ func TestResponseValidation(t *testing.T) {
 			test: testOut[struct{ Int int }](),
 			err:  nil,
 		},
+		{
+			name: "1 required 1 slice of string type",
+			config: map[string]reflect.Type{
+				"Orders": reflect.TypeOf([]string{}),
+			},
+			test: testOut[struct{ Orders []CustomString }](),
+			err:  nil,
+		},
+		{
+			name: "1 required 1 slice of int type",
+			config: map[string]reflect.Type{
+				"IDs": reflect.TypeOf([]int{}),
+			},
+			test: testOut[struct{ IDs []CustomInt }](),
+			err:  dynfunc.ErrInvalidType,
+		},
 
 		{
 			name: "1 optional 0 provided",
@@ -524,6 +708,42 @@ func TestNewSignature(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "optional input with default value",
+			conf: &config.Service{
+				Input: map[string]*config.Parameter{
+					"OptInt":    {GoType: reflect.TypeOf(int(0)), Rename: "OptInt", Optional: true, Default: 20},
+					"OptString": {GoType: reflect.TypeOf(""), Rename: "OptString", Optional: true, Default: "asc"},
+				},
+			},
+			expect: dynfunc.Signature{
+				In: map[string]reflect.Type{
+					"OptInt":    reflect.TypeOf(int(0)),
+					"OptString": reflect.TypeOf(""),
+				},
+			},
+		},
+		{
+			name: "any type as interface",
+			conf: &config.Service{
+				Input: map[string]*config.Parameter{
+					"Any":    {Rename: "Any"},
+					"OptAny": {Rename: "OptAny", Optional: true},
+				},
+				Output: map[string]*config.Parameter{
+					"Any": {Rename: "Any"},
+				},
+			},
+			expect: dynfunc.Signature{
+				In: map[string]reflect.Type{
+					"Any":    reflect.TypeOf((*interface{})(nil)).Elem(),
+					"OptAny": reflect.TypeOf((*interface{})(nil)),
+				},
+				Out: map[string]reflect.Type{
+					"Any": reflect.TypeOf((*interface{})(nil)).Elem(),
+				},
+			},
+		},
 	}
 
 	for _, tc := range tt {
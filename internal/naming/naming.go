@@ -0,0 +1,68 @@
+// Package naming derives go names from configuration names, they are shared by
+// generated code and documentation so that a service has the same name in both
+package naming
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/xdrm-io/aicra/internal/config"
+)
+
+// initialisms are written in upper case in go names
+var initialisms = map[string]string{
+	"api":  "API",
+	"http": "HTTP",
+	"id":   "ID",
+	"ids":  "IDs",
+	"json": "JSON",
+	"uri":  "URI",
+	"url":  "URL",
+	"uuid": "UUID",
+}
+
+// GoName converts a name into an exported go name, e.g. "user-name" into
+// "UserName" or "id" into "ID"
+func GoName(name string) string {
+	var words = strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+	for _, word := range words {
+		if initialism, ok := initialisms[strings.ToLower(word)]; ok {
+			b.WriteString(initialism)
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+
+	var goName = b.String()
+	if len(goName) > 0 && unicode.IsDigit([]rune(goName)[0]) {
+		goName = "N" + goName
+	}
+	return goName
+}
+
+// ServiceName returns the go name of a service from its method and path, e.g.
+// "GET /user/{id}/articles" is named "GetUserByIDArticles"
+func ServiceName(service *config.Service) string {
+	var b strings.Builder
+	b.WriteString(GoName(strings.ToLower(service.Method)))
+
+	var parts = config.SplitURI(service.Pattern)
+	if len(parts) < 1 {
+		b.WriteString("Root")
+	}
+	for _, part := range parts {
+		if len(part) > 1 && part[0] == '{' {
+			b.WriteString("By")
+			b.WriteString(GoName(part[1 : len(part)-1]))
+			continue
+		}
+		b.WriteString(GoName(part))
+	}
+	return b.String()
+}
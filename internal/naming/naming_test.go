@@ -0,0 +1,64 @@
+package naming
+
+import (
+	"testing"
+
+	"github.com/xdrm-io/aicra/internal/config"
+)
+
+func TestGoName(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		expect string
+	}{
+		{"", ""},
+		{"user", "User"},
+		{"user-name", "UserName"},
+		{"user_name", "UserName"},
+		{"userName", "UserName"},
+		{"id", "ID"},
+		{"user-id", "UserID"},
+		{"api.url", "APIURL"},
+		{"2fa", "N2fa"},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if actual := GoName(tc.name); actual != tc.expect {
+				t.Fatalf("invalid name\nactual: %q\nexpect: %q", actual, tc.expect)
+			}
+		})
+	}
+}
+
+func TestServiceName(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		method  string
+		pattern string
+		expect  string
+	}{
+		{"GET", "/", "GetRoot"},
+		{"GET", "/users", "GetUsers"},
+		{"POST", "/users", "PostUsers"},
+		{"GET", "/user/{id}", "GetUserByID"},
+		{"GET", "/user/{id}/articles", "GetUserByIDArticles"},
+		{"DELETE", "/user/{user-id}/article/{article-id}", "DeleteUserByUserIDArticleByArticleID"},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.method+" "+tc.pattern, func(t *testing.T) {
+			t.Parallel()
+			service := &config.Service{Method: tc.method, Pattern: tc.pattern}
+			if actual := ServiceName(service); actual != tc.expect {
+				t.Fatalf("invalid name\nactual: %q\nexpect: %q", actual, tc.expect)
+			}
+		})
+	}
+}
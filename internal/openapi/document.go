@@ -0,0 +1,86 @@
+package openapi
+
+import "github.com/xdrm-io/aicra/validator"
+
+// Version of the OpenAPI specification of generated documents
+const Version = "3.1.0"
+
+// Document is an OpenAPI document, only the parts used to describe an aicra
+// configuration are available
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components *Components          `json:"components,omitempty"`
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem lists the operations of a path
+type PathItem struct {
+	Get     *Operation `json:"get,omitempty"`
+	Put     *Operation `json:"put,omitempty"`
+	Post    *Operation `json:"post,omitempty"`
+	Delete  *Operation `json:"delete,omitempty"`
+	Options *Operation `json:"options,omitempty"`
+	Head    *Operation `json:"head,omitempty"`
+	Patch   *Operation `json:"patch,omitempty"`
+	Trace   *Operation `json:"trace,omitempty"`
+}
+
+// Operation describes a service
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []SecurityRequirement `json:"security,omitempty"`
+}
+
+// Parameter is a path, query, header or cookie parameter
+type Parameter struct {
+	Name        string            `json:"name"`
+	In          string            `json:"in"`
+	Description string            `json:"description,omitempty"`
+	Required    bool              `json:"required,omitempty"`
+	Schema      *validator.Schema `json:"schema"`
+}
+
+// RequestBody describes the form parameters
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response describes a response
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType describes a content type
+type MediaType struct {
+	Schema *validator.Schema `json:"schema"`
+}
+
+// Components defines reusable schemas and security schemes
+type Components struct {
+	Schemas         map[string]*validator.Schema `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme   `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme describes how requests are authenticated
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	Scheme      string `json:"scheme,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// SecurityRequirement lists the required roles for each security scheme
+type SecurityRequirement map[string][]string
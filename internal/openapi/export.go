@@ -0,0 +1,262 @@
+package openapi
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/xdrm-io/aicra/internal/config"
+	"github.com/xdrm-io/aicra/internal/naming"
+	"github.com/xdrm-io/aicra/validator"
+)
+
+// securityScheme is the name of the security scheme of services with a scope,
+// scope permissions are its roles
+const securityScheme = "permissions"
+
+// FormContentTypes are the content types handled for form parameters, in order
+// of preference
+var FormContentTypes = []string{
+	"application/json",
+	"application/x-www-form-urlencoded",
+	"multipart/form-data",
+}
+
+// Export converts a parsed configuration into an OpenAPI document:
+//   - captures are path parameters, "GET@" parameters are query parameters,
+//     "HEADER@" and "COOKIE@" parameters are header and cookie parameters
+//   - form parameters are the request body, in json, urlencoded or multipart
+//   - output parameters are the response body along with the "status" field
+//     of the default responder
+//   - scopes are security requirements whose roles are permissions
+//   - named types are schemas of the document components
+//
+// Services with a custom http method cannot be described and are ignored.
+func Export(srv *config.Server, info Info) *Document {
+	var e = exporter{srv: srv, schemas: make(map[string]*validator.Schema)}
+
+	var doc = &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]*PathItem),
+	}
+
+	var secured bool
+	for _, service := range srv.Services {
+		var item = doc.Paths[service.Pattern]
+		if item == nil {
+			item = &PathItem{}
+		}
+		var op = operation(item, service.Method)
+		if op == nil {
+			continue
+		}
+		doc.Paths[service.Pattern] = item
+		*op = e.operation(service)
+		secured = secured || len((*op).Security) > 0
+	}
+
+	if len(e.schemas) > 0 || secured {
+		doc.Components = &Components{}
+	}
+	if len(e.schemas) > 0 {
+		doc.Components.Schemas = e.schemas
+	}
+	if secured {
+		doc.Components.SecuritySchemes = map[string]*SecurityScheme{
+			securityScheme: {
+				Type:        "http",
+				Scheme:      "bearer",
+				Description: "roles are the permissions required by the service scope",
+			},
+		}
+	}
+	return doc
+}
+
+// operation returns the operation of a path item for a method, nil for custom
+// methods
+func operation(item *PathItem, method string) **Operation {
+	switch method {
+	case http.MethodGet:
+		return &item.Get
+	case http.MethodPut:
+		return &item.Put
+	case http.MethodPost:
+		return &item.Post
+	case http.MethodDelete:
+		return &item.Delete
+	case http.MethodOptions:
+		return &item.Options
+	case http.MethodHead:
+		return &item.Head
+	case http.MethodPatch:
+		return &item.Patch
+	case http.MethodTrace:
+		return &item.Trace
+	}
+	return nil
+}
+
+// exporter keeps track of the named types used as component schemas
+type exporter struct {
+	srv     *config.Server
+	schemas map[string]*validator.Schema
+}
+
+// operation describes a service
+func (e *exporter) operation(service *config.Service) *Operation {
+	var op = &Operation{
+		OperationID: naming.ServiceName(service),
+		Summary:     service.Description,
+		Responses:   make(map[string]*Response, 2),
+	}
+
+	for _, capture := range service.Captures {
+		op.Parameters = append(op.Parameters, e.parameter(capture.Name, "path", capture.Ref))
+	}
+	for _, group := range []struct {
+		in     string
+		params map[string]*config.Parameter
+	}{
+		{"query", service.Query},
+		{"header", service.Header},
+		{"cookie", service.Cookie},
+	} {
+		for _, name := range sortedKeys(group.params) {
+			op.Parameters = append(op.Parameters, e.parameter(name, group.in, group.params[name]))
+		}
+	}
+
+	if len(service.Form) > 0 {
+		var (
+			body   = e.object(service.Form, e.srv.Input)
+			accept = make(map[string]*MediaType, len(FormContentTypes))
+		)
+		for _, contentType := range FormContentTypes {
+			accept[contentType] = &MediaType{Schema: body}
+		}
+		op.RequestBody = &RequestBody{
+			Required: len(body.Required) > 0,
+			Content:  accept,
+		}
+	}
+
+	var output = e.object(service.Output, e.srv.Output)
+	output.Properties["status"] = &validator.Schema{Type: "string"}
+	output.Required = append(output.Required, "status")
+	sort.Strings(output.Required)
+
+	op.Responses["200"] = &Response{
+		Description: "success",
+		Content: map[string]*MediaType{
+			"application/json": {Schema: output},
+		},
+	}
+	op.Responses["default"] = &Response{
+		Description: "error",
+		Content: map[string]*MediaType{
+			"application/json": {Schema: &validator.Schema{
+				Type:       "object",
+				Properties: map[string]*validator.Schema{"status": {Type: "string"}},
+				Required:   []string{"status"},
+			}},
+		},
+	}
+
+	// scope: alternatives of permissions that are all required
+	for _, permissions := range service.Scope {
+		op.Security = append(op.Security, SecurityRequirement{
+			securityScheme: append([]string{}, permissions...),
+		})
+	}
+	return op
+}
+
+// parameter describes a non-form input parameter
+func (e *exporter) parameter(name, in string, param *config.Parameter) *Parameter {
+	var schema = e.param(param, e.srv.Input)
+	schema.Description = ""
+	return &Parameter{
+		Name:        name,
+		In:          in,
+		Description: param.Description,
+		Required:    !param.Optional,
+		Schema:      schema,
+	}
+}
+
+// object describes parameters as an object whose properties are the parameter
+// keys, `avail` are the available types
+func (e *exporter) object(params map[string]*config.Parameter, avail []validator.Type) *validator.Schema {
+	var schema = &validator.Schema{
+		Type:       "object",
+		Properties: make(map[string]*validator.Schema, len(params)),
+	}
+	for _, name := range sortedKeys(params) {
+		var param = params[name]
+		schema.Properties[name] = e.param(param, avail)
+		if !param.Optional {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
+
+// param describes a parameter
+func (e *exporter) param(param *config.Parameter, avail []validator.Type) *validator.Schema {
+	var schema = e.schema(param.Type, param.Fields, avail)
+	if len(schema.Ref) > 0 {
+		return schema
+	}
+	// copy as types may share their schemas
+	var described = *schema
+	described.Description = param.Description
+	described.Default = param.Default
+	return &described
+}
+
+// schema describes a typename, `fields` are those of inline object schemas
+func (e *exporter) schema(typename string, fields map[string]*config.Parameter, avail []validator.Type) *validator.Schema {
+	switch {
+	case strings.HasPrefix(typename, "[]"):
+		return &validator.Schema{
+			Type:  "array",
+			Items: e.schema(typename[2:], fields, avail),
+		}
+
+	case strings.HasPrefix(typename, "map[string]"):
+		return &validator.Schema{
+			Type:                 "object",
+			AdditionalProperties: e.schema(typename[len("map[string]"):], fields, avail),
+		}
+
+	case typename == "object" && fields != nil:
+		return e.object(fields, avail)
+	}
+
+	if def, ok := e.srv.Types[typename]; ok {
+		if _, exists := e.schemas[typename]; !exists {
+			schema := e.schema(def.Type, def.Fields, avail)
+			schema.Description = def.Description
+			e.schemas[typename] = schema
+		}
+		return &validator.Schema{Ref: "#/components/schemas/" + typename}
+	}
+
+	if schema := validator.Describe(typename, avail...); schema != nil {
+		return schema
+	}
+	_, goType := validator.Resolve(typename, avail...)
+	return goTypeSchema(goType)
+}
+
+// sortedKeys returns the keys of parameters sorted alphabetically
+func sortedKeys(params map[string]*config.Parameter) []string {
+	var keys = make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,250 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xdrm-io/aicra/internal/config"
+	"github.com/xdrm-io/aicra/validator"
+)
+
+// describedType is a custom type describing its values
+type describedType struct{}
+
+func (describedType) GoType() reflect.Type {
+	return reflect.TypeOf("")
+}
+func (describedType) Validator(typename string, avail ...validator.Type) validator.ValidateFunc {
+	if typename != "email" {
+		return nil
+	}
+	return func(value interface{}) (interface{}, bool) {
+		s, ok := value.(string)
+		return s, ok
+	}
+}
+func (describedType) Schema(string, ...validator.Type) *validator.Schema {
+	return &validator.Schema{Type: "string", Format: "email"}
+}
+
+const exportConfig = `{
+	"types": {
+		"username": "string",
+		"user": { "info": "a user", "type": "object", "fields": {
+			"name": { "info": "info", "type": "username", "name": "Name" },
+			"aliases": { "info": "info", "type": "?[]username", "name": "Aliases" }
+		} }
+	},
+	"services": [
+		{
+			"method": "PUT",
+			"path": "/user/{id}",
+			"info": "updates a user",
+			"scope": [["admin"], ["user[ID]", "write"]],
+			"in": {
+				"{id}": { "info": "user id", "type": "uint", "name": "ID" },
+				"GET@dry": { "info": "dry run", "type": "?bool", "name": "DryRun" },
+				"HEADER@X-Tenant": { "info": "tenant", "type": "string", "name": "Tenant" },
+				"COOKIE@session": { "info": "session", "type": "?string(8,64)", "name": "Session" },
+				"user": { "info": "new user", "type": "user", "name": "User" },
+				"email": { "info": "new email", "type": "?email", "name": "Email" },
+				"limit": { "info": "limit", "type": "?int", "name": "Limit", "default": 10 },
+				"tags": { "info": "tags", "type": "map[string][]string", "name": "Tags" }
+			},
+			"out": {
+				"id": { "info": "user id", "type": "uint", "name": "ID" }
+			}
+		},
+		{
+			"method": "GET",
+			"path": "/user/{id}",
+			"info": "fetches a user",
+			"in": {
+				"{id}": { "info": "user id", "type": "uint", "name": "ID" }
+			}
+		},
+		{
+			"method": "PURGE",
+			"path": "/cache",
+			"info": "custom method"
+		}
+	]
+}`
+
+func exportTestServer(t *testing.T) *config.Server {
+	t.Helper()
+	srv := &config.Server{}
+	for _, v := range []validator.Type{
+		describedType{},
+		validator.AnyType{},
+		validator.BoolType{},
+		validator.IntType{},
+		validator.StringType{},
+		validator.UintType{},
+		validator.SliceType{},
+		validator.MapType{},
+	} {
+		srv.AddInputValidator(v)
+	}
+	srv.AddOutputValidator("uint", reflect.TypeOf(uint(0)))
+	if err := srv.AddMethod("PURGE"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := srv.Parse(strings.NewReader(exportConfig)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return srv
+}
+
+// toJSON marshals a value for comparisons
+func toJSON(t *testing.T, value interface{}) string {
+	t.Helper()
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return string(encoded)
+}
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	doc := Export(exportTestServer(t), Info{Title: "test", Version: "1.2.3"})
+
+	if doc.OpenAPI != Version {
+		t.Fatalf("invalid version\nactual: %q\nexpect: %q", doc.OpenAPI, Version)
+	}
+	if len(doc.Paths) != 1 {
+		t.Fatalf("custom methods must be ignored, got paths %v", doc.Paths)
+	}
+	item := doc.Paths["/user/{id}"]
+	if item == nil || item.Put == nil || item.Get == nil {
+		t.Fatalf("missing operations for /user/{id}")
+	}
+
+	var put = item.Put
+	if put.OperationID != "PutUserByID" {
+		t.Fatalf("invalid operation id\nactual: %q\nexpect: %q", put.OperationID, "PutUserByID")
+	}
+
+	t.Run("parameters", func(t *testing.T) {
+		expect := `[` +
+			`{"name":"id","in":"path","description":"user id","required":true,"schema":{"type":"integer","minimum":0}},` +
+			`{"name":"dry","in":"query","description":"dry run","schema":{"type":"boolean"}},` +
+			`{"name":"X-Tenant","in":"header","description":"tenant","required":true,"schema":{"type":"string"}},` +
+			`{"name":"session","in":"cookie","description":"session","schema":{"type":"string","minLength":8,"maxLength":64}}` +
+			`]`
+		if actual := toJSON(t, put.Parameters); actual != expect {
+			t.Fatalf("invalid parameters\nactual: %s\nexpect: %s", actual, expect)
+		}
+	})
+
+	t.Run("request body", func(t *testing.T) {
+		if put.RequestBody == nil || !put.RequestBody.Required {
+			t.Fatalf("expected a required request body")
+		}
+		if len(put.RequestBody.Content) != 3 {
+			t.Fatalf("expected json, urlencoded and multipart content types, got %v", put.RequestBody.Content)
+		}
+		expect := `{"type":"object","properties":{` +
+			`"email":{"type":"string","format":"email","description":"new email"},` +
+			`"limit":{"type":"integer","format":"int64","description":"limit","default":10},` +
+			`"tags":{"type":"object","description":"tags","additionalProperties":{"type":"array","items":{"type":"string"}}},` +
+			`"user":{"$ref":"#/components/schemas/user"}` +
+			`},"required":["tags","user"]}`
+		schema := put.RequestBody.Content["application/json"].Schema
+		if actual := toJSON(t, schema); actual != expect {
+			t.Fatalf("invalid request body\nactual: %s\nexpect: %s", actual, expect)
+		}
+	})
+
+	t.Run("response", func(t *testing.T) {
+		expect := `{"type":"object","properties":{` +
+			`"id":{"type":"integer","description":"user id","minimum":0},` +
+			`"status":{"type":"string"}` +
+			`},"required":["id","status"]}`
+		schema := put.Responses["200"].Content["application/json"].Schema
+		if actual := toJSON(t, schema); actual != expect {
+			t.Fatalf("invalid response\nactual: %s\nexpect: %s", actual, expect)
+		}
+		if _, ok := put.Responses["default"]; !ok {
+			t.Fatalf("missing error response")
+		}
+	})
+
+	t.Run("security", func(t *testing.T) {
+		expect := `[{"permissions":["admin"]},{"permissions":["user[ID]","write"]}]`
+		if actual := toJSON(t, put.Security); actual != expect {
+			t.Fatalf("invalid security\nactual: %s\nexpect: %s", actual, expect)
+		}
+		if item.Get.Security != nil {
+			t.Fatalf("services without scope must not have security requirements")
+		}
+		if doc.Components == nil || doc.Components.SecuritySchemes[securityScheme] == nil {
+			t.Fatalf("missing security scheme")
+		}
+	})
+
+	t.Run("components", func(t *testing.T) {
+		expect := `{"user":{"type":"object","description":"a user","properties":{` +
+			`"aliases":{"type":"array","description":"info","items":{"$ref":"#/components/schemas/username"}},` +
+			`"name":{"$ref":"#/components/schemas/username"}` +
+			`},"required":["name"]},` +
+			`"username":{"type":"string"}}`
+		if actual := toJSON(t, doc.Components.Schemas); actual != expect {
+			t.Fatalf("invalid components\nactual: %s\nexpect: %s", actual, expect)
+		}
+	})
+}
+
+func TestGoTypeSchema(t *testing.T) {
+	t.Parallel()
+
+	type nested struct {
+		Name     string `json:"name"`
+		Optional *int   `json:"optional,omitempty"`
+		Ignored  bool   `json:"-"`
+		Raw      []byte
+	}
+
+	tt := []struct {
+		name   string
+		goType reflect.Type
+		expect string
+	}{
+		{"nil", nil, `{}`},
+		{"interface", reflect.TypeOf((*interface{})(nil)).Elem(), `{}`},
+		{"bool", reflect.TypeOf(true), `{"type":"boolean"}`},
+		{"int", reflect.TypeOf(int(0)), `{"type":"integer","format":"int64"}`},
+		{"int32", reflect.TypeOf(int32(0)), `{"type":"integer","format":"int32"}`},
+		{"uint", reflect.TypeOf(uint(0)), `{"type":"integer","minimum":0}`},
+		{"float32", reflect.TypeOf(float32(0)), `{"type":"number","format":"float"}`},
+		{"float64", reflect.TypeOf(float64(0)), `{"type":"number","format":"double"}`},
+		{"string", reflect.TypeOf(""), `{"type":"string"}`},
+		{"bytes", reflect.TypeOf([]byte{}), `{"type":"string","format":"binary"}`},
+		{"slice", reflect.TypeOf([]string{}), `{"type":"array","items":{"type":"string"}}`},
+		{"map", reflect.TypeOf(map[string]bool{}), `{"type":"object","additionalProperties":{"type":"boolean"}}`},
+		{"pointer", reflect.TypeOf(new(string)), `{"type":"string"}`},
+		{
+			"struct",
+			reflect.TypeOf(nested{}),
+			`{"type":"object","properties":{` +
+				`"Raw":{"type":"string","format":"binary"},` +
+				`"name":{"type":"string"},` +
+				`"optional":{"type":"integer","format":"int64"}` +
+				`},"required":["name","Raw"]}`,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if actual := toJSON(t, goTypeSchema(tc.goType)); actual != tc.expect {
+				t.Fatalf("invalid schema\nactual: %s\nexpect: %s", actual, tc.expect)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package importer
+
+// Err allows you to create constant "const" error with type boxing.
+type Err string
+
+func (err Err) Error() string {
+	return string(err)
+}
+
+const (
+	// ErrInvalidDocument - the document is not valid json or yaml
+	ErrInvalidDocument = Err("invalid openapi document")
+
+	// ErrUnsupportedVersion - the document is not an OpenAPI 3 document
+	ErrUnsupportedVersion = Err("unsupported openapi version")
+)
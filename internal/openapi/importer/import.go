@@ -0,0 +1,947 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xdrm-io/aicra/internal/config"
+	"github.com/xdrm-io/aicra/internal/naming"
+	"github.com/xdrm-io/aicra/internal/openapi"
+)
+
+var (
+	// captureNameRegex matches names that can be used as uri captures, query
+	// parameters and header or cookie parameters
+	captureNameRegex = regexp.MustCompile(`^[A-Za-z_-]+$`)
+	headerNameRegex  = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	// invalidTypeChars are replaced in the name of component schemas
+	invalidTypeChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+)
+
+// builtinTypes cannot be used as names of component schemas
+var builtinTypes = map[string]struct{}{
+	"any": {}, "bool": {}, "float": {}, "int": {}, "string": {}, "uint": {}, "object": {},
+}
+
+// Config is an aicra configuration in its object format
+type Config struct {
+	Types    map[string]*config.TypeDef `json:"types,omitempty"`
+	Services []*Service                 `json:"services"`
+}
+
+// Service is a service of an aicra configuration
+type Service struct {
+	Method string                       `json:"method"`
+	Path   string                       `json:"path"`
+	Scope  [][]string                   `json:"scope"`
+	Info   string                       `json:"info"`
+	In     map[string]*config.Parameter `json:"in,omitempty"`
+	Out    map[string]*config.Parameter `json:"out,omitempty"`
+}
+
+// Issue is a construct of an OpenAPI document that cannot be represented in an
+// aicra configuration, the configuration uses the closest representation
+type Issue struct {
+	// Location of the construct, e.g. "GET /users/{id}: query 'limit'"
+	Location string
+	Message  string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s", i.Location, i.Message)
+}
+
+// Import converts an OpenAPI 3 document in json or yaml into an aicra
+// configuration:
+//   - path parameters are uri captures "{name}", query, header and cookie
+//     parameters are "GET@name", "HEADER@name" and "COOKIE@name" parameters
+//   - properties of the json, urlencoded or multipart request body are form
+//     parameters
+//   - properties of the json body of the first successful response are output
+//     parameters
+//   - security requirements are scopes, each scheme being either its roles
+//     or a permission named after the scheme when it has none
+//   - component schemas used by parameters are named types
+//
+// Every parameter is renamed to an exported go name. Schema constraints are
+// mapped onto builtin types, e.g. "string(3,20)". Constructs without an
+// equivalent are listed as issues.
+func Import(data []byte) (*Config, []Issue, error) {
+	src, err := parseSource(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var imp = importer{
+		src:   src,
+		conf:  &Config{Services: make([]*Service, 0)},
+		names: make(map[string]string),
+	}
+	if len(src.Webhooks) > 0 {
+		imp.issue("webhooks", "webhooks are ignored")
+	}
+
+	for _, path := range sortedPaths(src.Paths) {
+		var item = src.Paths[path]
+		if len(item.Ref) > 0 {
+			imp.issue(path, "path item references are not supported")
+			continue
+		}
+		for _, op := range []struct {
+			method string
+			op     *sourceOperation
+		}{
+			{http.MethodGet, item.Get},
+			{http.MethodPut, item.Put},
+			{http.MethodPost, item.Post},
+			{http.MethodDelete, item.Delete},
+			{http.MethodOptions, item.Options},
+			{http.MethodHead, item.Head},
+			{http.MethodPatch, item.Patch},
+			{http.MethodTrace, item.Trace},
+		} {
+			if op.op == nil {
+				continue
+			}
+			service := imp.service(op.method, path, item.Parameters, op.op)
+			if service != nil {
+				imp.conf.Services = append(imp.conf.Services, service)
+			}
+		}
+	}
+	return imp.conf, imp.issues, nil
+}
+
+// importer converts a source document and keeps track of issues and of named
+// types
+type importer struct {
+	src    *source
+	conf   *Config
+	issues []Issue
+	// names of named types indexed by component schema name
+	names map[string]string
+	// component schemas being converted, used to detect recursion
+	converting []string
+}
+
+func (imp *importer) issue(location, format string, args ...interface{}) {
+	imp.issues = append(imp.issues, Issue{Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+// service converts an operation, it returns nil when the operation cannot be
+// represented
+func (imp *importer) service(method, path string, shared []*sourceParameter, op *sourceOperation) *Service {
+	var (
+		location = method + " " + path
+		service  = &Service{
+			Method: method,
+			Path:   path,
+			Scope:  imp.scope(op),
+			Info:   description(op.Summary, op.Description, op.OperationID, location),
+			In:     make(map[string]*config.Parameter),
+			Out:    make(map[string]*config.Parameter),
+		}
+		renames = make(map[string]struct{})
+	)
+
+	// uri captures must be whole segments
+	var captures = make(map[string]bool)
+	for _, segment := range config.SplitURI(path) {
+		if !strings.ContainsAny(segment, "{}") {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(segment, "{"), "}")
+		if segment[0] != '{' || segment[len(segment)-1] != '}' || !captureNameRegex.MatchString(name) {
+			imp.issue(location, "path segment %q cannot be a capture, the operation is ignored", segment)
+			return nil
+		}
+		captures[name] = false
+	}
+
+	if len(op.Callbacks) > 0 {
+		imp.issue(location, "callbacks are ignored")
+	}
+
+	for _, param := range imp.parameters(location, shared, op.Parameters) {
+		key, ok := imp.parameterKey(location, param)
+		if !ok {
+			if param.In == "path" {
+				return nil
+			}
+			continue
+		}
+		var where = location + ": " + param.In + " '" + param.Name + "'"
+		if param.In == "path" {
+			if _, inPath := captures[param.Name]; !inPath {
+				imp.issue(where, "the capture is not in the path, the parameter is ignored")
+				continue
+			}
+			captures[param.Name] = true
+		}
+		converted := imp.param(where, param.Schema, param.Description, param.Name, param.Required || param.In == "path")
+		converted.Rename = uniqueRename(param.Name, renames)
+		service.In[key] = converted
+	}
+
+	// captures must all be defined
+	for _, name := range config.SplitURI(path) {
+		name = strings.TrimSuffix(strings.TrimPrefix(name, "{"), "}")
+		if defined, isCapture := captures[name]; !isCapture || defined {
+			continue
+		}
+		imp.issue(location+": path '"+name+"'", "undefined capture, any string is accepted")
+		service.In["{"+name+"}"] = &config.Parameter{
+			Description: name,
+			Type:        "string",
+			Rename:      uniqueRename(name, renames),
+		}
+		captures[name] = true
+	}
+
+	if body := imp.requestBody(location, op.RequestBody); body != nil {
+		for _, name := range sortedProperties(body.schema.Properties) {
+			var (
+				where    = fmt.Sprintf("%s: body '%s'", location, name)
+				required = body.required && contains(body.schema.Required, name)
+			)
+			// reserved prefixes would turn the property into another kind of
+			// parameter
+			if _, exists := service.In[name]; exists || len(name) < 1 || strings.HasPrefix(name, "{") || strings.Contains(name, "@") {
+				imp.issue(where, "invalid form parameter name, the property is ignored")
+				continue
+			}
+			converted := imp.param(where, body.schema.Properties[name], "", name, required)
+			converted.Rename = uniqueRename(name, renames)
+			service.In[name] = converted
+		}
+	}
+
+	outRenames := make(map[string]struct{})
+	if schema := imp.response(location, op.Responses); schema != nil {
+		for _, name := range sortedProperties(schema.Properties) {
+			// added by the default responder
+			if name == "status" {
+				continue
+			}
+			converted := imp.output(location+": response '"+name+"'", schema.Properties[name], name)
+			converted.Rename = uniqueRename(name, outRenames)
+			service.Out[name] = converted
+		}
+	}
+	return service
+}
+
+// scope converts security requirements into a scope
+func (imp *importer) scope(op *sourceOperation) [][]string {
+	var requirements = imp.src.Security
+	if op.Security != nil {
+		requirements = *op.Security
+	}
+
+	var scope = make([][]string, 0, len(requirements))
+	for _, requirement := range requirements {
+		// anonymous access
+		if len(requirement) < 1 {
+			return [][]string{}
+		}
+		var permissions []string
+		for _, scheme := range sortedScopes(requirement) {
+			if roles := requirement[scheme]; len(roles) > 0 {
+				permissions = append(permissions, roles...)
+				continue
+			}
+			permissions = append(permissions, scheme)
+		}
+		scope = append(scope, permissions)
+	}
+	return scope
+}
+
+// parameters returns the parameters of an operation, those of the operation
+// override those of the path item
+func (imp *importer) parameters(location string, shared, own []*sourceParameter) []*sourceParameter {
+	var (
+		params  []*sourceParameter
+		indexes = make(map[string]int)
+	)
+	for _, param := range append(append([]*sourceParameter{}, shared...), own...) {
+		resolved := imp.resolveParameter(location, param)
+		if resolved == nil {
+			continue
+		}
+		var id = resolved.In + ":" + resolved.Name
+		if i, exists := indexes[id]; exists {
+			params[i] = resolved
+			continue
+		}
+		indexes[id] = len(params)
+		params = append(params, resolved)
+	}
+	return params
+}
+
+// parameterKey returns the key of a parameter in the service input
+func (imp *importer) parameterKey(location string, param *sourceParameter) (string, bool) {
+	var where = location + ": " + param.In + " '" + param.Name + "'"
+	switch param.In {
+	case "path":
+		if !captureNameRegex.MatchString(param.Name) {
+			imp.issue(where, "invalid capture name, the operation is ignored")
+			return "", false
+		}
+		return "{" + param.Name + "}", true
+	case "query":
+		if !captureNameRegex.MatchString(param.Name) {
+			imp.issue(where, "invalid query parameter name, the parameter is ignored")
+			return "", false
+		}
+		return "GET@" + param.Name, true
+	case "header":
+		if !headerNameRegex.MatchString(param.Name) {
+			imp.issue(where, "invalid header name, the parameter is ignored")
+			return "", false
+		}
+		return "HEADER@" + http.CanonicalHeaderKey(param.Name), true
+	case "cookie":
+		if !headerNameRegex.MatchString(param.Name) {
+			imp.issue(where, "invalid cookie name, the parameter is ignored")
+			return "", false
+		}
+		return "COOKIE@" + param.Name, true
+	}
+	imp.issue(where, "unknown parameter location, the parameter is ignored")
+	return "", false
+}
+
+// body is the object schema of a request body
+type body struct {
+	schema   *sourceSchema
+	required bool
+}
+
+// requestBody returns the object schema of a request body, nil when there is
+// none or it cannot be represented
+func (imp *importer) requestBody(location string, reqBody *sourceRequestBody) *body {
+	if reqBody == nil {
+		return nil
+	}
+	if len(reqBody.Ref) > 0 {
+		name, ok := componentName(reqBody.Ref, "requestBodies")
+		if !ok || imp.src.Components.RequestBodies[name] == nil {
+			imp.issue(location, "unresolved request body reference %q", reqBody.Ref)
+			return nil
+		}
+		reqBody = imp.src.Components.RequestBodies[name]
+	}
+
+	for _, contentType := range openapi.FormContentTypes {
+		media, exists := reqBody.Content[contentType]
+		if !exists || media.Schema == nil {
+			continue
+		}
+		schema := imp.resolveSchema(location+": body", media.Schema)
+		if schema == nil || !schema.is("object") || len(schema.Properties) < 1 {
+			imp.issue(location+": body", "only object bodies with properties can be represented, the body is ignored")
+			return nil
+		}
+		return &body{schema: schema, required: reqBody.Required}
+	}
+	if len(reqBody.Content) > 0 {
+		imp.issue(location+": body", "unsupported content types %s, the body is ignored", strings.Join(sortedContent(reqBody.Content), ", "))
+	}
+	return nil
+}
+
+// response returns the json object schema of the first successful response,
+// nil when there is none
+func (imp *importer) response(location string, responses map[string]*sourceResponse) *sourceSchema {
+	var codes []string
+	for code := range responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		var res = responses[code]
+		if len(res.Ref) > 0 {
+			name, ok := componentName(res.Ref, "responses")
+			if !ok || imp.src.Components.Responses[name] == nil {
+				imp.issue(location, "unresolved response reference %q", res.Ref)
+				continue
+			}
+			res = imp.src.Components.Responses[name]
+		}
+		for _, contentType := range sortedContent(res.Content) {
+			if contentType != "application/json" && !strings.HasSuffix(contentType, "+json") {
+				continue
+			}
+			var media = res.Content[contentType]
+			if media.Schema == nil {
+				continue
+			}
+			schema := imp.resolveSchema(location+": response", media.Schema)
+			if schema == nil || !schema.is("object") {
+				imp.issue(location+": response", "only object responses can be represented, the response is ignored")
+				return nil
+			}
+			return schema
+		}
+	}
+	return nil
+}
+
+// param converts an input parameter
+func (imp *importer) param(location string, schema *sourceSchema, desc, name string, required bool) *config.Parameter {
+	var param = &config.Parameter{}
+	if schema == nil {
+		imp.issue(location, "missing schema, any value is accepted")
+		schema = &sourceSchema{}
+	}
+	param.Type, param.Fields = imp.typename(location, schema)
+	param.Description = description(desc, schema.Description, name)
+
+	if required {
+		return param
+	}
+	param.Type = "?" + param.Type
+	if schema.Default != nil {
+		param.Default = schema.Default
+	}
+	return param
+}
+
+// output converts an output parameter, outputs only use builtin types or
+// types registered with Builder.Output()
+func (imp *importer) output(location string, schema *sourceSchema, name string) *config.Parameter {
+	var param = &config.Parameter{Description: description(schema.Description, name)}
+	param.Type = imp.outputTypename(location, schema)
+
+	var base = param.Type
+	for strings.HasPrefix(base, "[]") || strings.HasPrefix(base, "map[string]") {
+		base = strings.TrimPrefix(strings.TrimPrefix(base, "[]"), "map[string]")
+	}
+	if _, builtin := builtinTypes[base]; !builtin || base == "object" {
+		imp.issue(location, "output type %q must be registered with Builder.Output()", param.Type)
+	}
+	return param
+}
+
+// typename returns the input typename of a schema along with the fields of
+// inline object schemas
+func (imp *importer) typename(location string, schema *sourceSchema) (string, map[string]*config.Parameter) {
+	if len(schema.Ref) > 0 {
+		return imp.namedType(location, schema.Ref), nil
+	}
+	schema = imp.combine(location, schema)
+
+	var kind = imp.kind(location, schema)
+	if len(schema.Enum) > 0 {
+		if typename, ok := enumType(kind, schema.Enum); ok {
+			return typename, nil
+		}
+		imp.issue(location, "enum values are not checked")
+	}
+	switch kind {
+	case "string":
+		return imp.stringType(location, schema), nil
+	case "integer":
+		return imp.integerType(location, schema), nil
+	case "number":
+		return imp.numberType(location, schema), nil
+	case "boolean":
+		return "bool", nil
+
+	case "array":
+		if schema.MinItems != nil || schema.MaxItems != nil {
+			imp.issue(location, "array size bounds are not checked")
+		}
+		if schema.Items == nil {
+			return "any", nil
+		}
+		typename, fields := imp.typename(location+"[]", schema.Items)
+		return container("[]", typename), fields
+
+	case "object":
+		if len(schema.Properties) < 1 {
+			if schema.AdditionalProperties == nil || schema.AdditionalProperties.Schema == nil {
+				return "any", nil
+			}
+			typename, fields := imp.typename(location+"{}", schema.AdditionalProperties.Schema)
+			return container("map[string]", typename), fields
+		}
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Allowed {
+			imp.issue(location, "additional properties are ignored")
+		}
+		return "object", imp.fields(location, schema)
+	}
+	return "any", nil
+}
+
+// fields converts the properties of an object schema into object fields
+func (imp *importer) fields(location string, schema *sourceSchema) map[string]*config.Parameter {
+	var (
+		fields  = make(map[string]*config.Parameter, len(schema.Properties))
+		renames = make(map[string]struct{}, len(schema.Properties))
+	)
+	for _, name := range sortedProperties(schema.Properties) {
+		field := imp.param(location+"."+name, schema.Properties[name], "", name, contains(schema.Required, name))
+		field.Rename = uniqueRename(name, renames)
+		fields[name] = field
+	}
+	return fields
+}
+
+// outputTypename returns the output typename of a schema, outputs cannot use
+// named types nor inline objects
+func (imp *importer) outputTypename(location string, schema *sourceSchema) string {
+	if len(schema.Ref) > 0 {
+		name, ok := componentName(schema.Ref, "schemas")
+		if !ok {
+			imp.issue(location, "unresolved schema reference %q", schema.Ref)
+			return "any"
+		}
+		return typeName(name)
+	}
+	schema = imp.combine(location, schema)
+
+	switch imp.kind(location, schema) {
+	case "string":
+		return "string"
+	case "integer":
+		return "int"
+	case "number":
+		return "float"
+	case "boolean":
+		return "bool"
+	case "array":
+		if schema.Items == nil {
+			return "any"
+		}
+		return container("[]", imp.outputTypename(location+"[]", schema.Items))
+	case "object":
+		if len(schema.Properties) < 1 && schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			return container("map[string]", imp.outputTypename(location+"{}", schema.AdditionalProperties.Schema))
+		}
+		if len(schema.Properties) > 0 {
+			return "object"
+		}
+	}
+	return "any"
+}
+
+// combine returns the schema of single element combinations (allOf, oneOf or
+// anyOf), other combinations cannot be represented
+func (imp *importer) combine(location string, schema *sourceSchema) *sourceSchema {
+	for _, list := range [][]*sourceSchema{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		if len(list) == 1 {
+			return imp.resolveSchema(location, list[0])
+		}
+		if len(list) > 1 {
+			imp.issue(location, "schema combinations are not supported, any value is accepted")
+			return &sourceSchema{}
+		}
+	}
+	if schema.Not != nil {
+		imp.issue(location, "'not' schemas are ignored")
+	}
+	return schema
+}
+
+// kind returns the json type of a schema, "null" is not supported
+func (imp *importer) kind(location string, schema *sourceSchema) string {
+	var kinds []string
+	for _, kind := range schema.Type {
+		if kind == "null" {
+			imp.issue(location, "null values are not supported")
+			continue
+		}
+		kinds = append(kinds, kind)
+	}
+	if schema.Nullable {
+		imp.issue(location, "null values are not supported")
+	}
+	if len(kinds) > 1 {
+		imp.issue(location, "multiple types are not supported, any value is accepted")
+		return ""
+	}
+	if len(kinds) < 1 {
+		// untyped schemas with properties are objects
+		if len(schema.Properties) > 0 {
+			return "object"
+		}
+		return ""
+	}
+	return kinds[0]
+}
+
+// enumType returns the enum typename of string enum values, e.g.
+// "enum(asc,desc)". It fails when a value cannot be represented
+func enumType(kind string, enum []interface{}) (string, bool) {
+	if kind != "string" {
+		return "", false
+	}
+	var (
+		values = make([]string, 0, len(enum))
+		unique = make(map[string]struct{}, len(enum))
+	)
+	for _, value := range enum {
+		str, ok := value.(string)
+		if !ok || len(str) < 1 || str != strings.TrimSpace(str) || strings.ContainsAny(str, ",()") {
+			return "", false
+		}
+		// repeated values are allowed by OpenAPI but not by enum typenames
+		if _, exists := unique[str]; exists {
+			continue
+		}
+		unique[str] = struct{}{}
+		values = append(values, str)
+	}
+	return "enum(" + strings.Join(values, ",") + ")", true
+}
+
+// stringType returns the string typename with its pattern or its length bounds,
+// patterns are preferred as they are usually stricter
+func (imp *importer) stringType(location string, schema *sourceSchema) string {
+	if len(schema.Format) > 0 && schema.Format != "binary" {
+		imp.issue(location, "format %q is not checked", schema.Format)
+	}
+
+	var min, max = schema.MinLength, schema.MaxLength
+	if len(schema.Pattern) > 0 {
+		// OpenAPI patterns are ECMA 262 expressions, some features such as
+		// lookarounds or backreferences are not supported
+		if _, err := regexp.Compile(schema.Pattern); err != nil {
+			imp.issue(location, "pattern %q is not checked", schema.Pattern)
+		} else {
+			if min != nil || max != nil {
+				imp.issue(location, "length bounds are not checked along with a pattern")
+			}
+			return "string(/" + schema.Pattern + "/)"
+		}
+	}
+	switch {
+	case min == nil && max == nil:
+		return "string"
+	case max == nil:
+		imp.issue(location, "minimum length without maximum is not checked")
+		return "string"
+	case min != nil && *min == *max:
+		return fmt.Sprintf("string(%d)", *max)
+	case min == nil:
+		return fmt.Sprintf("string(0,%d)", *max)
+	}
+	return fmt.Sprintf("string(%d,%d)", *min, *max)
+}
+
+// integerType returns the int or uint typename, bounds are mapped onto ranges,
+// e.g. "int(1,100)"
+func (imp *importer) integerType(location string, schema *sourceSchema) string {
+	if schema.MultipleOf != nil {
+		imp.issue(location, "multiple of %v is not checked", *schema.MultipleOf)
+	}
+	min, minExclusive, minOk := bound(schema.Minimum, schema.ExclusiveMinimum, true)
+	max, maxExclusive, maxOk := bound(schema.Maximum, schema.ExclusiveMaximum, false)
+	if !minOk || !maxOk {
+		imp.issue(location, "integer bounds are not checked")
+		return "int"
+	}
+
+	// integer bounds are inclusive
+	if min != nil {
+		bound := math.Ceil(*min)
+		if minExclusive && bound == *min {
+			bound++
+		}
+		min = &bound
+	}
+	if max != nil {
+		bound := math.Floor(*max)
+		if maxExclusive && bound == *max {
+			bound--
+		}
+		max = &bound
+	}
+	if (min != nil && math.Abs(*min) > maxSafeInteger) || (max != nil && math.Abs(*max) > maxSafeInteger) {
+		imp.issue(location, "integer bounds are not checked")
+		return "int"
+	}
+
+	switch {
+	case min == nil && max == nil:
+		return "int"
+	case min != nil && *min == 0 && max == nil:
+		return "uint"
+	case min != nil && max != nil && *min > *max:
+		imp.issue(location, "empty integer range is not checked")
+		return "int"
+	case min != nil && *min >= 0:
+		return fmt.Sprintf("uint(%s,%s)", formatBound(min), formatBound(max))
+	}
+	return fmt.Sprintf("int(%s,%s)", formatBound(min), formatBound(max))
+}
+
+// numberType returns the float typename, bounds are mapped onto ranges, e.g.
+// "float(>0,1)"
+func (imp *importer) numberType(location string, schema *sourceSchema) string {
+	if schema.MultipleOf != nil {
+		imp.issue(location, "multiple of %v is not checked", *schema.MultipleOf)
+	}
+	min, minExclusive, minOk := bound(schema.Minimum, schema.ExclusiveMinimum, true)
+	max, maxExclusive, maxOk := bound(schema.Maximum, schema.ExclusiveMaximum, false)
+	if !minOk || !maxOk {
+		imp.issue(location, "number bounds are not checked")
+		return "float"
+	}
+	if min == nil && max == nil {
+		return "float"
+	}
+	if min != nil && max != nil && (*min > *max || (*min == *max && (minExclusive || maxExclusive))) {
+		imp.issue(location, "empty number range is not checked")
+		return "float"
+	}
+
+	var lower, upper = formatBound(min), formatBound(max)
+	if minExclusive {
+		lower = ">" + lower
+	}
+	if maxExclusive {
+		upper = "<" + upper
+	}
+	return fmt.Sprintf("float(%s,%s)", lower, upper)
+}
+
+// maxSafeInteger is the greatest integer bound that is exactly represented by
+// json numbers
+const maxSafeInteger = 1<<53 - 1
+
+// bound returns the lower or upper bound of a schema and whether it is
+// exclusive, nil when there is none. The exclusive keyword is either the bound
+// itself (OpenAPI 3.1) or a boolean that applies to the inclusive bound
+// (OpenAPI 3.0). It fails when the exclusive keyword is invalid
+func bound(inclusive *float64, exclusive json.RawMessage, lower bool) (*float64, bool, bool) {
+	if len(exclusive) < 1 {
+		return inclusive, false, true
+	}
+	var flag bool
+	if err := json.Unmarshal(exclusive, &flag); err == nil {
+		return inclusive, flag && inclusive != nil, true
+	}
+	var value float64
+	if err := json.Unmarshal(exclusive, &value); err != nil {
+		return nil, false, false
+	}
+	// both are set: keep the stricter one
+	if inclusive != nil && ((lower && *inclusive > value) || (!lower && *inclusive < value)) {
+		return inclusive, false, true
+	}
+	return &value, true, true
+}
+
+// formatBound returns the typename representation of a range bound, empty when
+// open
+func formatBound(bound *float64) string {
+	if bound == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*bound, 'f', -1, 64)
+}
+
+// namedType returns the name of the named type of a schema reference, the type
+// is added to the configuration on first use
+func (imp *importer) namedType(location, ref string) string {
+	component, ok := componentName(ref, "schemas")
+	if !ok || imp.src.Components.Schemas[component] == nil {
+		imp.issue(location, "unresolved schema reference %q, any value is accepted", ref)
+		return "any"
+	}
+	if name, exists := imp.names[component]; exists {
+		return name
+	}
+
+	for _, converting := range imp.converting {
+		if converting == component {
+			imp.issue(location, "recursive schema %q is not supported, any value is accepted", component)
+			return "any"
+		}
+	}
+	imp.converting = append(imp.converting, component)
+	defer func() {
+		imp.converting = imp.converting[:len(imp.converting)-1]
+	}()
+
+	var (
+		schema = imp.src.Components.Schemas[component]
+		where  = "components.schemas." + component
+	)
+	typename, fields := imp.typename(where, schema)
+
+	var name = typeName(component)
+	for i := 2; imp.taken(name); i++ {
+		name = typeName(component) + strconv.Itoa(i)
+	}
+	if imp.conf.Types == nil {
+		imp.conf.Types = make(map[string]*config.TypeDef)
+	}
+	imp.conf.Types[name] = &config.TypeDef{
+		Description: schema.Description,
+		Type:        typename,
+		Fields:      fields,
+	}
+	imp.names[component] = name
+	return name
+}
+
+// taken returns whether a type name is already used
+func (imp *importer) taken(name string) bool {
+	_, exists := imp.conf.Types[name]
+	return exists
+}
+
+// resolveSchema returns the schema referenced by a schema, if any
+func (imp *importer) resolveSchema(location string, schema *sourceSchema) *sourceSchema {
+	for i := 0; len(schema.Ref) > 0; i++ {
+		name, ok := componentName(schema.Ref, "schemas")
+		if !ok || imp.src.Components.Schemas[name] == nil || i > len(imp.src.Components.Schemas) {
+			imp.issue(location, "unresolved schema reference %q", schema.Ref)
+			return nil
+		}
+		schema = imp.src.Components.Schemas[name]
+	}
+	return schema
+}
+
+// resolveParameter returns the parameter referenced by a parameter, if any
+func (imp *importer) resolveParameter(location string, param *sourceParameter) *sourceParameter {
+	if len(param.Ref) < 1 {
+		return param
+	}
+	name, ok := componentName(param.Ref, "parameters")
+	if !ok || imp.src.Components.Parameters[name] == nil {
+		imp.issue(location, "unresolved parameter reference %q, the parameter is ignored", param.Ref)
+		return nil
+	}
+	return imp.src.Components.Parameters[name]
+}
+
+// is returns whether a schema has a json type
+func (s *sourceSchema) is(kind string) bool {
+	for _, t := range s.Type {
+		if t == kind {
+			return true
+		}
+	}
+	return len(s.Type) < 1 && kind == "object" && len(s.Properties) > 0
+}
+
+// container returns the typename of a slice or map of items, containers of
+// any items are "any" as they have no go type
+func container(prefix, item string) string {
+	if item == "any" {
+		return item
+	}
+	return prefix + item
+}
+
+// componentName returns the name of a local component reference, e.g.
+// "#/components/schemas/User"
+func componentName(ref, section string) (string, bool) {
+	var prefix = "#/components/" + section + "/"
+	if !strings.HasPrefix(ref, prefix) {
+		return "", false
+	}
+	// json pointer escapes
+	name := strings.NewReplacer("~1", "/", "~0", "~").Replace(ref[len(prefix):])
+	return name, len(name) > 0
+}
+
+// typeName returns a valid named type name from a component name
+func typeName(component string) string {
+	var name = invalidTypeChars.ReplaceAllString(component, "_")
+	if len(name) < 1 || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	if _, builtin := builtinTypes[name]; builtin {
+		name = name + "_"
+	}
+	return name
+}
+
+// uniqueRename returns a unique exported go name for a parameter
+func uniqueRename(name string, used map[string]struct{}) string {
+	var rename = naming.GoName(name)
+	if len(rename) < 1 {
+		rename = "Param"
+	}
+	var unique = rename
+	for i := 2; ; i++ {
+		if _, exists := used[unique]; !exists {
+			break
+		}
+		unique = rename + strconv.Itoa(i)
+	}
+	used[unique] = struct{}{}
+	return unique
+}
+
+// description returns the first non-empty description
+func description(candidates ...string) string {
+	for _, candidate := range candidates {
+		if trimmed := strings.TrimSpace(candidate); len(trimmed) > 0 {
+			return trimmed
+		}
+	}
+	return ""
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedPaths(paths map[string]*sourcePathItem) []string {
+	var keys = make([]string, 0, len(paths))
+	for key := range paths {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedProperties(properties map[string]*sourceSchema) []string {
+	var keys = make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedContent(content map[string]*sourceMediaType) []string {
+	var keys = make([]string, 0, len(content))
+	for key := range content {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedScopes(requirement map[string][]string) []string {
+	var keys = make([]string, 0, len(requirement))
+	for key := range requirement {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,366 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xdrm-io/aicra/internal/config"
+	"github.com/xdrm-io/aicra/validator"
+)
+
+const importYAML = `
+openapi: 3.0.3
+info: { title: pets, version: "1" }
+security:
+  - bearer: []
+paths:
+  /pets/{petId}:
+    parameters:
+      - $ref: '#/components/parameters/PetID'
+    get:
+      summary: fetches a pet
+      parameters:
+        - name: verbose
+          in: query
+          schema: { type: boolean }
+        - name: x-request-id
+          in: header
+          required: true
+          schema: { type: string, minLength: 8, maxLength: 64 }
+      responses:
+        200:
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  status: { type: string }
+                  name: { type: string }
+                  tags: { type: array, items: { type: string } }
+    put:
+      security: [{}]
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: { $ref: '#/components/schemas/Pet' }
+      responses:
+        "204": { description: none }
+  /pets:
+    post:
+      operationId: createPet
+      security:
+        - oauth: [write, admin]
+        - key: []
+      requestBody:
+        content:
+          application/x-www-form-urlencoded:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name: { type: string, maxLength: 20, pattern: "^[a-z]+$" }
+                owner: { $ref: '#/components/schemas/Owner' }
+      responses:
+        "201": { description: created }
+components:
+  parameters:
+    PetID:
+      name: petId
+      in: path
+      required: true
+      schema: { type: integer, minimum: 0 }
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name: { type: string }
+        age: { type: integer, default: 1 }
+    Owner:
+      description: an owner
+      type: object
+      properties:
+        name: { type: string, nullable: true }
+        pets: { type: array, items: { $ref: '#/components/schemas/Owner' } }
+`
+
+// importTestServer returns a server with the builtin types to validate
+// imported configurations
+func importTestServer() *config.Server {
+	srv := &config.Server{}
+	for _, v := range []validator.Type{
+		validator.AnyType{},
+		validator.BoolType{},
+		validator.FloatType{},
+		validator.IntType{},
+		validator.StringType{},
+		validator.UintType{},
+		validator.EnumType{},
+		validator.SliceType{},
+		validator.MapType{},
+	} {
+		srv.AddInputValidator(v)
+	}
+	srv.Output = srv.Input
+	return srv
+}
+
+func toJSON(t *testing.T, value interface{}) string {
+	t.Helper()
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return string(encoded)
+}
+
+func TestImport(t *testing.T) {
+	t.Parallel()
+
+	conf, issues, err := Import([]byte(importYAML))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// the imported configuration must be valid
+	srv := importTestServer()
+	if err := srv.Parse(strings.NewReader(toJSON(t, conf))); err != nil {
+		t.Fatalf("invalid configuration: %s", err)
+	}
+
+	tt := []struct {
+		name   string
+		actual interface{}
+		expect string
+	}{
+		{
+			name:   "types",
+			actual: conf.Types,
+			expect: `{"Owner":{"info":"an owner","type":"object","fields":{` +
+				`"name":{"info":"name","type":"?string","name":"Name"},` +
+				`"pets":{"info":"pets","type":"?any","name":"Pets"}` +
+				`}}}`,
+		},
+		{
+			name:   "parameters",
+			actual: conf.Services[1],
+			expect: `{"method":"GET","path":"/pets/{petId}","scope":[["bearer"]],"info":"fetches a pet",` +
+				`"in":{` +
+				`"GET@verbose":{"info":"verbose","type":"?bool","name":"Verbose"},` +
+				`"HEADER@X-Request-Id":{"info":"x-request-id","type":"string(8,64)","name":"XRequestID"},` +
+				`"{petId}":{"info":"petId","type":"uint","name":"PetId"}` +
+				`},"out":{` +
+				`"name":{"info":"name","type":"string","name":"Name"},` +
+				`"tags":{"info":"tags","type":"[]string","name":"Tags"}` +
+				`}}`,
+		},
+		{
+			name:   "body",
+			actual: conf.Services[2],
+			expect: `{"method":"PUT","path":"/pets/{petId}","scope":[],"info":"PUT /pets/{petId}",` +
+				`"in":{` +
+				`"age":{"info":"age","type":"?int","name":"Age","default":1},` +
+				`"name":{"info":"name","type":"string","name":"Name"},` +
+				`"{petId}":{"info":"petId","type":"uint","name":"PetId"}` +
+				`}}`,
+		},
+		{
+			name:   "optional body",
+			actual: conf.Services[0],
+			expect: `{"method":"POST","path":"/pets","scope":[["write","admin"],["key"]],"info":"createPet",` +
+				`"in":{` +
+				`"name":{"info":"name","type":"?string(/^[a-z]+$/)","name":"Name"},` +
+				`"owner":{"info":"owner","type":"?Owner","name":"Owner"}` +
+				`}}`,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if actual := toJSON(t, tc.actual); actual != tc.expect {
+				t.Fatalf("invalid import\nactual: %s\nexpect: %s", actual, tc.expect)
+			}
+		})
+	}
+
+	t.Run("issues", func(t *testing.T) {
+		t.Parallel()
+		expect := []string{
+			`POST /pets: body 'name': length bounds are not checked along with a pattern`,
+			`components.schemas.Owner.name: null values are not supported`,
+			`components.schemas.Owner.pets[]: recursive schema "Owner" is not supported, any value is accepted`,
+		}
+		if len(issues) != len(expect) {
+			t.Fatalf("invalid issues\nactual: %v\nexpect: %v", issues, expect)
+		}
+		for i, issue := range issues {
+			if issue.String() != expect[i] {
+				t.Fatalf("invalid issue %d\nactual: %s\nexpect: %s", i, issue, expect[i])
+			}
+		}
+	})
+}
+
+func TestImportJSON(t *testing.T) {
+	t.Parallel()
+
+	const doc = `{
+		"openapi": "3.1.0",
+		"paths": {
+			"/items/{id}/name": {
+				"get": {
+					"parameters": [
+						{ "name": "id", "in": "path", "required": true, "schema": { "type": "string" } }
+					],
+					"responses": {
+						"200": { "description": "ok", "content": { "application/json": { "schema": {
+							"type": "object",
+							"properties": { "item": { "$ref": "#/components/schemas/Item" } }
+						} } } }
+					}
+				}
+			},
+			"/items/{id}.json": { "get": { "responses": {} } },
+			"/users/{user}": { "delete": { "responses": {} } }
+		},
+		"components": { "schemas": {
+			"Item": { "type": "object", "properties": { "name": { "type": ["string", "null"] } } }
+		} }
+	}`
+
+	conf, issues, err := Import([]byte(doc))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(conf.Services) != 2 {
+		t.Fatalf("expected 2 services, got %d", len(conf.Services))
+	}
+
+	expect := `{"method":"GET","path":"/items/{id}/name","scope":[],"info":"GET /items/{id}/name",` +
+		`"in":{"{id}":{"info":"id","type":"string","name":"ID"}},` +
+		`"out":{"item":{"info":"item","type":"Item","name":"Item"}}}`
+	if actual := toJSON(t, conf.Services[0]); actual != expect {
+		t.Fatalf("invalid service\nactual: %s\nexpect: %s", actual, expect)
+	}
+	expect = `{"method":"DELETE","path":"/users/{user}","scope":[],"info":"DELETE /users/{user}",` +
+		`"in":{"{user}":{"info":"user","type":"string","name":"User"}}}`
+	if actual := toJSON(t, conf.Services[1]); actual != expect {
+		t.Fatalf("invalid undefined capture\nactual: %s\nexpect: %s", actual, expect)
+	}
+
+	expectIssues := []string{
+		`GET /items/{id}.json: path segment "{id}.json" cannot be a capture, the operation is ignored`,
+		`GET /items/{id}/name: response 'item': output type "Item" must be registered with Builder.Output()`,
+		`DELETE /users/{user}: path 'user': undefined capture, any string is accepted`,
+	}
+	if len(issues) != len(expectIssues) {
+		t.Fatalf("invalid issues\nactual: %v\nexpect: %v", issues, expectIssues)
+	}
+	for i, issue := range issues {
+		if issue.String() != expectIssues[i] {
+			t.Fatalf("invalid issue %d\nactual: %s\nexpect: %s", i, issue, expectIssues[i])
+		}
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		doc  string
+		err  error
+	}{
+		{"invalid document", "openapi: [", ErrInvalidDocument},
+		{"invalid json", `{"openapi": 3}`, ErrInvalidDocument},
+		{"swagger", `{"swagger": "2.0", "paths": {}}`, ErrUnsupportedVersion},
+		{"missing version", `paths: {}`, ErrUnsupportedVersion},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, _, err := Import([]byte(tc.doc))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestImportBounds(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		schema   string
+		typename string
+		issue    string
+	}{
+		{`{"type": "integer"}`, "int", ""},
+		{`{"type": "integer", "minimum": 0}`, "uint", ""},
+		{`{"type": "integer", "minimum": 1, "maximum": 100}`, "uint(1,100)", ""},
+		{`{"type": "integer", "minimum": -10}`, "int(-10,)", ""},
+		{`{"type": "integer", "maximum": 10}`, "int(,10)", ""},
+		{`{"type": "integer", "minimum": 0, "exclusiveMinimum": true}`, "uint(1,)", ""},
+		{`{"type": "integer", "exclusiveMinimum": 0, "exclusiveMaximum": 10}`, "uint(1,9)", ""},
+		{`{"type": "integer", "minimum": 0.5, "maximum": 2.5}`, "uint(1,2)", ""},
+		{`{"type": "integer", "minimum": 5, "exclusiveMinimum": 2}`, "uint(5,)", ""},
+		{`{"type": "integer", "minimum": 2, "exclusiveMaximum": 2}`, "int", "empty integer range is not checked"},
+		{`{"type": "integer", "maximum": 1e20}`, "int", "integer bounds are not checked"},
+		{`{"type": "integer", "exclusiveMinimum": "a"}`, "int", "integer bounds are not checked"},
+		{`{"type": "integer", "multipleOf": 2}`, "int", "multiple of 2 is not checked"},
+		{`{"type": "number"}`, "float", ""},
+		{`{"type": "number", "minimum": 0, "maximum": 1}`, "float(0,1)", ""},
+		{`{"type": "number", "minimum": 0, "exclusiveMinimum": true, "maximum": 1.5, "exclusiveMaximum": true}`, "float(>0,<1.5)", ""},
+		{`{"type": "number", "exclusiveMinimum": -0.5}`, "float(>-0.5,)", ""},
+		{`{"type": "number", "minimum": 1, "exclusiveMaximum": 1}`, "float", "empty number range is not checked"},
+		{`{"type": "string", "pattern": "^[a-z0-9-]+$"}`, "string(/^[a-z0-9-]+$/)", ""},
+		{`{"type": "string", "pattern": "^(?!new)"}`, "string", `pattern "^(?!new)" is not checked`},
+		{`{"type": "string", "enum": ["asc", "desc"]}`, "enum(asc,desc)", ""},
+		{`{"type": "string", "enum": ["en-US", "fr-FR", "en-US"]}`, "enum(en-US,fr-FR)", ""},
+		{`{"type": "string", "enum": ["a,b", "c"]}`, "string", "enum values are not checked"},
+		{`{"type": "string", "enum": [" a", "b"]}`, "string", "enum values are not checked"},
+		{`{"type": "integer", "enum": [1, 2]}`, "int", "enum values are not checked"},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.schema, func(t *testing.T) {
+			t.Parallel()
+
+			var schema sourceSchema
+			if err := json.Unmarshal([]byte(tc.schema), &schema); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			imp := importer{}
+			typename, _ := imp.typename("x", &schema)
+			if typename != tc.typename {
+				t.Fatalf("invalid typename\nactual: %s\nexpect: %s", typename, tc.typename)
+			}
+
+			var issue string
+			if len(imp.issues) > 0 {
+				issue = imp.issues[0].Message
+			}
+			if issue != tc.issue {
+				t.Fatalf("invalid issue\nactual: %q\nexpect: %q", issue, tc.issue)
+			}
+			if len(tc.issue) > 0 {
+				return
+			}
+
+			// the typename must be handled by the builtin types
+			srv := importTestServer()
+			if validate, _ := validator.Resolve(typename, srv.Input...); validate == nil {
+				t.Fatalf("typename %q is not handled", typename)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// source is an OpenAPI 3 document to import, fields that have no equivalent
+// in aicra configurations are omitted
+type source struct {
+	OpenAPI    string                     `json:"openapi"`
+	Paths      map[string]*sourcePathItem `json:"paths"`
+	Components sourceComponents           `json:"components"`
+	Security   []map[string][]string      `json:"security"`
+	Webhooks   map[string]json.RawMessage `json:"webhooks"`
+}
+
+type sourceComponents struct {
+	Schemas       map[string]*sourceSchema      `json:"schemas"`
+	Parameters    map[string]*sourceParameter   `json:"parameters"`
+	RequestBodies map[string]*sourceRequestBody `json:"requestBodies"`
+	Responses     map[string]*sourceResponse    `json:"responses"`
+}
+
+type sourcePathItem struct {
+	Ref        string             `json:"$ref"`
+	Parameters []*sourceParameter `json:"parameters"`
+	Get        *sourceOperation   `json:"get"`
+	Put        *sourceOperation   `json:"put"`
+	Post       *sourceOperation   `json:"post"`
+	Delete     *sourceOperation   `json:"delete"`
+	Options    *sourceOperation   `json:"options"`
+	Head       *sourceOperation   `json:"head"`
+	Patch      *sourceOperation   `json:"patch"`
+	Trace      *sourceOperation   `json:"trace"`
+}
+
+type sourceOperation struct {
+	OperationID string                     `json:"operationId"`
+	Summary     string                     `json:"summary"`
+	Description string                     `json:"description"`
+	Parameters  []*sourceParameter         `json:"parameters"`
+	RequestBody *sourceRequestBody         `json:"requestBody"`
+	Responses   map[string]*sourceResponse `json:"responses"`
+	Security    *[]map[string][]string     `json:"security"`
+	Callbacks   map[string]json.RawMessage `json:"callbacks"`
+}
+
+type sourceParameter struct {
+	Ref         string        `json:"$ref"`
+	Name        string        `json:"name"`
+	In          string        `json:"in"`
+	Description string        `json:"description"`
+	Required    bool          `json:"required"`
+	Schema      *sourceSchema `json:"schema"`
+}
+
+type sourceRequestBody struct {
+	Ref         string                      `json:"$ref"`
+	Description string                      `json:"description"`
+	Required    bool                        `json:"required"`
+	Content     map[string]*sourceMediaType `json:"content"`
+}
+
+type sourceResponse struct {
+	Ref         string                      `json:"$ref"`
+	Description string                      `json:"description"`
+	Content     map[string]*sourceMediaType `json:"content"`
+}
+
+type sourceMediaType struct {
+	Schema *sourceSchema `json:"schema"`
+}
+
+type sourceSchema struct {
+	Ref         string        `json:"$ref"`
+	Type        typeList      `json:"type"`
+	Nullable    bool          `json:"nullable"`
+	Format      string        `json:"format"`
+	Description string        `json:"description"`
+	Default     interface{}   `json:"default"`
+	Enum        []interface{} `json:"enum"`
+
+	Minimum          *float64        `json:"minimum"`
+	Maximum          *float64        `json:"maximum"`
+	ExclusiveMinimum json.RawMessage `json:"exclusiveMinimum"`
+	ExclusiveMaximum json.RawMessage `json:"exclusiveMaximum"`
+	MultipleOf       *float64        `json:"multipleOf"`
+
+	MinLength *int   `json:"minLength"`
+	MaxLength *int   `json:"maxLength"`
+	Pattern   string `json:"pattern"`
+
+	Items    *sourceSchema `json:"items"`
+	MinItems *int          `json:"minItems"`
+	MaxItems *int          `json:"maxItems"`
+
+	Properties           map[string]*sourceSchema `json:"properties"`
+	Required             []string                 `json:"required"`
+	AdditionalProperties *additionalProperties    `json:"additionalProperties"`
+
+	AllOf []*sourceSchema `json:"allOf"`
+	OneOf []*sourceSchema `json:"oneOf"`
+	AnyOf []*sourceSchema `json:"anyOf"`
+	Not   *sourceSchema   `json:"not"`
+}
+
+// typeList is the "type" of a schema, either a single type or a list of types
+// since OpenAPI 3.1
+type typeList []string
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *typeList) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*t = typeList{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+// additionalProperties is either a schema or a boolean
+type additionalProperties struct {
+	Allowed bool
+	Schema  *sourceSchema
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (a *additionalProperties) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &a.Allowed); err == nil {
+		return nil
+	}
+	a.Allowed = true
+	return json.Unmarshal(b, &a.Schema)
+}
+
+// parseSource parses an OpenAPI 3 document in json or yaml
+func parseSource(data []byte) (*source, error) {
+	if !json.Valid(data) {
+		var node interface{}
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidDocument, err)
+		}
+		converted, err := json.Marshal(stringKeys(node))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidDocument, err)
+		}
+		data = converted
+	}
+
+	var src source
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&src); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDocument, err)
+	}
+	if !strings.HasPrefix(src.OpenAPI, "3.") {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedVersion, src.OpenAPI)
+	}
+	return &src, nil
+}
+
+// stringKeys converts yaml mappings with non-string keys, e.g. status codes,
+// into json compatible maps
+func stringKeys(node interface{}) interface{} {
+	switch node := node.(type) {
+	case map[string]interface{}:
+		for key, value := range node {
+			node[key] = stringKeys(value)
+		}
+		return node
+	case map[interface{}]interface{}:
+		var converted = make(map[string]interface{}, len(node))
+		for key, value := range node {
+			converted[fmt.Sprint(key)] = stringKeys(value)
+		}
+		return converted
+	case []interface{}:
+		for i, value := range node {
+			node[i] = stringKeys(value)
+		}
+		return node
+	}
+	return node
+}
@@ -0,0 +1,77 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/xdrm-io/aicra/validator"
+)
+
+// goTypeSchema describes the values of a go type, it is used for types that do
+// not describe their values
+func goTypeSchema(t reflect.Type) *validator.Schema {
+	if t == nil {
+		return &validator.Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &validator.Schema{Type: "boolean"}
+
+	case reflect.Int, reflect.Int64:
+		return &validator.Schema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &validator.Schema{Type: "integer", Format: "int32"}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var min float64
+		return &validator.Schema{Type: "integer", Minimum: &min}
+
+	case reflect.Float32:
+		return &validator.Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &validator.Schema{Type: "number", Format: "double"}
+
+	case reflect.String:
+		return &validator.Schema{Type: "string"}
+
+	case reflect.Slice, reflect.Array:
+		// raw files from multipart forms
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &validator.Schema{Type: "string", Format: "binary"}
+		}
+		return &validator.Schema{Type: "array", Items: goTypeSchema(t.Elem())}
+
+	case reflect.Map:
+		return &validator.Schema{Type: "object", AdditionalProperties: goTypeSchema(t.Elem())}
+
+	case reflect.Ptr:
+		return goTypeSchema(t.Elem())
+
+	case reflect.Struct:
+		var schema = &validator.Schema{
+			Type:       "object",
+			Properties: make(map[string]*validator.Schema, t.NumField()),
+		}
+		for i := 0; i < t.NumField(); i++ {
+			var field = t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			var name = field.Name
+			if tag, _, _ := strings.Cut(field.Tag.Get("json"), ","); tag == "-" {
+				continue
+			} else if len(tag) > 0 {
+				name = tag
+			}
+			schema.Properties[name] = goTypeSchema(field.Type)
+			if field.Type.Kind() != reflect.Ptr {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+		return schema
+	}
+
+	// interfaces and unsupported types accept any value
+	return &validator.Schema{}
+}
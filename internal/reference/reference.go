@@ -0,0 +1,305 @@
+// Package reference renders a static html reference of an aicra configuration
+package reference
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/xdrm-io/aicra/internal/config"
+	"github.com/xdrm-io/aicra/internal/naming"
+)
+
+//go:embed reference.html
+var source string
+
+var page = template.Must(template.New("reference").Parse(source))
+
+// methodOrder sorts services of the same path, custom methods come last
+var methodOrder = map[string]int{
+	http.MethodGet:     0,
+	http.MethodPost:    1,
+	http.MethodPut:     2,
+	http.MethodPatch:   3,
+	http.MethodDelete:  4,
+	http.MethodHead:    5,
+	http.MethodOptions: 6,
+}
+
+// Page is the content of the reference
+type Page struct {
+	Title  string
+	Groups []Group
+	Types  []Type
+}
+
+// Group lists the services sharing the same path prefix, i.e. the first
+// segment of their path
+type Group struct {
+	Prefix   string
+	Services []Service
+}
+
+// Service is the reference of a service
+type Service struct {
+	ID          string
+	Method      string
+	Path        string
+	Description string
+	// Scope lists alternative permission sets, any of them grants access
+	Scope [][]Permission
+	// Inputs are grouped by location
+	Inputs  []Location
+	Outputs []Param
+	// Search is the lower case text matched by the search
+	Search string
+}
+
+// Permission is a permission of a scope split into text and contextual
+// variables, e.g. "user[ID]" is made of "user" and the variable "ID" that is
+// replaced by the value of the capture renamed "ID"
+type Permission []Token
+
+// Token is either text or a contextual variable of a permission
+type Token struct {
+	Text string
+	Var  bool
+}
+
+// Location lists the input parameters read from the same location of the
+// request, e.g. the query
+type Location struct {
+	Name   string
+	Params []Param
+}
+
+// Param is the reference of a parameter or field
+type Param struct {
+	// Key is the name of the parameter in the request or response
+	Key         string
+	Name        string
+	Type        string
+	TypeRef     string
+	Optional    bool
+	Default     string
+	Description string
+	Fields      []Param
+}
+
+// Type is the reference of a named type
+type Type struct {
+	ID          string
+	Name        string
+	Type        string
+	TypeRef     string
+	Description string
+	Fields      []Param
+}
+
+// Render writes the html reference of a parsed configuration: services are
+// grouped by path prefix along with their required scopes, their inputs by
+// location, their outputs and the named types they use.
+func Render(w io.Writer, srv *config.Server, title string) error {
+	return page.Execute(w, Build(srv, title))
+}
+
+// Build returns the content of the reference of a parsed configuration
+func Build(srv *config.Server, title string) Page {
+	var p = Page{Title: title}
+
+	var services = make([]*config.Service, len(srv.Services))
+	copy(services, srv.Services)
+	sort.SliceStable(services, func(i, j int) bool {
+		a, b := services[i], services[j]
+		if a.Pattern != b.Pattern {
+			return a.Pattern < b.Pattern
+		}
+		return order(a.Method) < order(b.Method)
+	})
+
+	var groups = make(map[string]int)
+	for _, service := range services {
+		prefix := prefix(service.Pattern)
+		index, exists := groups[prefix]
+		if !exists {
+			index = len(p.Groups)
+			groups[prefix] = index
+			p.Groups = append(p.Groups, Group{Prefix: prefix})
+		}
+		p.Groups[index].Services = append(p.Groups[index].Services, newService(srv, service))
+	}
+	sort.SliceStable(p.Groups, func(i, j int) bool {
+		return p.Groups[i].Prefix < p.Groups[j].Prefix
+	})
+
+	var names = make([]string, 0, len(srv.Types))
+	for name := range srv.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		def := srv.Types[name]
+		p.Types = append(p.Types, Type{
+			ID:          typeID(name),
+			Name:        name,
+			Type:        def.Type,
+			TypeRef:     typeRef(srv, def.Type),
+			Description: def.Description,
+			Fields:      params(srv, def.Fields),
+		})
+	}
+	return p
+}
+
+// newService returns the reference of a service
+func newService(srv *config.Server, service *config.Service) Service {
+	var s = Service{
+		ID:          "service-" + naming.ServiceName(service),
+		Method:      service.Method,
+		Path:        service.Pattern,
+		Description: service.Description,
+		Outputs:     params(srv, service.Output),
+	}
+	for _, list := range service.Scope {
+		var perms = make([]Permission, 0, len(list))
+		for _, perm := range list {
+			perms = append(perms, permission(perm))
+		}
+		s.Scope = append(s.Scope, perms)
+	}
+
+	if len(service.Captures) > 0 {
+		var path = Location{Name: "Path"}
+		for _, capture := range service.Captures {
+			path.Params = append(path.Params, param(srv, capture.Name, capture.Ref))
+		}
+		s.Inputs = append(s.Inputs, path)
+	}
+	for _, location := range []Location{
+		{Name: "Query", Params: params(srv, service.Query)},
+		{Name: "Header", Params: params(srv, service.Header)},
+		{Name: "Cookie", Params: params(srv, service.Cookie)},
+		{Name: "Body", Params: params(srv, service.Form)},
+	} {
+		if len(location.Params) > 0 {
+			s.Inputs = append(s.Inputs, location)
+		}
+	}
+
+	var search = []string{s.Method, s.Path, s.Description}
+	for _, location := range s.Inputs {
+		for _, p := range location.Params {
+			search = append(search, p.Key, p.Name)
+		}
+	}
+	for _, p := range s.Outputs {
+		search = append(search, p.Key, p.Name)
+	}
+	for _, list := range service.Scope {
+		search = append(search, list...)
+	}
+	s.Search = strings.ToLower(strings.Join(search, " "))
+	return s
+}
+
+// params returns the reference of parameters sorted by key
+func params(srv *config.Server, params map[string]*config.Parameter) []Param {
+	var keys = make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var refs = make([]Param, 0, len(keys))
+	for _, key := range keys {
+		refs = append(refs, param(srv, key, params[key]))
+	}
+	return refs
+}
+
+// param returns the reference of a parameter
+func param(srv *config.Server, key string, p *config.Parameter) Param {
+	var ref = Param{
+		Key:         key,
+		Name:        p.Rename,
+		Type:        p.Type,
+		TypeRef:     typeRef(srv, p.Type),
+		Optional:    p.Optional,
+		Description: p.Description,
+		Fields:      params(srv, p.Fields),
+	}
+	if p.Default != nil {
+		ref.Default = fmt.Sprint(p.Default)
+		if encoded, err := json.Marshal(p.Default); err == nil {
+			ref.Default = string(encoded)
+		}
+	}
+	return ref
+}
+
+// permission splits a permission into text and contextual variables
+func permission(perm string) Permission {
+	var tokens Permission
+	for len(perm) > 0 {
+		start := strings.IndexByte(perm, '[')
+		end := strings.IndexByte(perm, ']')
+		if start < 0 || end < start {
+			tokens = append(tokens, Token{Text: perm})
+			break
+		}
+		if start > 0 {
+			tokens = append(tokens, Token{Text: perm[:start]})
+		}
+		tokens = append(tokens, Token{Text: perm[start+1 : end], Var: true})
+		perm = perm[end+1:]
+	}
+	return tokens
+}
+
+// typeRef returns the anchor of the named type a typename refers to, e.g.
+// "[]user" refers to the "user" type. It is empty for other types
+func typeRef(srv *config.Server, typename string) string {
+	for {
+		switch {
+		case strings.HasPrefix(typename, "[]"):
+			typename = typename[2:]
+			continue
+		case strings.HasPrefix(typename, "map[string]"):
+			typename = typename[len("map[string]"):]
+			continue
+		}
+		break
+	}
+	if _, named := srv.Types[typename]; !named {
+		return ""
+	}
+	return typeID(typename)
+}
+
+// typeID returns the anchor of a named type
+func typeID(name string) string {
+	return "type-" + name
+}
+
+// prefix returns the first segment of a path, e.g. "/user" for
+// "/user/{id}/articles"
+func prefix(pattern string) string {
+	var parts = config.SplitURI(pattern)
+	if len(parts) < 1 {
+		return "/"
+	}
+	return "/" + parts[0]
+}
+
+// order returns the sort order of a method
+func order(method string) int {
+	if index, standard := methodOrder[method]; standard {
+		return index
+	}
+	return len(methodOrder)
+}
@@ -0,0 +1,178 @@
+package reference
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xdrm-io/aicra/internal/config"
+	"github.com/xdrm-io/aicra/validator"
+)
+
+const referenceConfig = `{
+	"types": {
+		"user": { "info": "a user", "type": "object", "fields": {
+			"name": { "info": "user name", "type": "string", "name": "Name" }
+		} }
+	},
+	"services": [
+		{
+			"method": "PUT",
+			"path": "/user/{id}",
+			"info": "updates a user",
+			"scope": [["admin"], ["user[ID]", "write"]],
+			"in": {
+				"{id}": { "info": "user id", "type": "uint", "name": "ID" },
+				"GET@dry": { "info": "dry run", "type": "?bool", "name": "DryRun" },
+				"HEADER@X-Tenant": { "info": "tenant", "type": "string", "name": "Tenant" },
+				"user": { "info": "new user", "type": "user", "name": "User" },
+				"limit": { "info": "limit", "type": "?int", "name": "Limit", "default": 10 }
+			},
+			"out": {
+				"users": { "info": "users <b>", "type": "[]user", "name": "Users" }
+			}
+		},
+		{ "method": "GET", "path": "/user/{id}", "info": "fetches a user",
+			"in": { "{id}": { "info": "user id", "type": "uint", "name": "ID" } }
+		},
+		{ "method": "GET", "path": "/", "info": "home" },
+		{ "method": "GET", "path": "/article", "info": "lists articles" }
+	]
+}`
+
+func referenceServer(t *testing.T) *config.Server {
+	t.Helper()
+	srv := &config.Server{}
+	for _, v := range []validator.Type{
+		validator.BoolType{},
+		validator.IntType{},
+		validator.StringType{},
+		validator.UintType{},
+		validator.SliceType{},
+	} {
+		srv.AddInputValidator(v)
+	}
+	srv.AddOutputValidator("[]user", reflect.TypeOf([]struct{}{}))
+	if err := srv.Parse(strings.NewReader(referenceConfig)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return srv
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	p := Build(referenceServer(t), "title")
+
+	t.Run("groups", func(t *testing.T) {
+		var actual []string
+		for _, group := range p.Groups {
+			for _, service := range group.Services {
+				actual = append(actual, group.Prefix+" "+service.Method+" "+service.Path)
+			}
+		}
+		expect := []string{"/ GET /", "/article GET /article", "/user GET /user/{id}", "/user PUT /user/{id}"}
+		if !reflect.DeepEqual(actual, expect) {
+			t.Fatalf("invalid services\nactual: %v\nexpect: %v", actual, expect)
+		}
+	})
+
+	var put = p.Groups[2].Services[1]
+
+	t.Run("scope", func(t *testing.T) {
+		expect := [][]Permission{
+			{{{Text: "admin"}}},
+			{{{Text: "user"}, {Text: "ID", Var: true}}, {{Text: "write"}}},
+		}
+		if !reflect.DeepEqual(put.Scope, expect) {
+			t.Fatalf("invalid scope\nactual: %v\nexpect: %v", put.Scope, expect)
+		}
+	})
+
+	t.Run("inputs", func(t *testing.T) {
+		var actual []string
+		for _, location := range put.Inputs {
+			for _, param := range location.Params {
+				actual = append(actual, location.Name+" "+param.Key+" "+param.Name)
+			}
+		}
+		expect := []string{
+			"Path id ID",
+			"Query dry DryRun",
+			"Header X-Tenant Tenant",
+			"Body limit Limit",
+			"Body user User",
+		}
+		if !reflect.DeepEqual(actual, expect) {
+			t.Fatalf("invalid inputs\nactual: %v\nexpect: %v", actual, expect)
+		}
+
+		limit := put.Inputs[3].Params[0]
+		if !limit.Optional || limit.Default != "10" {
+			t.Fatalf("invalid optional parameter\nactual: optional %t default %q\nexpect: optional true default %q", limit.Optional, limit.Default, "10")
+		}
+		if user := put.Inputs[3].Params[1]; user.TypeRef != "type-user" {
+			t.Fatalf("invalid type reference\nactual: %q\nexpect: %q", user.TypeRef, "type-user")
+		}
+	})
+
+	t.Run("outputs", func(t *testing.T) {
+		if len(put.Outputs) != 1 || put.Outputs[0].TypeRef != "type-user" {
+			t.Fatalf("invalid outputs %v", put.Outputs)
+		}
+	})
+
+	t.Run("types", func(t *testing.T) {
+		if len(p.Types) != 1 || p.Types[0].Name != "user" || len(p.Types[0].Fields) != 1 {
+			t.Fatalf("invalid types %v", p.Types)
+		}
+	})
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	var out strings.Builder
+	if err := Render(&out, referenceServer(t), "My API"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expect := []string{
+		"<title>My API</title>",
+		`<details class="service" id="service-PutUserByID"`,
+		`<span class="method PUT">PUT</span><span class="path">/user/{id}</span>`,
+		`<span class="perm">user[<var title="replaced by the value of the ID input">ID</var>]</span>`,
+		`<a href="#type-user">[]user</a>`,
+		"users &lt;b&gt;",
+		`<details class="service" id="type-user"`,
+	}
+	for _, snippet := range expect {
+		if !strings.Contains(out.String(), snippet) {
+			t.Fatalf("missing %q in\n%s", snippet, out.String())
+		}
+	}
+}
+
+func TestPermission(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		perm   string
+		expect Permission
+	}{
+		{"admin", Permission{{Text: "admin"}}},
+		{"user[ID]", Permission{{Text: "user"}, {Text: "ID", Var: true}}},
+		{"[Org].[ID]", Permission{{Text: "Org", Var: true}, {Text: "."}, {Text: "ID", Var: true}}},
+		{"broken]", Permission{{Text: "broken]"}}},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.perm, func(t *testing.T) {
+			t.Parallel()
+			if actual := permission(tc.perm); !reflect.DeepEqual(actual, tc.expect) {
+				t.Fatalf("invalid permission\nactual: %v\nexpect: %v", actual, tc.expect)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package reqdata
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // cerr defines const-enabled errors with type boxing
 type cerr string
@@ -33,14 +37,25 @@ const (
 	ErrMissingURIParameter = cerr("missing URI parameter")
 )
 
+// errMultipleValues is the reason of an invalid parameter that does not expect
+// a slice but is provided multiple values
+var errMultipleValues = errors.New("expected a single value")
+
 // Err defines errors for request data
 type Err struct {
 	field string
+	// param is the name of the parameter in the configuration, e.g. "GET@id"
+	param string
 	err   cerr
+	// reason describes why the value is invalid, it is nil when unknown
+	reason error
 }
 
 // Error implements error
 func (err Err) Error() string {
+	if err.reason != nil {
+		return fmt.Sprintf("%s: %s: %s", err.field, err.reason, err.err)
+	}
 	return fmt.Sprintf("%s: %s", err.field, err.err)
 }
 
@@ -49,7 +64,30 @@ func (err Err) Field() string {
 	return err.field
 }
 
+// Param returns the name of the parameter in the configuration, e.g.
+// "GET@id" or "{id}"
+func (err Err) Param() string {
+	return err.param
+}
+
+// Reason returns why the value is invalid, nil when unknown
+func (err Err) Reason() error {
+	return err.reason
+}
+
 // Unwrap implements errors.Unwrap
 func (err Err) Unwrap() error {
 	return err.err
 }
+
+// Errors lists the errors of every invalid or missing parameter
+type Errors []*Err
+
+// Error implements error
+func (errs Errors) Error() string {
+	var messages = make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"mime"
 	"reflect"
+	"sort"
 	"sync"
 
 	"github.com/xdrm-io/aicra/internal/config"
@@ -27,6 +28,8 @@ var mapPool = &sync.Pool{
 // specific configuration service; it features:
 // - uri data
 // - get data
+// - header data
+// - cookie data
 // - form data depending on the Content-Type http header
 //   - 'application/json'                  => key-value pair is parsed as json into the map
 //   - 'application/x-www-form-urlencoded' => standard parameters as QUERY parameters
@@ -34,6 +37,11 @@ var mapPool = &sync.Pool{
 type Request struct {
 	service *config.Service
 	Data    map[string]interface{}
+
+	// collect defines whether extraction goes on after invalid or missing
+	// parameters, c.f. CollectErrors()
+	collect bool
+	errs    Errors
 }
 
 // NewRequest creates a new empty store.
@@ -55,6 +63,57 @@ func (r *Request) Release() {
 	mapPool.Put(r.Data)
 }
 
+// CollectErrors makes extraction go on after invalid or missing parameters
+// instead of failing on the first one, their errors are listed by Errors()
+// once the extraction is over. Errors that prevent reading the request, e.g.
+// invalid json, still fail the extraction.
+func (r *Request) CollectErrors() {
+	r.collect = true
+}
+
+// Errors returns the errors of every invalid or missing parameter sorted by
+// field, c.f. CollectErrors(). It returns nil when there is none.
+func (r *Request) Errors() Errors {
+	if len(r.errs) < 1 {
+		return nil
+	}
+	sort.Slice(r.errs, func(i, j int) bool {
+		return r.errs[i].field < r.errs[j].field
+	})
+	return r.errs
+}
+
+// fail returns the error of a parameter, in collect mode the error is stored
+// and nil is returned so that the extraction goes on
+func (r *Request) fail(param *config.Parameter, err cerr, reason error) error {
+	var failure = &Err{field: param.Rename, param: r.key(param), err: err, reason: reason}
+	if !r.collect {
+		return failure
+	}
+	r.errs = append(r.errs, failure)
+	return nil
+}
+
+// failed returns whether an error has been collected for a parameter
+func (r *Request) failed(param *config.Parameter) bool {
+	for _, err := range r.errs {
+		if err.field == param.Rename {
+			return true
+		}
+	}
+	return false
+}
+
+// key returns the name of a parameter in the configuration, e.g. "GET@id"
+func (r *Request) key(param *config.Parameter) string {
+	for key, input := range r.service.Input {
+		if input == param {
+			return key
+		}
+	}
+	return param.Rename
+}
+
 // ExtractURI parameters
 func (r *Request) ExtractURI(req *http.Request) error {
 	if len(r.service.Captures) < 1 {
@@ -65,7 +124,10 @@ func (r *Request) ExtractURI(req *http.Request) error {
 	for _, capture := range r.service.Captures {
 		// out of range
 		if capture.Index > len(uriparts)-1 {
-			return &Err{field: capture.Ref.Rename, err: ErrMissingURIParameter}
+			if err := r.fail(capture.Ref, ErrMissingURIParameter, nil); err != nil {
+				return err
+			}
+			continue
 		}
 		value := uriparts[capture.Index]
 
@@ -74,9 +136,12 @@ func (r *Request) ExtractURI(req *http.Request) error {
 		}
 
 		parsed := value
-		cast, valid := capture.Ref.Validator(parsed)
-		if !valid {
-			return &Err{field: capture.Ref.Rename, err: ErrInvalidType}
+		cast, err := capture.Ref.Check(parsed)
+		if err != nil {
+			if err = r.fail(capture.Ref, ErrInvalidType, err); err != nil {
+				return err
+			}
+			continue
 		}
 		r.Data[capture.Ref.Rename] = cast
 	}
@@ -98,7 +163,9 @@ func (r *Request) ExtractQuery(req *http.Request) error {
 
 		if !exist {
 			if !param.Optional {
-				return &Err{field: param.Rename, err: ErrMissingRequiredParam}
+				if err := r.fail(param, ErrMissingRequiredParam, nil); err != nil {
+					return err
+				}
 			}
 			continue
 		}
@@ -106,34 +173,130 @@ func (r *Request) ExtractQuery(req *http.Request) error {
 		var parsed interface{}
 
 		// consider slice only if we expect a slice, otherwise, only take the first parameter
-		if param.GoType.Kind() == reflect.Slice {
+		if isSlice(param.GoType) {
 			parsed = values
 		} else {
 			// should expect at most 1 value
 			if len(values) > 1 {
-				return &Err{field: param.Rename, err: ErrInvalidType}
+				if err := r.fail(param, ErrInvalidType, errMultipleValues); err != nil {
+					return err
+				}
+				continue
 			}
 			if len(values) > 0 {
 				parsed = values[0]
 			}
 		}
 
-		cast, valid := param.Validator(parsed)
-		if !valid {
-			return &Err{field: param.Rename, err: ErrInvalidType}
+		cast, err := param.Check(parsed)
+		if err != nil {
+			if err = r.fail(param, ErrInvalidType, err); err != nil {
+				return err
+			}
+			continue
+		}
+		r.Data[param.Rename] = cast
+	}
+
+	return nil
+}
+
+// ExtractHeader data from the http headers
+func (r *Request) ExtractHeader(req *http.Request) error {
+	for name, param := range r.service.Header {
+		values := req.Header.Values(name)
+
+		if len(values) < 1 {
+			if !param.Optional {
+				if err := r.fail(param, ErrMissingRequiredParam, nil); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		var parsed interface{}
+
+		// consider slice only if we expect a slice, otherwise, only take the first parameter
+		if isSlice(param.GoType) {
+			parsed = values
+		} else {
+			// should expect at most 1 value
+			if len(values) > 1 {
+				if err := r.fail(param, ErrInvalidType, errMultipleValues); err != nil {
+					return err
+				}
+				continue
+			}
+			parsed = values[0]
+		}
+
+		cast, err := param.Check(parsed)
+		if err != nil {
+			if err = r.fail(param, ErrInvalidType, err); err != nil {
+				return err
+			}
+			continue
 		}
 		r.Data[param.Rename] = cast
 	}
+	return nil
+}
+
+// ExtractCookie data from the http cookies
+func (r *Request) ExtractCookie(req *http.Request) error {
+	for name, param := range r.service.Cookie {
+		cookie, err := req.Cookie(name)
+
+		if err != nil {
+			if !param.Optional {
+				if err := r.fail(param, ErrMissingRequiredParam, nil); err != nil {
+					return err
+				}
+			}
+			continue
+		}
 
+		cast, err := param.Check(cookie.Value)
+		if err != nil {
+			if err = r.fail(param, ErrInvalidType, err); err != nil {
+				return err
+			}
+			continue
+		}
+		r.Data[param.Rename] = cast
+	}
 	return nil
 }
 
+// isSlice returns whether a parameter of a go type gathers repeated values,
+// parameters without go type (e.g. "any") take a single value
+func isSlice(t reflect.Type) bool {
+	return t != nil && t.Kind() == reflect.Slice
+}
+
+// ExtractDefaults fills optional parameters that have not been provided with
+// their default value. It must be called after every other extraction.
+//
+// Slice and map defaults are copied so that a handler modifying its input
+// cannot alter the default value of the next requests.
+func (r *Request) ExtractDefaults() {
+	for _, param := range r.service.Input {
+		if param.Default == nil {
+			continue
+		}
+		if _, exists := r.Data[param.Rename]; !exists {
+			r.Data[param.Rename] = param.DefaultValue()
+		}
+	}
+}
+
 // ExtractForm parameters according go the http Content-Type header
 // - 'multipart/form-data'
 // - 'x-www-form-urlencoded'
 // - 'application/json'
 func (r *Request) ExtractForm(req *http.Request) error {
-	if req.Method == http.MethodGet {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
 		return nil
 	}
 
@@ -166,8 +329,10 @@ func (r *Request) ExtractForm(req *http.Request) error {
 	// fail on at least 1 mandatory form param when there is no body
 	for _, param := range r.service.Form {
 		_, exists := r.Data[param.Rename]
-		if !exists && !param.Optional {
-			return &Err{field: param.Rename, err: ErrMissingRequiredParam}
+		if !exists && !param.Optional && !r.failed(param) {
+			if err := r.fail(param, ErrMissingRequiredParam, nil); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -193,9 +358,12 @@ func (r *Request) parseJSON(reader io.Reader) error {
 			continue
 		}
 
-		cast, valid := param.Validator(value)
-		if !valid {
-			return &Err{field: param.Rename, err: ErrInvalidType}
+		cast, err := param.Check(value)
+		if err != nil {
+			if err = r.fail(param, ErrInvalidType, err); err != nil {
+				return err
+			}
+			continue
 		}
 		r.Data[param.Rename] = cast
 	}
@@ -226,21 +394,27 @@ func (r *Request) parseUrlencoded(reader io.Reader) error {
 		var parsed interface{}
 
 		// consider slice only if we expect a slice, otherwise, only take the first parameter
-		if param.GoType.Kind() == reflect.Slice {
+		if isSlice(param.GoType) {
 			parsed = values
 		} else if len(values) > 0 {
 			// should expect at most 1 value
 			if len(values) > 1 {
-				return &Err{field: param.Rename, err: ErrInvalidType}
+				if err := r.fail(param, ErrInvalidType, errMultipleValues); err != nil {
+					return err
+				}
+				continue
 			}
 			if len(values) > 0 {
 				parsed = values[0]
 			}
 		}
 
-		cast, valid := param.Validator(parsed)
-		if !valid {
-			return &Err{field: param.Rename, err: ErrInvalidType}
+		cast, err := param.Check(parsed)
+		if err != nil {
+			if err = r.fail(param, ErrInvalidType, err); err != nil {
+				return err
+			}
+			continue
 		}
 		r.Data[param.Rename] = cast
 	}
@@ -257,7 +431,7 @@ func (r *Request) parseMultipart(reader io.Reader, boundary string) error {
 	}
 
 	var (
-		parts = make(map[string]Part, len(r.service.Form))
+		parts = make(map[string][]Part, len(r.service.Form))
 		mr    = multipart.NewReader(reader, boundary)
 	)
 	var firstPart = true
@@ -279,30 +453,45 @@ func (r *Request) parseMultipart(reader io.Reader, boundary string) error {
 		if err != nil {
 			return fmt.Errorf("%w: %s: %s", ErrInvalidMultipart, p.FormName(), err)
 		}
-		parts[p.FormName()] = Part{
+		parts[p.FormName()] = append(parts[p.FormName()], Part{
 			contentType: p.Header.Get("Content-Type"),
 			data:        data,
+		})
+	}
+
+	// parse a part as a file or as a string
+	parse := func(part Part) interface{} {
+		var isFile = len(part.contentType) > 0
+		if isFile {
+			return part.data
 		}
+		return string(part.data)
 	}
 
 	for name, param := range r.service.Form {
-		part, exist := parts[name]
+		list, exist := parts[name]
 		if !exist {
 			continue
 		}
 
-		var isFile = len(part.contentType) > 0
-
+		// repeated parts are gathered for slices except raw files ([]byte)
 		var parsed interface{}
-		if isFile {
-			parsed = part.data
+		if isSlice(param.GoType) && param.GoType.Elem().Kind() != reflect.Uint8 {
+			items := make([]interface{}, len(list))
+			for i, part := range list {
+				items[i] = parse(part)
+			}
+			parsed = items
 		} else {
-			parsed = string(part.data)
+			parsed = parse(list[len(list)-1])
 		}
 
-		cast, valid := param.Validator(parsed)
-		if !valid {
-			return &Err{field: param.Rename, err: ErrInvalidType}
+		cast, err := param.Check(parsed)
+		if err != nil {
+			if err = r.fail(param, ErrInvalidType, err); err != nil {
+				return err
+			}
+			continue
 		}
 		r.Data[param.Rename] = cast
 	}
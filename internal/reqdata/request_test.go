@@ -77,6 +77,43 @@ func getServiceWithForm(t reflect.Type, params ...string) *config.Service {
 	return service
 }
 
+func getServiceWithHeader(t reflect.Type, optional bool, params ...string) *config.Service {
+	service := &config.Service{
+		Input:  make(map[string]*config.Parameter),
+		Header: make(map[string]*config.Parameter),
+	}
+
+	for _, name := range params {
+		id := fmt.Sprintf("HEADER@%s", name)
+		service.Input[id] = &config.Parameter{
+			Rename:    name,
+			GoType:    t,
+			Optional:  optional,
+			Validator: func(value interface{}) (interface{}, bool) { return value, true },
+		}
+		service.Header[http.CanonicalHeaderKey(name)] = service.Input[id]
+	}
+	return service
+}
+func getServiceWithCookie(optional bool, params ...string) *config.Service {
+	service := &config.Service{
+		Input:  make(map[string]*config.Parameter),
+		Cookie: make(map[string]*config.Parameter),
+	}
+
+	for _, name := range params {
+		id := fmt.Sprintf("COOKIE@%s", name)
+		service.Input[id] = &config.Parameter{
+			Rename:    name,
+			GoType:    reflect.TypeOf(""),
+			Optional:  optional,
+			Validator: func(value interface{}) (interface{}, bool) { return value, true },
+		}
+		service.Cookie[name] = service.Input[id]
+	}
+	return service
+}
+
 func TestRequestPoolReUse(t *testing.T) {
 	// replace the map pool with our custom code
 	var oldPool = mapPool
@@ -445,6 +482,285 @@ func TestExtractQuery(t *testing.T) {
 
 }
 
+func TestExtractHeader(t *testing.T) {
+	tt := []struct {
+		name     string
+		params   []string
+		optional bool
+		headers  [][2]string
+		err      error
+		field    string
+
+		paramTypes  reflect.Type
+		paramNames  []string
+		paramValues [][]string
+	}{
+		{
+			name:       "none required",
+			paramTypes: reflect.TypeOf(""),
+		},
+		{
+			name:       "1 required missing",
+			params:     []string{"X-Missing"},
+			err:        ErrMissingRequiredParam,
+			field:      "X-Missing",
+			paramTypes: reflect.TypeOf(""),
+		},
+		{
+			name:       "1 optional missing",
+			params:     []string{"X-Missing"},
+			optional:   true,
+			paramTypes: reflect.TypeOf(""),
+		},
+		{
+			name:        "1 required ok",
+			params:      []string{"X-Tenant-ID"},
+			headers:     [][2]string{{"X-Tenant-Id", "abc"}},
+			paramTypes:  reflect.TypeOf(""),
+			paramNames:  []string{"X-Tenant-ID"},
+			paramValues: [][]string{{"abc"}},
+		},
+		{
+			name:        "case insensitive",
+			params:      []string{"if-match"},
+			headers:     [][2]string{{"IF-MATCH", "abc"}},
+			paramTypes:  reflect.TypeOf(""),
+			paramNames:  []string{"if-match"},
+			paramValues: [][]string{{"abc"}},
+		},
+		{
+			name:       "expect string got values",
+			params:     []string{"X-A"},
+			headers:    [][2]string{{"X-A", "a"}, {"X-A", "b"}},
+			err:        ErrInvalidType,
+			field:      "X-A",
+			paramTypes: reflect.TypeOf(""),
+		},
+		{
+			name:        "expect slice got values",
+			params:      []string{"X-A"},
+			headers:     [][2]string{{"X-A", "a"}, {"X-A", "b"}},
+			paramTypes:  reflect.TypeOf([]string{}),
+			paramNames:  []string{"X-A"},
+			paramValues: [][]string{{"a", "b"}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://host.com", nil)
+			for _, header := range tc.headers {
+				req.Header.Add(header[0], header[1])
+			}
+			var (
+				store = NewRequest(getServiceWithHeader(tc.paramTypes, tc.optional, tc.params...))
+				err   = store.ExtractHeader(req)
+			)
+
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, tc.err)
+			}
+			if err != nil {
+				cast, ok := err.(*Err)
+				if !ok {
+					t.Fatalf("error should be of type *Err")
+				}
+				if cast.Field() != tc.field {
+					t.Fatalf("invalid field\nactual: %v\nexpect: %v", cast.Field(), tc.field)
+				}
+				return
+			}
+
+			if tc.paramNames == nil || tc.paramValues == nil {
+				if len(store.Data) != 0 {
+					t.Fatalf("expected no header parameters and got %d", len(store.Data))
+				}
+				return
+			}
+			checkExtracted(t, tc.paramNames, tc.paramTypes.Kind(), tc.paramValues, store.Data)
+		})
+	}
+}
+
+func TestExtractAnyType(t *testing.T) {
+	t.Parallel()
+
+	// "any" parameters have no go type
+	var (
+		header = getServiceWithHeader(nil, true, "X-Data")
+		query  = getServiceWithQuery(nil, "data")
+		form   = getServiceWithForm(nil, "data")
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "http://host.com?data=x", strings.NewReader("data=x"))
+	req.Header.Set("X-Data", "x")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	for _, tc := range []struct {
+		name    string
+		service *config.Service
+		extract func(*Request, *http.Request) error
+		param   string
+	}{
+		{name: "header", service: header, extract: (*Request).ExtractHeader, param: "X-Data"},
+		{name: "query", service: query, extract: (*Request).ExtractQuery, param: "data"},
+		{name: "urlencoded", service: form, extract: (*Request).ExtractForm, param: "data"},
+	} {
+		store := NewRequest(tc.service)
+		if err := tc.extract(store, req); err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.name, err)
+		}
+		if store.Data[tc.param] != "x" {
+			t.Fatalf("%s: invalid value\nactual: %v\nexpect: %v", tc.name, store.Data[tc.param], "x")
+		}
+	}
+}
+
+func TestExtractCookie(t *testing.T) {
+	tt := []struct {
+		name     string
+		params   []string
+		optional bool
+		cookies  map[string]string
+		err      error
+		field    string
+
+		paramNames  []string
+		paramValues [][]string
+	}{
+		{
+			name: "none required",
+		},
+		{
+			name:   "1 required missing",
+			params: []string{"session"},
+			err:    ErrMissingRequiredParam,
+			field:  "session",
+		},
+		{
+			name:     "1 optional missing",
+			params:   []string{"session"},
+			optional: true,
+		},
+		{
+			name:        "1 required ok",
+			params:      []string{"session"},
+			cookies:     map[string]string{"session": "abc", "other": "def"},
+			paramNames:  []string{"session"},
+			paramValues: [][]string{{"abc"}},
+		},
+		{
+			name:        "empty value",
+			params:      []string{"session"},
+			cookies:     map[string]string{"session": ""},
+			paramNames:  []string{"session"},
+			paramValues: [][]string{{""}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://host.com", nil)
+			for name, value := range tc.cookies {
+				req.AddCookie(&http.Cookie{Name: name, Value: value})
+			}
+			var (
+				store = NewRequest(getServiceWithCookie(tc.optional, tc.params...))
+				err   = store.ExtractCookie(req)
+			)
+
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, tc.err)
+			}
+			if err != nil {
+				cast, ok := err.(*Err)
+				if !ok {
+					t.Fatalf("error should be of type *Err")
+				}
+				if cast.Field() != tc.field {
+					t.Fatalf("invalid field\nactual: %v\nexpect: %v", cast.Field(), tc.field)
+				}
+				return
+			}
+
+			if tc.paramNames == nil || tc.paramValues == nil {
+				if len(store.Data) != 0 {
+					t.Fatalf("expected no cookie parameters and got %d", len(store.Data))
+				}
+				return
+			}
+			checkExtracted(t, tc.paramNames, reflect.String, tc.paramValues, store.Data)
+		})
+	}
+}
+
+func TestExtractDefaults(t *testing.T) {
+	service := getServiceWithQuery(reflect.TypeOf(""), "provided", "absent", "nodefault")
+	for _, param := range service.Query {
+		param.Optional = true
+	}
+	service.Query["provided"].Default = "default1"
+	service.Query["absent"].Default = "default2"
+
+	var (
+		req   = httptest.NewRequest(http.MethodGet, "http://host.com?provided=value", nil)
+		store = NewRequest(service)
+	)
+	if err := store.ExtractQuery(req); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	store.ExtractDefaults()
+
+	if len(store.Data) != 2 {
+		t.Fatalf("invalid parameter count\nactual: %d\nexpect: %d", len(store.Data), 2)
+	}
+	checkExtracted(t, []string{"provided"}, reflect.String, [][]string{{"value"}}, store.Data)
+	checkExtracted(t, []string{"absent"}, reflect.String, [][]string{{"default2"}}, store.Data)
+}
+
+func TestExtractDefaultsCopy(t *testing.T) {
+	service := getServiceWithQuery(reflect.TypeOf([]string{}), "slice", "object")
+	for _, param := range service.Query {
+		param.Optional = true
+	}
+	service.Query["slice"].Default = []string{"a", "b"}
+	service.Query["object"].Default = map[string]interface{}{
+		"tags": []interface{}{"x"},
+	}
+
+	for i := 0; i < 2; i++ {
+		var (
+			req   = httptest.NewRequest(http.MethodGet, "http://host.com", nil)
+			store = NewRequest(service)
+		)
+		if err := store.ExtractQuery(req); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		store.ExtractDefaults()
+
+		slice, ok := store.Data["slice"].([]string)
+		if !ok {
+			t.Fatalf("invalid slice type\nactual: %T\nexpect: %T", store.Data["slice"], []string{})
+		}
+		if !reflect.DeepEqual(slice, []string{"a", "b"}) {
+			t.Fatalf("request %d: invalid slice\nactual: %v\nexpect: %v", i, slice, []string{"a", "b"})
+		}
+		object, ok := store.Data["object"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("invalid object type\nactual: %T\nexpect: %T", store.Data["object"], map[string]interface{}{})
+		}
+		tags, ok := object["tags"].([]interface{})
+		if !ok || !reflect.DeepEqual(tags, []interface{}{"x"}) {
+			t.Fatalf("request %d: invalid tags\nactual: %v\nexpect: %v", i, object["tags"], []interface{}{"x"})
+		}
+
+		// handler modifying its input
+		slice[0] = "modified"
+		tags[0] = "modified"
+		object["added"] = true
+	}
+}
+
 func TestExtractFormUrlEncoded(t *testing.T) {
 	tt := []struct {
 		name   string
@@ -1001,3 +1317,202 @@ Content-Type: application/zip
 	}
 
 }
+
+func TestMultipartSliceParameters(t *testing.T) {
+	tt := []struct {
+		name         string
+		gotype       reflect.Type
+		rawMultipart string
+		expect       interface{}
+	}{
+		{
+			name:   "string keeps last part",
+			gotype: reflect.TypeOf(""),
+			rawMultipart: `--xxx
+Content-Disposition: form-data; name="a"
+
+x
+--xxx
+Content-Disposition: form-data; name="a"
+
+y
+--xxx--`,
+			expect: "y",
+		},
+		{
+			name:   "slice gathers parts",
+			gotype: reflect.TypeOf([]string{}),
+			rawMultipart: `--xxx
+Content-Disposition: form-data; name="a"
+
+x
+--xxx
+Content-Disposition: form-data; name="a"
+
+y
+--xxx--`,
+			expect: []interface{}{"x", "y"},
+		},
+		{
+			name:   "slice single part",
+			gotype: reflect.TypeOf([]int{}),
+			rawMultipart: `--xxx
+Content-Disposition: form-data; name="a"
+
+1
+--xxx--`,
+			expect: []interface{}{"1"},
+		},
+		{
+			name:   "bytes keeps last file",
+			gotype: reflect.TypeOf([]byte{}),
+			rawMultipart: `--xxx
+Content-Disposition: form-data; name="a"; filename="f1"
+Content-Type: application/octet-stream
+
+x
+--xxx
+Content-Disposition: form-data; name="a"; filename="f2"
+Content-Type: application/octet-stream
+
+y
+--xxx--`,
+			expect: []byte("y"),
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			body := strings.NewReader(tc.rawMultipart)
+			req := httptest.NewRequest(http.MethodPost, "http://host.com", body)
+			req.Header.Add("Content-Type", "multipart/form-data; boundary=xxx")
+			defer req.Body.Close()
+
+			store := NewRequest(getServiceWithForm(tc.gotype, "a"))
+			if err := store.ExtractForm(req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(store.Data["a"], tc.expect) {
+				t.Fatalf("invalid value\nactual: %#v\nexpect: %#v", store.Data["a"], tc.expect)
+			}
+		})
+	}
+}
+
+func TestInvalidTypeReason(t *testing.T) {
+	t.Parallel()
+
+	var reason = errors.New("length 4 exceeds max 3")
+
+	tt := []struct {
+		name    string
+		checker func(interface{}) (interface{}, error)
+		url     string
+		reason  error
+		message string
+	}{
+		{
+			name:    "checker reason",
+			checker: func(value interface{}) (interface{}, error) { return value, reason },
+			url:     "/?name=abcd",
+			reason:  reason,
+			message: "name: length 4 exceeds max 3: invalid type",
+		},
+		{
+			name:    "multiple values",
+			url:     "/?name=a&name=b",
+			reason:  errMultipleValues,
+			message: "name: expected a single value: invalid type",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := getServiceWithQuery(reflect.TypeOf(""), "name")
+			service.Query["name"].Checker = tc.checker
+
+			req := httptest.NewRequest(http.MethodGet, "http://host.com"+tc.url, nil)
+			err := NewRequest(service).ExtractQuery(req)
+			if !errors.Is(err, ErrInvalidType) {
+				t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, ErrInvalidType)
+			}
+
+			var cast *Err
+			if !errors.As(err, &cast) {
+				t.Fatalf("expected *Err, got %T", err)
+			}
+			if cast.Reason() != tc.reason {
+				t.Fatalf("invalid reason\nactual: %v\nexpect: %v", cast.Reason(), tc.reason)
+			}
+			if err.Error() != tc.message {
+				t.Fatalf("invalid message\nactual: %s\nexpect: %s", err.Error(), tc.message)
+			}
+		})
+	}
+}
+
+func TestCollectErrors(t *testing.T) {
+	t.Parallel()
+
+	var reason = errors.New("not an integer")
+	invalid := func(value interface{}) (interface{}, error) { return nil, reason }
+
+	service := getServiceWithQuery(reflect.TypeOf(0), "page", "limit")
+	service.Form = make(map[string]*config.Parameter)
+	for _, name := range []string{"name", "age", "email"} {
+		service.Input[name] = &config.Parameter{
+			Rename:    name,
+			GoType:    reflect.TypeOf(""),
+			Validator: func(value interface{}) (interface{}, bool) { return value, true },
+		}
+		service.Form[name] = service.Input[name]
+	}
+	service.Query["page"].Checker = invalid
+	service.Form["age"].Checker = invalid
+
+	req := httptest.NewRequest(http.MethodPost, "http://host.com/?page=abc", strings.NewReader(`{"name":"x","age":"abc"}`))
+	req.Header.Add("Content-Type", "application/json")
+
+	r := NewRequest(service)
+	r.CollectErrors()
+	if err := r.ExtractQuery(req); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := r.ExtractForm(req); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expect := []struct {
+		param string
+		err   error
+		msg   string
+	}{
+		{"age", ErrInvalidType, "age: not an integer: invalid type"},
+		{"email", ErrMissingRequiredParam, "email: missing required param"},
+		{"GET@limit", ErrMissingRequiredParam, "limit: missing required param"},
+		{"GET@page", ErrInvalidType, "page: not an integer: invalid type"},
+	}
+
+	errs := r.Errors()
+	if len(errs) != len(expect) {
+		t.Fatalf("invalid error count\nactual: %d (%v)\nexpect: %d", len(errs), errs, len(expect))
+	}
+	for i, exp := range expect {
+		if errs[i].Param() != exp.param {
+			t.Errorf("error %d: invalid param\nactual: %s\nexpect: %s", i, errs[i].Param(), exp.param)
+		}
+		if !errors.Is(errs[i], exp.err) {
+			t.Errorf("error %d: invalid error\nactual: %v\nexpect: %v", i, errs[i], exp.err)
+		}
+		if errs[i].Error() != exp.msg {
+			t.Errorf("error %d: invalid message\nactual: %s\nexpect: %s", i, errs[i].Error(), exp.msg)
+		}
+	}
+	if r.Data["name"] != "x" {
+		t.Fatalf("valid parameter not extracted\nactual: %v\nexpect: %v", r.Data["name"], "x")
+	}
+}
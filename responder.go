@@ -2,6 +2,7 @@ package aicra
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/xdrm-io/aicra/api"
@@ -10,6 +11,13 @@ import (
 // Responder defines how to write data and error  into the http response
 type Responder func(http.ResponseWriter, map[string]interface{}, error)
 
+// paramError is the json representation of an api.ParamError, e.g.
+// {"error":"invalid parameter","reason":"not an integer"}
+type paramError struct {
+	Error  string `json:"error"`
+	Reason string `json:"reason,omitempty"`
+}
+
 // DefaultResponder used for writing data and error into http responses
 func DefaultResponder(w http.ResponseWriter, data map[string]interface{}, e error) {
 	w.Header().Add("Content-Type", "application/json; charset=utf-8")
@@ -24,6 +32,20 @@ func DefaultResponder(w http.ResponseWriter, data map[string]interface{}, e erro
 		data["status"] = e.Error()
 	}
 
+	// list every invalid or missing parameter
+	var params api.ParamErrors
+	if errors.As(e, &params) {
+		var errs = make(map[string]paramError, len(params))
+		for _, param := range params {
+			var rendered = paramError{Error: param.Err.Error()}
+			if param.Reason != nil {
+				rendered.Reason = param.Reason.Error()
+			}
+			errs[param.Param] = rendered
+		}
+		data["errors"] = errs
+	}
+
 	encoded, err := json.Marshal(data)
 	if err == nil {
 		w.Write(encoded)
@@ -1,6 +1,7 @@
 package aicra
 
 import (
+	"errors"
 	"net/http/httptest"
 	"strings"
 	"testing"
@@ -65,6 +66,16 @@ func TestResponseJSON(t *testing.T) {
 			data: map[string]interface{}{"r": "before", "t": "after"},
 			json: `{"r":"before","status":"all right","t":"after"}`,
 		},
+		{
+			name: "parameter errors",
+			err: api.ParamErrors{
+				{Param: "GET@id", Field: "ID", Err: api.ErrMissingParam},
+				{Param: "name", Field: "Name", Err: api.ErrInvalidParam, Reason: errors.New("not a string")},
+				{Param: "age", Err: api.ErrInvalidParam},
+			},
+			data: map[string]interface{}{},
+			json: `{"errors":{"GET@id":{"error":"missing parameter"},"age":{"error":"invalid parameter"},"name":{"error":"invalid parameter","reason":"not a string"}},"status":"ID: missing parameter; Name: not a string: invalid parameter; invalid parameter"}`,
+		},
 	}
 
 	for _, tc := range tt {
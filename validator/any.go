@@ -14,11 +14,24 @@ func (AnyType) GoType() reflect.Type {
 }
 
 // Validator that considers any value valid
-func (AnyType) Validator(typename string, avail ...Type) ValidateFunc {
+func (t AnyType) Validator(typename string, avail ...Type) ValidateFunc {
+	return validateFunc(t.Checker(typename, avail...))
+}
+
+// Checker that considers any value valid
+func (AnyType) Checker(typename string, avail ...Type) CheckFunc {
 	if typename != "any" {
 		return nil
 	}
-	return func(value interface{}) (interface{}, bool) {
-		return value, true
+	return func(value interface{}) (interface{}, error) {
+		return value, nil
+	}
+}
+
+// Schema describes any json value with an empty schema
+func (AnyType) Schema(typename string, avail ...Type) *Schema {
+	if typename != "any" {
+		return nil
 	}
+	return &Schema{}
 }
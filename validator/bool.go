@@ -1,9 +1,12 @@
 package validator
 
 import (
+	"errors"
 	"reflect"
 )
 
+var errNotBoolean = errors.New("not a boolean")
+
 // BoolType makes the "bool" type available in the aicra configuration
 // It considers valid:
 // - booleans
@@ -17,25 +20,45 @@ func (BoolType) GoType() reflect.Type {
 }
 
 // Validator for bool values
-func (BoolType) Validator(typename string, avail ...Type) ValidateFunc {
+func (t BoolType) Validator(typename string, avail ...Type) ValidateFunc {
+	return validateFunc(t.Checker(typename, avail...))
+}
+
+// Checker for bool values
+func (BoolType) Checker(typename string, avail ...Type) CheckFunc {
 	if typename != "bool" {
 		return nil
 	}
 
-	return func(value interface{}) (interface{}, bool) {
+	return func(value interface{}) (interface{}, error) {
 		switch cast := value.(type) {
 		case bool:
-			return cast, true
+			return cast, nil
 
 		case string:
-			return cast == "true", cast == "true" || cast == "false"
+			return parseBool(cast)
 
 		case []byte:
-			strVal := string(cast)
-			return strVal == "true", strVal == "true" || strVal == "false"
+			return parseBool(string(cast))
 
 		default:
-			return false, false
+			return false, errNotBoolean
 		}
 	}
 }
+
+// parseBool parses "true" or "false"
+func parseBool(value string) (bool, error) {
+	if value != "true" && value != "false" {
+		return false, errNotBoolean
+	}
+	return value == "true", nil
+}
+
+// Schema describes booleans
+func (BoolType) Schema(typename string, avail ...Type) *Schema {
+	if typename != "bool" {
+		return nil
+	}
+	return &Schema{Type: "boolean"}
+}
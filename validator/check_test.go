@@ -0,0 +1,141 @@
+package validator_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/xdrm-io/aicra/validator"
+)
+
+// uncheckedType handles "unchecked" strings equal to "ok" without describing
+// why other values are invalid
+type uncheckedType struct{}
+
+func (uncheckedType) GoType() reflect.Type {
+	return reflect.TypeOf("")
+}
+func (uncheckedType) Validator(typename string, avail ...validator.Type) validator.ValidateFunc {
+	if typename != "unchecked" {
+		return nil
+	}
+	return func(value interface{}) (interface{}, bool) {
+		return value, value == "ok"
+	}
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	avail := []validator.Type{
+		validator.AnyType{},
+		validator.BoolType{},
+		validator.FloatType{},
+		validator.IntType{},
+		validator.StringType{},
+		validator.UintType{},
+		validator.SliceType{},
+		validator.MapType{},
+		validator.EnumType{},
+		uncheckedType{},
+	}
+
+	tt := []struct {
+		typename string
+		value    interface{}
+		reason   string
+	}{
+		{"any", nil, ""},
+		{"bool", "true", ""},
+		{"bool", "yes", "not a boolean"},
+		{"bool", 1, "not a boolean"},
+		{"float", "1.5", ""},
+		{"float", "abc", "not a number"},
+		{"float", "1e400", "overflows float"},
+		{"int", -12, ""},
+		{"int", "abc", "not an integer"},
+		{"int", 1.5, "not an integer"},
+		{"int", "9223372036854775808", "overflows int"},
+		{"int", uint(math.MaxUint64), "overflows int"},
+		{"uint", "12", ""},
+		{"uint", -1, "negative value"},
+		{"uint", "-1", "negative value"},
+		{"uint", float64(-2), "negative value"},
+		{"uint", "+1", "not an integer"},
+		{"uint", "18446744073709551616", "overflows uint"},
+		{"uint", "abc", "not an integer"},
+		{"string", 12, "not a string"},
+		{"string(3)", "abcd", "length 4 differs from 3"},
+		{"string(3,20)", "ab", "length 2 is below min 3"},
+		{"string(3,20)", "abcdefghijklmnopqrstuvwxy", "length 25 exceeds max 20"},
+		{"string(3,20)", "abc", ""},
+		{"string(/^[a-z-]+$/)", "my-slug", ""},
+		{"string(/^[a-z-]+$/)", "My Slug", "does not match ^[a-z-]+$"},
+		{"string(/^[a-z-]+$/)", 1, "not a string"},
+		{"[]int", []interface{}{1, "a"}, "item 1: not an integer"},
+		{"[]int", nil, "not a list"},
+		{"[][]string(1,2)", []interface{}{[]interface{}{"a"}, []interface{}{"abc"}}, "item 1: item 0: length 3 exceeds max 2"},
+		{"map[string]uint", map[string]interface{}{"a": -1}, `key "a": negative value`},
+		{"map[string]uint", []interface{}{}, "not an object"},
+		{"int(0,100)", "abc", "not an integer"},
+		{"int(0,100)", "-1", "below min 0"},
+		{"int(0,100)", 101, "exceeds max 100"},
+		{"int(>0,<10)", "0", "below min 1"},
+		{"uint(1,)", "0", "below min 1"},
+		{"uint(,<10)", "-1", "negative value"},
+		{"uint(,<10)", 10, "exceeds max 9"},
+		{"float(0,1)", "-0.5", "below min 0"},
+		{"float(0,1)", 1.5, "exceeds max 1"},
+		{"float(>0,<1)", 0, "must be above 0"},
+		{"float(>0,<1)", "1", "must be below 1"},
+		{"float(0,)", "NaN", "not a number"},
+		{"enum(asc,desc)", "up", "not one of asc, desc"},
+		{"enum(asc,desc)", 1, "not a string"},
+		{"[]enum(a,b)", []interface{}{"a", "c"}, "item 1: not one of a, b"},
+		{"unchecked", "ok", ""},
+		{"unchecked", "ko", "invalid value"},
+		{"[]unchecked", []interface{}{"ok", "ko"}, "item 1: invalid value"},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.typename, func(t *testing.T) {
+			t.Parallel()
+
+			check := validator.Check(tc.typename, avail...)
+			if check == nil {
+				t.Fatalf("expected %q to be handled", tc.typename)
+			}
+			_, err := check(tc.value)
+			if len(tc.reason) < 1 {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.reason {
+				t.Fatalf("invalid reason\nactual: %v\nexpect: %s", err, tc.reason)
+			}
+
+			// the ValidateFunc must reject the same values
+			validate, _ := validator.Resolve(tc.typename, avail...)
+			if _, valid := validate(tc.value); valid {
+				t.Fatalf("value %v considered valid by the ValidateFunc", tc.value)
+			}
+		})
+	}
+}
+
+func TestCheckFallback(t *testing.T) {
+	t.Parallel()
+
+	if check := validator.Check("unknown", uncheckedType{}); check != nil {
+		t.Fatalf("expected unknown typename not to be handled")
+	}
+
+	check := validator.Check("unchecked", uncheckedType{})
+	if _, err := check("ko"); !errors.Is(err, validator.ErrInvalidValue) {
+		t.Fatalf("invalid error\nactual: %v\nexpect: %v", err, validator.ErrInvalidValue)
+	}
+}
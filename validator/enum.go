@@ -0,0 +1,97 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// enumRegex matches enum typenames, e.g. "enum(asc,desc)"
+var enumRegex = regexp.MustCompile(`^enum\(([^()]+)\)$`)
+
+// EnumType makes the "enum(a,b,c)" type available in the aicra configuration.
+// It considers valid strings that are one of the listed values, e.g.
+// "enum(asc,desc)" only accepts "asc" and "desc". Spaces around values are
+// ignored, values cannot be empty nor repeated.
+//
+// It considers valid:
+// - strings
+// - []byte
+type EnumType struct{}
+
+// GoType returns the `string` type
+func (EnumType) GoType() reflect.Type {
+	return reflect.TypeOf(string(""))
+}
+
+// Validator for enum values
+func (e EnumType) Validator(typename string, avail ...Type) ValidateFunc {
+	return validateFunc(e.Checker(typename, avail...))
+}
+
+// Checker for enum values, it describes the expected values
+func (e EnumType) Checker(typename string, avail ...Type) CheckFunc {
+	values, ok := e.values(typename)
+	if !ok {
+		return nil
+	}
+
+	var (
+		allowed   = make(map[string]struct{}, len(values))
+		errNotOne = fmt.Errorf("not one of %s", strings.Join(values, ", "))
+	)
+	for _, value := range values {
+		allowed[value] = struct{}{}
+	}
+
+	return func(value interface{}) (interface{}, error) {
+		strValue, isString := value.(string)
+		if byteSliceValue, isByteSlice := value.([]byte); !isString && isByteSlice {
+			strValue = string(byteSliceValue)
+			isString = true
+		}
+		if !isString {
+			return "", errNotString
+		}
+		if _, exists := allowed[strValue]; !exists {
+			return strValue, errNotOne
+		}
+		return strValue, nil
+	}
+}
+
+// values returns the values of an enum typename in order
+func (EnumType) values(typename string) ([]string, bool) {
+	matches := enumRegex.FindStringSubmatch(typename)
+	if matches == nil {
+		return nil, false
+	}
+
+	var (
+		values = strings.Split(matches[1], ",")
+		unique = make(map[string]struct{}, len(values))
+	)
+	for i, value := range values {
+		value = strings.TrimSpace(value)
+		if _, exists := unique[value]; exists || len(value) < 1 {
+			return nil, false
+		}
+		unique[value] = struct{}{}
+		values[i] = value
+	}
+	return values, true
+}
+
+// Schema describes strings along with their values
+func (e EnumType) Schema(typename string, avail ...Type) *Schema {
+	values, ok := e.values(typename)
+	if !ok {
+		return nil
+	}
+	var enum = make([]interface{}, 0, len(values))
+	for _, value := range values {
+		enum = append(enum, value)
+	}
+	return &Schema{Type: "string", Enum: enum}
+}
@@ -0,0 +1,106 @@
+package validator_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/xdrm-io/aicra/validator"
+)
+
+func TestEnum_ReflectType(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dt       = validator.EnumType{}
+		expected = reflect.TypeOf(string(""))
+	)
+	if dt.GoType() != expected {
+		t.Fatalf("invalid GoType() %v ; expected %v", dt.GoType(), expected)
+	}
+}
+
+func TestEnum_AvailableTypes(t *testing.T) {
+	t.Parallel()
+
+	dt := validator.EnumType{}
+
+	tests := []struct {
+		Type    string
+		Handled bool
+	}{
+		{"enum(a)", true},
+		{"enum(asc,desc)", true},
+		{"enum(asc, desc)", true},
+		{"enum(en-US,fr_FR,1)", true},
+		{"enum", false},
+		{"enum()", false},
+		{"enum( )", false},
+		{"enum(a,)", false},
+		{"enum(,a)", false},
+		{"enum(a,a)", false},
+		{"enum(a, a)", false},
+		{"enum(a(b))", false},
+		{"Enum(a)", false},
+		{" enum(a)", false},
+		{"enum(a) ", false},
+		{"enum[a]", false},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.Type, func(t *testing.T) {
+			t.Parallel()
+
+			validator := dt.Validator(test.Type)
+			if validator == nil && test.Handled {
+				t.Fatalf("expect %q to be handled", test.Type)
+			}
+			if validator != nil && !test.Handled {
+				t.Fatalf("expect %q NOT to be handled", test.Type)
+			}
+		})
+	}
+}
+
+func TestEnum_Values(t *testing.T) {
+	t.Parallel()
+
+	const typeName = "enum(asc, desc)"
+
+	validator := validator.EnumType{}.Validator(typeName)
+	if validator == nil {
+		t.Fatalf("expect %q to be handled", typeName)
+	}
+
+	tests := []struct {
+		Value interface{}
+		Valid bool
+	}{
+		{"asc", true},
+		{"desc", true},
+		{[]byte("asc"), true},
+		{"ASC", false},
+		{" asc", false},
+		{"asc, desc", false},
+		{"", false},
+		{1, false},
+		{nil, false},
+		{[]string{"asc"}, false},
+	}
+
+	for i, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			t.Parallel()
+
+			cast, valid := validator(test.Value)
+			if valid != test.Valid {
+				t.Fatalf("invalid validation of %v\nactual: %t\nexpect: %t", test.Value, valid, test.Valid)
+			}
+			if valid && cast != fmt.Sprintf("%s", test.Value) {
+				t.Fatalf("invalid cast\nactual: %v\nexpect: %s", cast, test.Value)
+			}
+		})
+	}
+}
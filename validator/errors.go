@@ -0,0 +1,14 @@
+package validator
+
+// Err allows you to create constant "const" error with type boxing.
+type Err string
+
+func (err Err) Error() string {
+	return string(err)
+}
+
+const (
+	// ErrInvalidValue - value does not fulfill the datatype, returned by the
+	// CheckFunc of types that do not describe why their values are invalid
+	ErrInvalidValue = Err("invalid value")
+)
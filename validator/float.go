@@ -1,11 +1,24 @@
 package validator
 
 import (
+	"errors"
+	"math"
 	"reflect"
 	"strconv"
 )
 
-// FloatType makes the "float" (or "float64") type available in the aicra configuration
+var (
+	errNotNumber     = errors.New("not a number")
+	errFloatOverflow = errors.New("overflows float")
+)
+
+// FloatType makes the types below available in the aicra configuration:
+// - "float" (or "float64") considers any number valid
+// - "float(a,b)" considers any number between `a` and `b` valid, one of the
+// bounds can be omitted, e.g. "float(0,)"
+// > bounds are included unless prefixed with '>' for `a` or '<' for `b`,
+// e.g. "float(>0,<1)"
+//
 // It considers valid:
 // - float64
 // - int (since it does not overflow)
@@ -20,35 +33,92 @@ func (FloatType) GoType() reflect.Type {
 }
 
 // Validator for float64 values
-func (FloatType) Validator(typename string, avail ...Type) ValidateFunc {
-	if typename != "float64" && typename != "float" {
+func (t FloatType) Validator(typename string, avail ...Type) ValidateFunc {
+	return validateFunc(t.Checker(typename, avail...))
+}
+
+// Checker for float64 values, it describes values out of bounds
+func (t FloatType) Checker(typename string, avail ...Type) CheckFunc {
+	if typename == "float64" || typename == "float" {
+		return t.check
+	}
+
+	// nothing if type not handled
+	r, min, max, ok := t.bounds(typename)
+	if !ok {
 		return nil
 	}
-	return func(value interface{}) (interface{}, bool) {
-		switch cast := value.(type) {
+	return func(value interface{}) (interface{}, error) {
+		cast, err := t.check(value)
+		if err != nil {
+			return cast, err
+		}
+		// NaN is neither below nor above any bound
+		if math.IsNaN(cast.(float64)) {
+			return cast, errNotNumber
+		}
+		return cast, checkFloat(cast.(float64), min, max, r.minExclusive, r.maxExclusive)
+	}
+}
 
-		case int:
-			return float64(cast), true
+// bounds returns the range of a ranged typename, e.g. "float(>0,1)", along with
+// its bounds, nil when open
+func (FloatType) bounds(typename string) (numRange, *float64, *float64, bool) {
+	r, ok := parseRange(typename, "float")
+	if !ok {
+		return numRange{}, nil, nil, false
+	}
+	min, max, ok := r.floatBounds()
+	return r, min, max, ok
+}
 
-		case uint:
-			return float64(cast), true
+// check casts a value into a float64
+func (FloatType) check(value interface{}) (interface{}, error) {
+	switch cast := value.(type) {
 
-		case float64:
-			return cast, true
+	case int:
+		return float64(cast), nil
 
-			// serialized string -> try to convert to float
-		case []byte:
-			num, err := strconv.ParseFloat(string(cast), 64)
-			return float64(num), err == nil
+	case uint:
+		return float64(cast), nil
 
-		case string:
-			num, err := strconv.ParseFloat(string(cast), 64)
-			return float64(num), err == nil
+	case float64:
+		return cast, nil
 
-			// unknown type
-		default:
-			return 0, false
+		// serialized string -> try to convert to float
+	case []byte:
+		num, err := strconv.ParseFloat(string(cast), 64)
+		return float64(num), parseError(err, errFloatOverflow, errNotNumber)
 
-		}
+	case string:
+		num, err := strconv.ParseFloat(string(cast), 64)
+		return float64(num), parseError(err, errFloatOverflow, errNotNumber)
+
+		// unknown type
+	default:
+		return 0, errNotNumber
+	}
+}
+
+// Schema describes double precision numbers along with their bounds
+func (t FloatType) Schema(typename string, avail ...Type) *Schema {
+	if typename == "float64" || typename == "float" {
+		return &Schema{Type: "number", Format: "double"}
+	}
+	r, min, max, ok := t.bounds(typename)
+	if !ok {
+		return nil
+	}
+	var schema = &Schema{Type: "number", Format: "double"}
+	if r.minExclusive {
+		schema.ExclusiveMinimum = min
+	} else {
+		schema.Minimum = min
+	}
+	if r.maxExclusive {
+		schema.ExclusiveMaximum = max
+	} else {
+		schema.Maximum = max
 	}
+	return schema
 }
@@ -1,12 +1,25 @@
 package validator
 
 import (
+	"errors"
+	"fmt"
 	"math"
 	"reflect"
 	"strconv"
 )
 
-// IntType makes the "int" type available in the aicra configuration
+var (
+	errNotInteger  = errors.New("not an integer")
+	errIntOverflow = errors.New("overflows int")
+)
+
+// IntType makes the types below available in the aicra configuration:
+// - "int" considers any integer valid
+// - "int(a,b)" considers any integer between `a` and `b` valid, one of the
+// bounds can be omitted, e.g. "int(0,)"
+// > bounds are included unless prefixed with '>' for `a` or '<' for `b`,
+// e.g. "int(>0,<100)"
+//
 // It considers valid:
 // - int
 // - float64 (since it does not overflow)
@@ -21,40 +34,114 @@ func (IntType) GoType() reflect.Type {
 }
 
 // Validator for int values
-func (IntType) Validator(typename string, avail ...Type) ValidateFunc {
+func (t IntType) Validator(typename string, avail ...Type) ValidateFunc {
+	return validateFunc(t.Checker(typename, avail...))
+}
+
+// Checker for int values, it describes overflows, non-integer values and
+// values out of bounds
+func (t IntType) Checker(typename string, avail ...Type) CheckFunc {
+	if typename == "int" {
+		return t.check
+	}
+
 	// nothing if type not handled
-	if typename != "int" {
+	min, max, ok := t.bounds(typename)
+	if !ok {
 		return nil
 	}
+	return func(value interface{}) (interface{}, error) {
+		cast, err := t.check(value)
+		if err != nil {
+			return cast, err
+		}
+		switch num := int64(cast.(int)); {
+		case num < min:
+			return cast, fmt.Errorf("below min %d", min)
+		case num > max:
+			return cast, fmt.Errorf("exceeds max %d", max)
+		}
+		return cast, nil
+	}
+}
 
-	return func(value interface{}) (interface{}, bool) {
-		switch cast := value.(type) {
+// bounds returns the inclusive bounds of a ranged typename, e.g. "int(0,100)"
+func (IntType) bounds(typename string) (int64, int64, bool) {
+	r, ok := parseRange(typename, "int")
+	if !ok {
+		return 0, 0, false
+	}
+	return r.intBounds(math.MinInt64, math.MaxInt64)
+}
 
-		case int:
-			return cast, true
+// check casts a value into an int
+func (IntType) check(value interface{}) (interface{}, error) {
+	switch cast := value.(type) {
 
-		case uint:
-			overflows := cast > math.MaxInt64
-			return int(cast), !overflows
+	case int:
+		return cast, nil
 
-		case float64:
-			intVal := int(cast)
-			overflows := cast < float64(math.MinInt64) || cast > float64(math.MaxInt64)
-			return intVal, cast == float64(intVal) && !overflows
+	case uint:
+		if cast > math.MaxInt64 {
+			return int(cast), errIntOverflow
+		}
+		return int(cast), nil
 
-			// serialized string -> try to convert to int
-		case string:
-			num, err := strconv.ParseInt(cast, 10, 64)
-			return int(num), err == nil
+	case float64:
+		intVal := int(cast)
+		if cast < float64(math.MinInt64) || cast > float64(math.MaxInt64) {
+			return intVal, errIntOverflow
+		}
+		if cast != float64(intVal) {
+			return intVal, errNotInteger
+		}
+		return intVal, nil
 
-			// serialized string -> try to convert to int
-		case []byte:
-			num, err := strconv.ParseInt(string(cast), 10, 64)
-			return int(num), err == nil
+		// serialized string -> try to convert to int
+	case string:
+		num, err := strconv.ParseInt(cast, 10, 64)
+		return int(num), parseError(err, errIntOverflow, errNotInteger)
 
-			// unknown type
-		default:
-			return 0, false
-		}
+		// serialized string -> try to convert to int
+	case []byte:
+		num, err := strconv.ParseInt(string(cast), 10, 64)
+		return int(num), parseError(err, errIntOverflow, errNotInteger)
+
+		// unknown type
+	default:
+		return 0, errNotInteger
+	}
+}
+
+// Schema describes 64-bit integers along with their bounds
+func (t IntType) Schema(typename string, avail ...Type) *Schema {
+	if typename == "int" {
+		return &Schema{Type: "integer", Format: "int64"}
+	}
+	min, max, ok := t.bounds(typename)
+	if !ok {
+		return nil
+	}
+	var schema = &Schema{Type: "integer", Format: "int64"}
+	if min != math.MinInt64 {
+		bound := float64(min)
+		schema.Minimum = &bound
+	}
+	if max != math.MaxInt64 {
+		bound := float64(max)
+		schema.Maximum = &bound
+	}
+	return schema
+}
+
+// parseError returns the reason of a strconv error, `overflow` for out of range
+// values and `invalid` for other errors
+func parseError(err, overflow, invalid error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return overflow
 	}
+	return invalid
 }
@@ -0,0 +1,109 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MapType makes the "map[string]T" types available in the aicra configuration,
+// where T is any typename handled by another available Type ; e.g.
+// "map[string]int" or "map[string][]string".
+//
+// It considers valid json objects (map[string]interface{}) where every value is
+// valid for T. The value is cast into a map of the go type associated with T,
+// e.g. "map[string]int" is cast into map[string]int.
+type MapType struct{}
+
+const mapPrefix = "map[string]"
+
+var errNotObject = errors.New("not an object")
+
+// GoType returns the `map[string]interface{}` type, the actual type depends on
+// the typename, c.f. TypeOf()
+func (MapType) GoType() reflect.Type {
+	return reflect.TypeOf(map[string]interface{}{})
+}
+
+// TypeOf returns the map type of the go type associated with the values
+// typename
+func (MapType) TypeOf(typename string, avail ...Type) reflect.Type {
+	if !strings.HasPrefix(typename, mapPrefix) {
+		return nil
+	}
+	_, valueType := Resolve(strings.TrimPrefix(typename, mapPrefix), avail...)
+	if valueType == nil {
+		return nil
+	}
+	return reflect.MapOf(reflect.TypeOf(""), valueType)
+}
+
+// Validator for maps of any available type
+func (t MapType) Validator(typename string, avail ...Type) ValidateFunc {
+	return validateFunc(t.Checker(typename, avail...))
+}
+
+// Checker for maps of any available type, it describes the first invalid
+// value along with its key
+func (MapType) Checker(typename string, avail ...Type) CheckFunc {
+	if !strings.HasPrefix(typename, mapPrefix) {
+		return nil
+	}
+	var valueTypename = strings.TrimPrefix(typename, mapPrefix)
+	_, valueType := Resolve(valueTypename, avail...)
+	check := Check(valueTypename, avail...)
+	// items without go type, e.g. "any", cannot be stored in a typed map
+	if check == nil || valueType == nil {
+		return nil
+	}
+
+	var mapType = reflect.MapOf(reflect.TypeOf(""), valueType)
+
+	return func(value interface{}) (interface{}, error) {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return reflect.Zero(mapType).Interface(), errNotObject
+		}
+
+		cast := reflect.MakeMapWithSize(mapType, len(object))
+		for key, item := range object {
+			value, err := check(item)
+			if err != nil {
+				return reflect.Zero(mapType).Interface(), fmt.Errorf("key %q: %w", key, err)
+			}
+
+			vvalue := reflect.Zero(valueType)
+			if value != nil {
+				vvalue = reflect.ValueOf(value)
+				if !vvalue.Type().ConvertibleTo(valueType) {
+					return reflect.Zero(mapType).Interface(), fmt.Errorf("key %q: %w", key, ErrInvalidValue)
+				}
+				vvalue = vvalue.Convert(valueType)
+			}
+			cast.SetMapIndex(reflect.ValueOf(key), vvalue)
+		}
+		return cast.Interface(), nil
+	}
+}
+
+// Schema describes objects whose values are described by the Type handling the
+// values typename, it returns nil when the values cannot be described
+func (MapType) Schema(typename string, avail ...Type) *Schema {
+	if !strings.HasPrefix(typename, mapPrefix) {
+		return nil
+	}
+	values := Describe(strings.TrimPrefix(typename, mapPrefix), avail...)
+	if values == nil {
+		return nil
+	}
+	return &Schema{Type: "object", AdditionalProperties: values}
+}
+
+// Explain describes why the values typename is invalid
+func (MapType) Explain(typename string, avail ...Type) error {
+	if !strings.HasPrefix(typename, mapPrefix) {
+		return nil
+	}
+	return Explain(strings.TrimPrefix(typename, mapPrefix), avail...)
+}
@@ -0,0 +1,139 @@
+package validator_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/xdrm-io/aicra/validator"
+)
+
+var mapAvail = []validator.Type{
+	validator.MapType{},
+	validator.SliceType{},
+	validator.IntType{},
+	validator.StringType{},
+	validator.AnyType{},
+}
+
+func TestMap_ReflectType(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dt       = validator.MapType{}
+		expected = reflect.TypeOf(map[string]interface{}{})
+	)
+	if dt.GoType() != expected {
+		t.Fatalf("invalid GoType() %v ; expected %v", dt.GoType(), expected)
+	}
+}
+
+func TestMap_TypeOf(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Type   string
+		GoType reflect.Type
+	}{
+		{"map[string]int", reflect.TypeOf(map[string]int{})},
+		{"map[string]string(3,5)", reflect.TypeOf(map[string]string{})},
+		{"map[string][]int", reflect.TypeOf(map[string][]int{})},
+		{"map[string]map[string]int", reflect.TypeOf(map[string]map[string]int{})},
+		{"map[string]unknown", nil},
+		{"map[string]any", nil},
+		{"map[int]int", nil},
+		{"int", nil},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.Type, func(t *testing.T) {
+			t.Parallel()
+
+			gotype := validator.MapType{}.TypeOf(test.Type, mapAvail...)
+			if gotype != test.GoType {
+				t.Fatalf("invalid go type\nactual: %v\nexpect: %v", gotype, test.GoType)
+			}
+		})
+	}
+}
+
+func TestMap_AvailableTypes(t *testing.T) {
+	t.Parallel()
+
+	dt := validator.MapType{}
+
+	tests := []struct {
+		Type    string
+		Handled bool
+	}{
+		{"map[string]int", true},
+		{"map[string][]string", true},
+		{"map[string]unknown", false},
+		{"map[string]any", false},
+		{"map[string]", false},
+		{"map[int]int", false},
+		{"map", false},
+		{" map[string]int", false},
+		{"map[string]int ", false},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.Type, func(t *testing.T) {
+			t.Parallel()
+
+			validator := dt.Validator(test.Type, mapAvail...)
+			if validator == nil {
+				if test.Handled {
+					t.Errorf("expect %q to be handled", test.Type)
+				}
+				return
+			}
+			if !test.Handled {
+				t.Errorf("expect %q NOT to be handled", test.Type)
+			}
+		})
+	}
+}
+
+func TestMap_Values(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Type   string
+		Value  interface{}
+		Valid  bool
+		Expect interface{}
+	}{
+		{"map[string]int", map[string]interface{}{}, true, map[string]int{}},
+		{"map[string]int", map[string]interface{}{"a": 1, "b": float64(2)}, true, map[string]int{"a": 1, "b": 2}},
+		{"map[string]int", map[string]interface{}{"a": 1, "b": "c"}, false, map[string]int(nil)},
+		{"map[string]string", map[string]interface{}{"a": "b"}, true, map[string]string{"a": "b"}},
+		{"map[string][]int", map[string]interface{}{"a": []interface{}{1, 2}}, true, map[string][]int{"a": {1, 2}}},
+		{"map[string]map[string]int", map[string]interface{}{"a": map[string]interface{}{"b": 1}}, true, map[string]map[string]int{"a": {"b": 1}}},
+		{"map[string]int", []interface{}{1}, false, map[string]int(nil)},
+		{"map[string]int", "a", false, map[string]int(nil)},
+		{"map[string]int", nil, false, map[string]int(nil)},
+	}
+
+	for i, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			t.Parallel()
+
+			validate := validator.MapType{}.Validator(test.Type, mapAvail...)
+			if validate == nil {
+				t.Fatalf("expect %q to be handled", test.Type)
+			}
+
+			cast, valid := validate(test.Value)
+			if valid != test.Valid {
+				t.Fatalf("invalid validity\nactual: %t\nexpect: %t", valid, test.Valid)
+			}
+			if !reflect.DeepEqual(cast, test.Expect) {
+				t.Fatalf("invalid value\nactual: %#v\nexpect: %#v", cast, test.Expect)
+			}
+		})
+	}
+}
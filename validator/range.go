@@ -0,0 +1,121 @@
+package validator
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+)
+
+// rangeRegex matches ranged numeric typenames, e.g. "int(0,100)", "int(0,)" or
+// "float(>0,<1)"
+var rangeRegex = regexp.MustCompile(`^(\w+)\((>?)([^,()<>]*), ?(<?)([^,()<>]*)\)$`)
+
+// numRange defines the bounds of a ranged numeric typename, e.g.
+// "float(>0,100)". Bounds are empty when open and exclusive when prefixed with
+// '>' for the minimum or '<' for the maximum
+type numRange struct {
+	min, max                   string
+	minExclusive, maxExclusive bool
+}
+
+// parseRange extracts the bounds of a ranged typename whose base is `name`,
+// e.g. "int" for "int(0,100)". It fails when the typename does not match or
+// when both bounds are open
+func parseRange(typename, name string) (numRange, bool) {
+	matches := rangeRegex.FindStringSubmatch(typename)
+	if matches == nil || matches[1] != name {
+		return numRange{}, false
+	}
+	r := numRange{
+		minExclusive: matches[2] == ">",
+		min:          matches[3],
+		maxExclusive: matches[4] == "<",
+		max:          matches[5],
+	}
+	// exclusive open bounds are meaningless
+	if (r.minExclusive && len(r.min) < 1) || (r.maxExclusive && len(r.max) < 1) {
+		return numRange{}, false
+	}
+	if len(r.min) < 1 && len(r.max) < 1 {
+		return numRange{}, false
+	}
+	return r, true
+}
+
+// intBounds returns the inclusive integer bounds of the range within [min,max],
+// exclusive bounds being moved to the next integer. It fails on invalid bounds
+// or empty ranges
+func (r numRange) intBounds(min, max int64) (int64, int64, bool) {
+	if len(r.min) > 0 {
+		bound, err := strconv.ParseInt(r.min, 10, 64)
+		if err != nil || (r.minExclusive && bound == math.MaxInt64) {
+			return 0, 0, false
+		}
+		if r.minExclusive {
+			bound++
+		}
+		if bound > min {
+			min = bound
+		}
+	}
+	if len(r.max) > 0 {
+		bound, err := strconv.ParseInt(r.max, 10, 64)
+		if err != nil || (r.maxExclusive && bound == math.MinInt64) {
+			return 0, 0, false
+		}
+		if r.maxExclusive {
+			bound--
+		}
+		if bound < max {
+			max = bound
+		}
+	}
+	return min, max, min <= max
+}
+
+// floatBounds returns the float bounds of the range, nil when open. It fails
+// on invalid bounds or empty ranges
+func (r numRange) floatBounds() (*float64, *float64, bool) {
+	var min, max *float64
+	if len(r.min) > 0 {
+		bound, err := strconv.ParseFloat(r.min, 64)
+		if err != nil {
+			return nil, nil, false
+		}
+		min = &bound
+	}
+	if len(r.max) > 0 {
+		bound, err := strconv.ParseFloat(r.max, 64)
+		if err != nil {
+			return nil, nil, false
+		}
+		max = &bound
+	}
+	if min != nil && max != nil {
+		if *min > *max || (*min == *max && (r.minExclusive || r.maxExclusive)) {
+			return nil, nil, false
+		}
+	}
+	return min, max, true
+}
+
+// checkFloat returns why a value is out of the range bounds, nil when it is
+// within
+func checkFloat(value float64, min, max *float64, minExclusive, maxExclusive bool) error {
+	switch {
+	case min != nil && minExclusive && value <= *min:
+		return fmt.Errorf("must be above %s", formatFloat(*min))
+	case min != nil && value < *min:
+		return fmt.Errorf("below min %s", formatFloat(*min))
+	case max != nil && maxExclusive && value >= *max:
+		return fmt.Errorf("must be below %s", formatFloat(*max))
+	case max != nil && value > *max:
+		return fmt.Errorf("exceeds max %s", formatFloat(*max))
+	}
+	return nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,134 @@
+package validator_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/xdrm-io/aicra/validator"
+)
+
+func TestRange_AvailableTypes(t *testing.T) {
+	t.Parallel()
+
+	avail := []validator.Type{
+		validator.FloatType{},
+		validator.IntType{},
+		validator.UintType{},
+	}
+
+	tests := []struct {
+		Type    string
+		Handled bool
+	}{
+		{"int(0,10)", true},
+		{"int(0, 10)", true},
+		{"int(-10,-5)", true},
+		{"int(0,)", true},
+		{"int(,0)", true},
+		{"int(>0,<10)", true},
+		{"int(5,5)", true},
+		{"int(>4,<6)", true},
+		{"int(,)", false},
+		{"int(>,10)", false},
+		{"int(0,<)", false},
+		{"int(10,0)", false},
+		{"int(>5,<6)", false},
+		{"int(0.5,1)", false},
+		{"int(a,b)", false},
+		{"int(0,10", false},
+		{"int[0,10]", false},
+		{"int(<0,10)", false},
+		{"int(0,>10)", false},
+		{"int(0,9223372036854775808)", false},
+		{"int(,<-9223372036854775808)", false},
+		{"uint(0,10)", true},
+		{"uint(1,)", true},
+		{"uint(,10)", true},
+		{"uint(-1,10)", false},
+		{"uint(,<0)", false},
+		{"float(0,1)", true},
+		{"float(-0.5,1e3)", true},
+		{"float(>0,)", true},
+		{"float(1,1)", true},
+		{"float(>1,1)", false},
+		{"float(1,<1)", false},
+		{"float(2,1)", false},
+		{"float(a,1)", false},
+		{"float64(0,1)", false},
+		{"string(0,1)", false},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.Type, func(t *testing.T) {
+			t.Parallel()
+
+			validate, _ := validator.Resolve(test.Type, avail...)
+			if validate == nil && test.Handled {
+				t.Fatalf("expect %q to be handled", test.Type)
+			}
+			if validate != nil && !test.Handled {
+				t.Fatalf("expect %q NOT to be handled", test.Type)
+			}
+		})
+	}
+}
+
+func TestRange_Values(t *testing.T) {
+	t.Parallel()
+
+	avail := []validator.Type{
+		validator.FloatType{},
+		validator.IntType{},
+		validator.UintType{},
+	}
+
+	tests := []struct {
+		Type  string
+		Value interface{}
+		Valid bool
+	}{
+		{"int(0,10)", 0, true},
+		{"int(0,10)", "10", true},
+		{"int(0,10)", -1, false},
+		{"int(0,10)", 11, false},
+		{"int(0,10)", 5.5, false},
+		{"int(>0,<10)", 0, false},
+		{"int(>0,<10)", 1, true},
+		{"int(>0,<10)", 9, true},
+		{"int(>0,<10)", 10, false},
+		{"int(0,)", math.MaxInt64, true},
+		{"int(,0)", math.MinInt64, true},
+		{"int(,0)", 1, false},
+		{"uint(10,)", uint(math.MaxUint64), true},
+		{"uint(10,)", 9, false},
+		{"uint(,10)", 0, true},
+		{"uint(,10)", "11", false},
+		{"uint(>0,)", 0, false},
+		{"float(0,1)", 0, true},
+		{"float(0,1)", "1", true},
+		{"float(0,1)", 1.0001, false},
+		{"float(>0,<1)", 0.0001, true},
+		{"float(>0,<1)", 0, false},
+		{"float(>0,<1)", 1, false},
+		{"float(0,)", "+Inf", true},
+		{"float(0,1)", "+Inf", false},
+		{"float(0,1)", "NaN", false},
+	}
+
+	for i, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			t.Parallel()
+
+			validate, _ := validator.Resolve(test.Type, avail...)
+			if validate == nil {
+				t.Fatalf("expect %q to be handled", test.Type)
+			}
+			if _, valid := validate(test.Value); valid != test.Valid {
+				t.Fatalf("invalid validation of %v for %q\nactual: %t\nexpect: %t", test.Value, test.Type, valid, test.Valid)
+			}
+		})
+	}
+}
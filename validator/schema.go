@@ -0,0 +1,32 @@
+package validator
+
+// Schema is a JSON Schema description of values, only a subset of the JSON
+// Schema keywords is available
+type Schema struct {
+	// Ref references another schema, other fields are ignored when set
+	Ref         string        `json:"$ref,omitempty"`
+	Type        string        `json:"type,omitempty"`
+	Format      string        `json:"format,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Default     interface{}   `json:"default,omitempty"`
+	Enum        []interface{} `json:"enum,omitempty"`
+
+	// numbers
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+
+	// strings
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+
+	// arrays
+	Items *Schema `json:"items,omitempty"`
+
+	// objects
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
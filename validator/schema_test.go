@@ -0,0 +1,115 @@
+package validator_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/xdrm-io/aicra/validator"
+)
+
+// undescribedType handles "undescribed" without describing its values
+type undescribedType struct{}
+
+func (undescribedType) GoType() reflect.Type {
+	return reflect.TypeOf("")
+}
+func (undescribedType) Validator(typename string, avail ...validator.Type) validator.ValidateFunc {
+	if typename != "undescribed" {
+		return nil
+	}
+	return func(value interface{}) (interface{}, bool) {
+		return value, true
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	avail := []validator.Type{
+		validator.AnyType{},
+		validator.BoolType{},
+		validator.FloatType{},
+		validator.IntType{},
+		validator.StringType{},
+		validator.UintType{},
+		validator.SliceType{},
+		validator.MapType{},
+		validator.EnumType{},
+		undescribedType{},
+	}
+
+	tests := []struct {
+		Type   string
+		Schema string
+	}{
+		{"any", `{}`},
+		{"bool", `{"type":"boolean"}`},
+		{"float", `{"type":"number","format":"double"}`},
+		{"float64", `{"type":"number","format":"double"}`},
+		{"int", `{"type":"integer","format":"int64"}`},
+		{"uint", `{"type":"integer","minimum":0}`},
+		{"string", `{"type":"string"}`},
+		{"string(0)", `{"type":"string","minLength":0,"maxLength":0}`},
+		{"string(16)", `{"type":"string","minLength":16,"maxLength":16}`},
+		{"string(3,20)", `{"type":"string","minLength":3,"maxLength":20}`},
+		{"string(3, 20)", `{"type":"string","minLength":3,"maxLength":20}`},
+		{"string(/^[a-z]+$/)", `{"type":"string","pattern":"^[a-z]+$"}`},
+		{"[]int", `{"type":"array","items":{"type":"integer","format":"int64"}}`},
+		{"[][]string(2)", `{"type":"array","items":{"type":"array","items":{"type":"string","minLength":2,"maxLength":2}}}`},
+		{"map[string]bool", `{"type":"object","additionalProperties":{"type":"boolean"}}`},
+		{"map[string][]uint", `{"type":"object","additionalProperties":{"type":"array","items":{"type":"integer","minimum":0}}}`},
+		{"enum(asc,desc)", `{"type":"string","enum":["asc","desc"]}`},
+		{"[]enum(a, b)", `{"type":"array","items":{"type":"string","enum":["a","b"]}}`},
+		{"int(0,100)", `{"type":"integer","format":"int64","minimum":0,"maximum":100}`},
+		{"int(>0,)", `{"type":"integer","format":"int64","minimum":1}`},
+		{"int(,<0)", `{"type":"integer","format":"int64","maximum":-1}`},
+		{"uint(,10)", `{"type":"integer","minimum":0,"maximum":10}`},
+		{"uint(>10,)", `{"type":"integer","minimum":11}`},
+		{"float(0,1)", `{"type":"number","format":"double","minimum":0,"maximum":1}`},
+		{"float(>0,<1.5)", `{"type":"number","format":"double","exclusiveMinimum":0,"exclusiveMaximum":1.5}`},
+
+		// not described
+		{"undescribed", `null`},
+		{"[]undescribed", `null`},
+		{"map[string]undescribed", `null`},
+		{"unknown", `null`},
+		{"string(a)", `null`},
+		{"string(/a(/)", `null`},
+		{"int(a,b)", `null`},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.Type, func(t *testing.T) {
+			t.Parallel()
+
+			encoded, err := json.Marshal(validator.Describe(test.Type, avail...))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(encoded) != test.Schema {
+				t.Fatalf("invalid schema\nactual: %s\nexpect: %s", encoded, test.Schema)
+			}
+		})
+	}
+}
+
+func TestSchemaUnhandledType(t *testing.T) {
+	t.Parallel()
+
+	for _, dt := range []validator.SchemaType{
+		validator.AnyType{},
+		validator.BoolType{},
+		validator.FloatType{},
+		validator.IntType{},
+		validator.StringType{},
+		validator.UintType{},
+		validator.SliceType{},
+		validator.MapType{},
+	} {
+		if schema := dt.Schema("unknown"); schema != nil {
+			t.Fatalf("%T must not describe unknown types, got %v", dt, schema)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var errNotList = errors.New("not a list")
+
+// SliceType makes the "[]T" types available in the aicra configuration, where
+// T is any typename handled by another available Type ; e.g. "[]int",
+// "[]string(3,20)" or "[][]bool".
+//
+// It considers valid:
+// - []interface{} (json arrays) where every item is valid for T
+// - []string (repeated query or form values) where every item is valid for T
+// - a single value valid for T (single query or form value)
+//
+// The value is cast into a slice of the go type associated with T, e.g.
+// "[]int" is cast into []int.
+type SliceType struct{}
+
+// GoType returns the `[]interface{}` type, the actual type depends on the
+// typename, c.f. TypeOf()
+func (SliceType) GoType() reflect.Type {
+	return reflect.TypeOf([]interface{}{})
+}
+
+// TypeOf returns the slice type of the go type associated with the items
+// typename
+func (SliceType) TypeOf(typename string, avail ...Type) reflect.Type {
+	if !strings.HasPrefix(typename, "[]") {
+		return nil
+	}
+	_, itemType := Resolve(strings.TrimPrefix(typename, "[]"), avail...)
+	if itemType == nil {
+		return nil
+	}
+	return reflect.SliceOf(itemType)
+}
+
+// Validator for slices of any available type
+func (t SliceType) Validator(typename string, avail ...Type) ValidateFunc {
+	return validateFunc(t.Checker(typename, avail...))
+}
+
+// Checker for slices of any available type, it describes the first invalid
+// item along with its index
+func (SliceType) Checker(typename string, avail ...Type) CheckFunc {
+	if !strings.HasPrefix(typename, "[]") {
+		return nil
+	}
+	var itemTypename = strings.TrimPrefix(typename, "[]")
+	_, itemType := Resolve(itemTypename, avail...)
+	check := Check(itemTypename, avail...)
+	// items without go type, e.g. "any", cannot be stored in a typed slice
+	if check == nil || itemType == nil {
+		return nil
+	}
+
+	var sliceType = reflect.SliceOf(itemType)
+
+	// cast checks every item and builds the typed slice
+	cast := func(items []interface{}) (interface{}, error) {
+		slice := reflect.MakeSlice(sliceType, len(items), len(items))
+		for i, item := range items {
+			value, err := check(item)
+			if err != nil {
+				return reflect.Zero(sliceType).Interface(), fmt.Errorf("item %d: %w", i, err)
+			}
+			if value == nil {
+				continue
+			}
+			vvalue := reflect.ValueOf(value)
+			if !vvalue.Type().ConvertibleTo(itemType) {
+				return reflect.Zero(sliceType).Interface(), fmt.Errorf("item %d: %w", i, ErrInvalidValue)
+			}
+			slice.Index(i).Set(vvalue.Convert(itemType))
+		}
+		return slice.Interface(), nil
+	}
+
+	return func(value interface{}) (interface{}, error) {
+		switch typed := value.(type) {
+		case []interface{}:
+			return cast(typed)
+
+		case []string:
+			items := make([]interface{}, len(typed))
+			for i, item := range typed {
+				items[i] = item
+			}
+			return cast(items)
+
+		case nil:
+			return reflect.Zero(sliceType).Interface(), errNotList
+
+		// single value
+		default:
+			return cast([]interface{}{typed})
+		}
+	}
+}
+
+// Schema describes arrays whose items are described by the Type handling the
+// items typename, it returns nil when the items cannot be described
+func (SliceType) Schema(typename string, avail ...Type) *Schema {
+	if !strings.HasPrefix(typename, "[]") {
+		return nil
+	}
+	items := Describe(strings.TrimPrefix(typename, "[]"), avail...)
+	if items == nil {
+		return nil
+	}
+	return &Schema{Type: "array", Items: items}
+}
+
+// Explain describes why the items typename is invalid
+func (SliceType) Explain(typename string, avail ...Type) error {
+	if !strings.HasPrefix(typename, "[]") {
+		return nil
+	}
+	return Explain(strings.TrimPrefix(typename, "[]"), avail...)
+}
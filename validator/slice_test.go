@@ -0,0 +1,156 @@
+package validator_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/xdrm-io/aicra/validator"
+)
+
+var sliceAvail = []validator.Type{
+	validator.SliceType{},
+	validator.IntType{},
+	validator.StringType{},
+	validator.BoolType{},
+	validator.AnyType{},
+}
+
+func TestSlice_ReflectType(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dt       = validator.SliceType{}
+		expected = reflect.TypeOf([]interface{}{})
+	)
+	if dt.GoType() != expected {
+		t.Fatalf("invalid GoType() %v ; expected %v", dt.GoType(), expected)
+	}
+}
+
+func TestSlice_TypeOf(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Type   string
+		GoType reflect.Type
+	}{
+		{"[]int", reflect.TypeOf([]int{})},
+		{"[]string", reflect.TypeOf([]string{})},
+		{"[]string(3,5)", reflect.TypeOf([]string{})},
+		{"[]bool", reflect.TypeOf([]bool{})},
+		{"[][]int", reflect.TypeOf([][]int{})},
+		{"[]unknown", nil},
+		{"[]any", nil},
+		{"int", nil},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.Type, func(t *testing.T) {
+			t.Parallel()
+
+			gotype := validator.SliceType{}.TypeOf(test.Type, sliceAvail...)
+			if gotype != test.GoType {
+				t.Fatalf("invalid go type\nactual: %v\nexpect: %v", gotype, test.GoType)
+			}
+		})
+	}
+}
+
+func TestSlice_AvailableTypes(t *testing.T) {
+	t.Parallel()
+
+	dt := validator.SliceType{}
+
+	tests := []struct {
+		Type    string
+		Handled bool
+	}{
+		{"[]int", true},
+		{"[]string", true},
+		{"[]string(10)", true},
+		{"[][]bool", true},
+		{"[]unknown", false},
+		{"[]any", false},
+		{"[]", false},
+		{"int", false},
+		{"[] int", false},
+		{" []int", false},
+		{"[]int ", false},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.Type, func(t *testing.T) {
+			t.Parallel()
+
+			validator := dt.Validator(test.Type, sliceAvail...)
+			if validator == nil {
+				if test.Handled {
+					t.Errorf("expect %q to be handled", test.Type)
+				}
+				return
+			}
+			if !test.Handled {
+				t.Errorf("expect %q NOT to be handled", test.Type)
+			}
+		})
+	}
+}
+
+func TestSlice_Values(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Type   string
+		Value  interface{}
+		Valid  bool
+		Expect interface{}
+	}{
+		// json arrays
+		{"[]int", []interface{}{}, true, []int{}},
+		{"[]int", []interface{}{1, 2, 3}, true, []int{1, 2, 3}},
+		{"[]int", []interface{}{float64(1), float64(2)}, true, []int{1, 2}},
+		{"[]int", []interface{}{1, "b"}, false, []int(nil)},
+		{"[]int", []interface{}{1.5}, false, []int(nil)},
+		{"[]string", []interface{}{"a", "b"}, true, []string{"a", "b"}},
+		{"[]string(1)", []interface{}{"a", "bc"}, false, []string(nil)},
+		{"[]bool", []interface{}{true, false}, true, []bool{true, false}},
+		{"[][]int", []interface{}{[]interface{}{1}, []interface{}{2, 3}}, true, [][]int{{1}, {2, 3}}},
+		{"[][]int", []interface{}{[]interface{}{1}, 2}, true, [][]int{{1}, {2}}},
+		{"[][]int", []interface{}{[]interface{}{"a"}}, false, [][]int(nil)},
+
+		// repeated query and form values
+		{"[]int", []string{"1", "2"}, true, []int{1, 2}},
+		{"[]int", []string{"1", "a"}, false, []int(nil)},
+		{"[]string", []string{"a", "b"}, true, []string{"a", "b"}},
+		{"[]bool", []string{"true", "false"}, true, []bool{true, false}},
+
+		// single values
+		{"[]int", 1, true, []int{1}},
+		{"[]string", "a", true, []string{"a"}},
+		{"[]int", "a", false, []int(nil)},
+		{"[]int", nil, false, []int(nil)},
+	}
+
+	for i, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			t.Parallel()
+
+			validate := validator.SliceType{}.Validator(test.Type, sliceAvail...)
+			if validate == nil {
+				t.Fatalf("expect %q to be handled", test.Type)
+			}
+
+			cast, valid := validate(test.Value)
+			if valid != test.Valid {
+				t.Fatalf("invalid validity\nactual: %t\nexpect: %t", valid, test.Valid)
+			}
+			if !reflect.DeepEqual(cast, test.Expect) {
+				t.Fatalf("invalid value\nactual: %#v\nexpect: %#v", cast, test.Expect)
+			}
+		})
+	}
+}
@@ -1,14 +1,19 @@
 package validator
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
 	"regexp"
 	"strconv"
 )
 
 var (
+	errNotString = errors.New("not a string")
+
 	fixedLengthRegex    = regexp.MustCompile(`^string\((\d+)\)$`)
 	variableLengthRegex = regexp.MustCompile(`^string\((\d+), ?(\d+)\)$`)
+	patternRegex        = regexp.MustCompile(`^string\(/(.+)/\)$`)
 )
 
 // StringType makes the types beloz available in the aicra configuration:
@@ -16,6 +21,9 @@ var (
 // - "string(n)" considers any string with an exact size of `n` valid
 // - "string(a,b)" considers any string with a size between `a` and `b` valid
 // > for the last one, `a` and `b` are included in the valid sizes
+// - "string(/re/)" considers any string matching the regular expression `re`
+// valid, e.g. "string(/^[a-z0-9-]+$/)"
+// > as for json schema patterns, the expression is not implicitly anchored
 type StringType struct{}
 
 // GoType returns the `string` type
@@ -25,6 +33,18 @@ func (StringType) GoType() reflect.Type {
 
 // Validator for strings with any/fixed/bound sizes
 func (s StringType) Validator(typename string, avail ...Type) ValidateFunc {
+	return validateFunc(s.Checker(typename, avail...))
+}
+
+// Checker for strings with any/fixed/bound sizes or matching a pattern, it
+// describes lengths out of bounds and mismatching patterns
+func (s StringType) Checker(typename string, avail ...Type) CheckFunc {
+	// the pattern is compiled once for all values
+	pattern, err := s.pattern(typename)
+	if err != nil {
+		return nil
+	}
+
 	var (
 		simple                = (typename == "string")
 		fixedLengthMatches    = fixedLengthRegex.FindStringSubmatch(typename)
@@ -32,7 +52,7 @@ func (s StringType) Validator(typename string, avail ...Type) ValidateFunc {
 	)
 
 	// ignore unknown typename
-	if !simple && fixedLengthMatches == nil && variableLengthMatches == nil {
+	if !simple && pattern == nil && fixedLengthMatches == nil && variableLengthMatches == nil {
 		return nil
 	}
 
@@ -60,10 +80,10 @@ func (s StringType) Validator(typename string, avail ...Type) ValidateFunc {
 		max = exMax
 	}
 
-	return func(value interface{}) (interface{}, bool) {
+	return func(value interface{}) (interface{}, error) {
 		// preprocessing error
 		if mustFail {
-			return "", false
+			return "", ErrInvalidValue
 		}
 
 		// check type
@@ -75,19 +95,51 @@ func (s StringType) Validator(typename string, avail ...Type) ValidateFunc {
 		}
 
 		if !isString {
-			return "", false
+			return "", errNotString
 		}
 
 		if simple {
-			return strValue, true
+			return strValue, nil
+		}
+
+		if pattern != nil {
+			if !pattern.MatchString(strValue) {
+				return strValue, fmt.Errorf("does not match %s", pattern)
+			}
+			return strValue, nil
 		}
 
 		// check length against previously extracted length
 		l := len(strValue)
-		return strValue, l >= min && l <= max
+		switch {
+		case min == max && l != min:
+			return strValue, fmt.Errorf("length %d differs from %d", l, min)
+		case l < min:
+			return strValue, fmt.Errorf("length %d is below min %d", l, min)
+		case l > max:
+			return strValue, fmt.Errorf("length %d exceeds max %d", l, max)
+		}
+		return strValue, nil
 	}
 }
 
+// pattern returns the compiled regular expression of a pattern typename, e.g.
+// "string(/^[a-z]+$/)", it returns nil for other typenames and fails when the
+// expression is invalid
+func (StringType) pattern(typename string) (*regexp.Regexp, error) {
+	matches := patternRegex.FindStringSubmatch(typename)
+	if matches == nil {
+		return nil, nil
+	}
+	return regexp.Compile(matches[1])
+}
+
+// Explain describes invalid regular expressions of pattern typenames
+func (s StringType) Explain(typename string, avail ...Type) error {
+	_, err := s.pattern(typename)
+	return err
+}
+
 // getFixedLength returns the fixed length from regex matches and a success state.
 func (StringType) getFixedLength(regexMatches []string) (int, bool) {
 	// incoherence error
@@ -124,3 +176,38 @@ func (StringType) getVariableLength(regexMatches []string) (int, int, bool) {
 
 	return int(minLen), int(maxLen), true
 }
+
+// Schema describes strings along with their length bounds or their pattern
+func (s StringType) Schema(typename string, avail ...Type) *Schema {
+	if typename == "string" {
+		return &Schema{Type: "string"}
+	}
+	if pattern, err := s.pattern(typename); err != nil {
+		return nil
+	} else if pattern != nil {
+		return &Schema{Type: "string", Pattern: pattern.String()}
+	}
+	var (
+		fixedLengthMatches    = fixedLengthRegex.FindStringSubmatch(typename)
+		variableLengthMatches = variableLengthRegex.FindStringSubmatch(typename)
+	)
+
+	var min, max int
+	switch {
+	case fixedLengthMatches != nil:
+		exLen, ok := s.getFixedLength(fixedLengthMatches)
+		if !ok {
+			return nil
+		}
+		min, max = exLen, exLen
+	case variableLengthMatches != nil:
+		exMin, exMax, ok := s.getVariableLength(variableLengthMatches)
+		if !ok {
+			return nil
+		}
+		min, max = exMin, exMax
+	default:
+		return nil
+	}
+	return &Schema{Type: "string", MinLength: &min, MaxLength: &max}
+}
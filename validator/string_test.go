@@ -62,6 +62,17 @@ func TestString_AvailableTypes(t *testing.T) {
 		{"string( 1, 2)", false},
 		{"string(1, 2 )", false},
 		{"string( 1, 2 )", false},
+
+		{"string(/^[a-z]+$/)", true},
+		{"string(/[a-z]+/)", true},
+		{"string(/(a|b),c/)", true},
+		{"string(/a/b/)", true},
+		{"string(//)", false},
+		{"string(/)", false},
+		{"string(/[a-z/)", false},
+		{"string(/a(/)", false},
+		{"string(^[a-z]+$)", false},
+		{"string(/a/ )", false},
 	}
 
 	for _, test := range tests {
@@ -230,3 +241,75 @@ func TestString_VariableLength(t *testing.T) {
 	}
 
 }
+
+func TestString_Pattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Type  string
+		Value interface{}
+		Valid bool
+	}{
+		{"string(/^[a-z0-9-]+$/)", "my-slug-2", true},
+		{"string(/^[a-z0-9-]+$/)", []byte("my-slug"), true},
+		{"string(/^[a-z0-9-]+$/)", "My-Slug", false},
+		{"string(/^[a-z0-9-]+$/)", "", false},
+		{"string(/^[a-z0-9-]+$/)", 12, false},
+		{"string(/^#[0-9a-f]{6}$/)", "#00ff7f", true},
+		{"string(/^#[0-9a-f]{6}$/)", "#00ff7f0", false},
+		// patterns are not implicitly anchored
+		{"string(/[0-9]/)", "abc1", true},
+		{"string(/[0-9]/)", "abc", false},
+	}
+
+	for i, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			t.Parallel()
+
+			validator := validator.StringType{}.Validator(test.Type)
+			if validator == nil {
+				t.Fatalf("expect %q to be handled", test.Type)
+			}
+			if _, isValid := validator(test.Value); isValid != test.Valid {
+				t.Fatalf("invalid validation\nactual: %v\nexpect: %v", isValid, test.Valid)
+			}
+		})
+	}
+}
+
+func TestString_Explain(t *testing.T) {
+	t.Parallel()
+
+	avail := []validator.Type{
+		validator.StringType{},
+		validator.SliceType{},
+		validator.MapType{},
+	}
+
+	tests := []struct {
+		Type    string
+		Explain bool
+	}{
+		{"string", false},
+		{"string(/^[a-z]+$/)", false},
+		{"string(a)", false},
+		{"unknown", false},
+		{"string(/[a-z/)", true},
+		{"string(/a(/)", true},
+		{"[]string(/a(/)", true},
+		{"map[string][]string(/a(/)", true},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.Type, func(t *testing.T) {
+			t.Parallel()
+
+			err := validator.Explain(test.Type, avail...)
+			if (err != nil) != test.Explain {
+				t.Fatalf("invalid explanation\nactual: %v\nexpect: %v", err, test.Explain)
+			}
+		})
+	}
+}
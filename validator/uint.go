@@ -1,12 +1,26 @@
 package validator
 
 import (
+	"errors"
+	"fmt"
 	"math"
 	"reflect"
 	"strconv"
+	"strings"
 )
 
-// UintType makes the "uint" type available in the aicra configuration
+var (
+	errNegative     = errors.New("negative value")
+	errUintOverflow = errors.New("overflows uint")
+)
+
+// UintType makes the types below available in the aicra configuration:
+// - "uint" considers any positive integer valid
+// - "uint(a,b)" considers any positive integer between `a` and `b` valid, one
+// of the bounds can be omitted, e.g. "uint(1,)"
+// > bounds are included unless prefixed with '>' for `a` or '<' for `b`,
+// e.g. "uint(>0,<100)"
+//
 // It considers valid:
 // - uint
 // - int (since it does not overflow)
@@ -21,37 +35,125 @@ func (UintType) GoType() reflect.Type {
 }
 
 // Validator for uint values
-func (UintType) Validator(other string, avail ...Type) ValidateFunc {
-	if other != "uint" {
+func (t UintType) Validator(other string, avail ...Type) ValidateFunc {
+	return validateFunc(t.Checker(other, avail...))
+}
+
+// Checker for uint values, it describes negative values, overflows,
+// non-integer values and values out of bounds
+func (t UintType) Checker(other string, avail ...Type) CheckFunc {
+	if other == "uint" {
+		return t.check
+	}
+
+	// nothing if type not handled
+	min, max, ok := t.bounds(other)
+	if !ok {
 		return nil
 	}
+	return func(value interface{}) (interface{}, error) {
+		cast, err := t.check(value)
+		if err != nil {
+			return cast, err
+		}
+		switch num := cast.(uint); {
+		case num < min:
+			return cast, fmt.Errorf("below min %d", min)
+		case num > max:
+			return cast, fmt.Errorf("exceeds max %d", max)
+		}
+		return cast, nil
+	}
+}
+
+// bounds returns the inclusive bounds of a ranged typename, e.g. "uint(1,100)"
+func (UintType) bounds(typename string) (uint, uint, bool) {
+	r, ok := parseRange(typename, "uint")
+	if !ok || strings.HasPrefix(r.min, "-") || strings.HasPrefix(r.max, "-") {
+		return 0, 0, false
+	}
+	min, max, ok := r.intBounds(0, math.MaxInt64)
+	if !ok {
+		return 0, 0, false
+	}
+	// open maximum
+	if len(r.max) < 1 {
+		return uint(min), math.MaxUint64, true
+	}
+	return uint(min), uint(max), true
+}
 
-	return func(value interface{}) (interface{}, bool) {
-		switch cast := value.(type) {
+// check casts a value into an uint
+func (UintType) check(value interface{}) (interface{}, error) {
+	switch cast := value.(type) {
 
-		case int:
-			return uint(cast), cast >= 0
+	case int:
+		if cast < 0 {
+			return uint(cast), errNegative
+		}
+		return uint(cast), nil
+
+	case uint:
+		return cast, nil
 
-		case uint:
-			return cast, true
+	case float64:
+		uintVal := uint(cast)
+		if cast < 0 {
+			return uintVal, errNegative
+		}
+		if cast > math.MaxUint64 {
+			return uintVal, errUintOverflow
+		}
+		if cast != float64(uintVal) {
+			return uintVal, errNotInteger
+		}
+		return uintVal, nil
 
-		case float64:
-			uintVal := uint(cast)
-			overflows := cast < 0 || cast > math.MaxUint64
-			return uintVal, cast == float64(uintVal) && !overflows
+		// serialized string -> try to convert to float
+	case string:
+		num, err := strconv.ParseUint(cast, 10, 64)
+		return uint(num), uintParseError(cast, err)
 
-			// serialized string -> try to convert to float
-		case string:
-			num, err := strconv.ParseUint(cast, 10, 64)
-			return int(num), err == nil
+	case []byte:
+		num, err := strconv.ParseUint(string(cast), 10, 64)
+		return uint(num), uintParseError(string(cast), err)
 
-		case []byte:
-			num, err := strconv.ParseUint(string(cast), 10, 64)
-			return int(num), err == nil
+		// unknown type
+	default:
+		return uint(0), errNotInteger
+	}
+}
 
-			// unknown type
-		default:
-			return 0, false
+// uintParseError returns the reason of a strconv error, negative integers
+// being described as such
+func uintParseError(value string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if strings.HasPrefix(value, "-") {
+		if _, serr := strconv.ParseInt(value, 10, 64); serr == nil || errors.Is(serr, strconv.ErrRange) {
+			return errNegative
 		}
 	}
+	return parseError(err, errUintOverflow, errNotInteger)
+}
+
+// Schema describes positive integers along with their bounds
+func (t UintType) Schema(typename string, avail ...Type) *Schema {
+	if typename == "uint" {
+		var min float64
+		return &Schema{Type: "integer", Minimum: &min}
+	}
+	min, max, ok := t.bounds(typename)
+	if !ok {
+		return nil
+	}
+	var schema = &Schema{Type: "integer"}
+	lower := float64(min)
+	schema.Minimum = &lower
+	if max != math.MaxUint64 {
+		upper := float64(max)
+		schema.Maximum = &upper
+	}
+	return schema
 }
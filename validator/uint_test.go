@@ -125,3 +125,40 @@ func TestUint_Values(t *testing.T) {
 	}
 
 }
+
+func TestUint_Cast(t *testing.T) {
+	t.Parallel()
+
+	validator := validator.UintType{}.Validator("uint")
+	if validator == nil {
+		t.Fatalf("expect %q to be handled", "uint")
+	}
+
+	tests := []struct {
+		Value  interface{}
+		Expect uint
+	}{
+		{uint(12), 12},
+		{int(12), 12},
+		{float64(12), 12},
+		{"12", 12},
+		{[]byte("12"), 12},
+		{fmt.Sprintf("%d", uint(math.MaxUint64)), math.MaxUint64},
+	}
+
+	for i, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			t.Parallel()
+
+			// values must be cast into the GoType whatever their input type
+			cast, isValid := validator(test.Value)
+			if !isValid {
+				t.Fatalf("expect value to be valid")
+			}
+			if cast != test.Expect {
+				t.Fatalf("invalid cast\nactual: %#v\nexpect: %#v", cast, test.Expect)
+			}
+		})
+	}
+}
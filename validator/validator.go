@@ -12,6 +12,14 @@ import (
 // will be cast into a go type, say, string.
 type ValidateFunc func(value interface{}) (cast interface{}, valid bool)
 
+// CheckFunc is the error-returning counterpart of ValidateFunc: it casts the
+// value into a go type or returns an error describing why the value does not
+// fulfill the datatype, e.g. "length 25 exceeds max 20".
+//
+// Errors are sent to clients, they should describe the expectation and must
+// not contain the value itself.
+type CheckFunc func(value interface{}) (cast interface{}, err error)
+
 // Type defines an available innput parameter "type" for the aicra configuration
 //
 // A Type maps to a go type in order to generate the handler signature from the
@@ -53,3 +61,123 @@ type Type interface {
 	// It is used to define handlers' signature from the configuration file.
 	GoType() reflect.Type
 }
+
+// TypeResolver can be implemented by a Type whose go type depends on the
+// typename, e.g. slices of other types. It takes precedence over GoType() when
+// resolving a typename.
+type TypeResolver interface {
+	// TypeOf returns the go type associated with a typename handled by the
+	// Type's Validator(), `avail` being all available Types
+	TypeOf(typename string, avail ...Type) reflect.Type
+}
+
+// Resolve finds the first available Type handling a typename and returns its
+// ValidateFunc along with the associated go type. It returns a nil ValidateFunc
+// when no Type handles the typename.
+func Resolve(typename string, avail ...Type) (ValidateFunc, reflect.Type) {
+	for _, t := range avail {
+		validate := t.Validator(typename, avail...)
+		if validate == nil {
+			continue
+		}
+		if resolver, ok := t.(TypeResolver); ok {
+			return validate, resolver.TypeOf(typename, avail...)
+		}
+		return validate, t.GoType()
+	}
+	return nil, nil
+}
+
+// SchemaType can be implemented by a Type to describe the values accepted for a
+// typename, e.g. to generate documentation
+type SchemaType interface {
+	// Schema returns the description of the values accepted for a typename
+	// handled by the Type's Validator(), `avail` being all available Types. It
+	// returns nil when the values cannot be described.
+	Schema(typename string, avail ...Type) *Schema
+}
+
+// Describe finds the first available Type handling a typename and returns the
+// description of the values it accepts. It returns nil when no Type handles the
+// typename or when the Type does not describe its values.
+func Describe(typename string, avail ...Type) *Schema {
+	for _, t := range avail {
+		if t.Validator(typename, avail...) == nil {
+			continue
+		}
+		if describer, ok := t.(SchemaType); ok {
+			return describer.Schema(typename, avail...)
+		}
+		return nil
+	}
+	return nil
+}
+
+// CheckType can be implemented by a Type to describe why values are invalid
+type CheckType interface {
+	// Checker returns the CheckFunc of a typename handled by the Type's
+	// Validator(), `avail` being all available Types. It must accept and cast
+	// the same values as the ValidateFunc.
+	Checker(typename string, avail ...Type) CheckFunc
+}
+
+// Check finds the first available Type handling a typename and returns its
+// CheckFunc. Types that do not implement CheckType are checked with their
+// ValidateFunc, invalid values failing with ErrInvalidValue. It returns nil
+// when no Type handles the typename.
+func Check(typename string, avail ...Type) CheckFunc {
+	for _, t := range avail {
+		validate := t.Validator(typename, avail...)
+		if validate == nil {
+			continue
+		}
+		if checker, ok := t.(CheckType); ok {
+			if check := checker.Checker(typename, avail...); check != nil {
+				return check
+			}
+		}
+		return func(value interface{}) (interface{}, error) {
+			cast, valid := validate(value)
+			if !valid {
+				return cast, ErrInvalidValue
+			}
+			return cast, nil
+		}
+	}
+	return nil
+}
+
+// ExplainType can be implemented by a Type to explain why it does not handle a
+// typename it recognizes, e.g. a pattern with an invalid regular expression
+type ExplainType interface {
+	// Explain returns why a typename is invalid, it returns nil when the
+	// typename is valid or not recognized by the Type.
+	Explain(typename string, avail ...Type) error
+}
+
+// Explain returns why a typename is not handled by the available Types, it
+// returns nil when no Type explains it.
+func Explain(typename string, avail ...Type) error {
+	for _, t := range avail {
+		explainer, ok := t.(ExplainType)
+		if !ok {
+			continue
+		}
+		if err := explainer.Explain(typename, avail...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateFunc returns the ValidateFunc of a CheckFunc, nil when the CheckFunc is
+// nil
+func validateFunc(check CheckFunc) ValidateFunc {
+	if check == nil {
+		return nil
+	}
+	return func(value interface{}) (interface{}, bool) {
+		cast, err := check(value)
+		return cast, err == nil
+	}
+}